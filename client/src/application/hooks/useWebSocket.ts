@@ -4,12 +4,48 @@ import { useRoomStore } from '@/application/stores/roomStore';
 import { useChatStore } from '@/application/stores/chatStore';
 import { useSettingsStore } from '@/application/stores/settingsStore';
 import { getToneGenerator } from '@/infrastructure/audio/toneGenerator';
-import type { WebSocketMessage, JoinRoomResponse, ChatMessage, SystemMessage, DisplayMessage } from '@/domain/types';
+import type { WebSocketMessage, JoinRoomResponse, ChatMessage, SystemMessage, DisplayMessage, ErrorResponse } from '@/domain/types';
 
 // Track processed message IDs to prevent duplicate processing
 const processedMessageIds = new Set<string>();
 const MAX_PROCESSED_IDS = 1000; // Limit to prevent memory leak
 
+// Convert a server system message to client format. Join and leave get their own rendering;
+// every other code is shown as the text the server already localized. Only older messages
+// without a code fall back to reading the content.
+function toSystemMessage(msg: any): SystemMessage {
+  const content: string = msg.content || '';
+  let type: SystemMessage['type'];
+  switch (msg.code) {
+    case 'user_joined':
+    case 'user_left':
+      type = msg.code;
+      break;
+    case undefined:
+    case null:
+    case '':
+      if (content.includes('joined')) {
+        type = 'user_joined';
+      } else if (content.includes('left')) {
+        type = 'user_left';
+      } else {
+        type = 'event';
+      }
+      break;
+    default:
+      type = 'event';
+  }
+
+  return {
+    id: msg.id,
+    type,
+    userId: msg.sender_id,
+    userName: msg.sender_name,
+    content,
+    timestamp: msg.timestamp,
+  };
+}
+
 export function useWebSocket() {
   const wsService = useRef(getWebSocketService());
   const handleMessageRef = useRef<((message: WebSocketMessage) => void) | null>(null);
@@ -233,21 +269,7 @@ export function useWebSocket() {
               };
               addChatMessage(chatMessage);
             } else if (msg.type === 'system') {
-              // System message (join/leave)
-              const content = msg.content || '';
-              let eventType: 'user_joined' | 'user_left' = 'user_joined';
-              if (content.includes('left')) {
-                eventType = 'user_left';
-              }
-
-              const systemMessage: SystemMessage = {
-                id: msg.id,
-                type: eventType,
-                userId: msg.sender_id,
-                userName: msg.sender_name,
-                timestamp: msg.timestamp,
-              };
-              addSystemMessage(systemMessage);
+              addSystemMessage(toSystemMessage(msg));
             }
           })();
           break;
@@ -281,19 +303,7 @@ export function useWebSocket() {
                 };
                 return { kind: 'chat' as const, message: chatMessage };
               } else {
-                const content = msg.content || '';
-                let eventType: 'user_joined' | 'user_left' = 'user_joined';
-                if (content.includes('left')) {
-                  eventType = 'user_left';
-                }
-                const systemMessage: SystemMessage = {
-                  id: msg.id,
-                  type: eventType,
-                  userId: msg.sender_id,
-                  userName: msg.sender_name,
-                  timestamp: msg.timestamp,
-                };
-                return { kind: 'system' as const, message: systemMessage };
+                return { kind: 'system' as const, message: toSystemMessage(msg) };
               }
             });
             loadHistory(displayMessages);
@@ -329,8 +339,8 @@ export function useWebSocket() {
           break;
 
         case 'error':
-          const errorPayload = payload as { message: string };
-          setError(errorPayload.message);
+          const errorPayload = payload as ErrorResponse;
+          setError(errorPayload.detail ? `${errorPayload.message} (${errorPayload.detail})` : errorPayload.message);
           break;
       }
     },
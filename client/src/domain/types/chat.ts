@@ -31,9 +31,10 @@ export interface ChatMessage {
 // System message (join/leave)
 export interface SystemMessage {
   id: string;
-  type: 'user_joined' | 'user_left';
+  type: 'user_joined' | 'user_left' | 'event'; // 'event' covers every other system code
   userId: string;
   userName: string;
+  content: string; // Server text, already in the client's locale
   timestamp: number;
 }
 
@@ -118,6 +118,7 @@ export interface WebSocketMessage<T = unknown> {
 export interface ErrorResponse {
   code: string;
   message: string;
+  detail?: string; // The server's own English text, when the message was translated
 }
 
 // Admin stats
@@ -10,15 +10,18 @@ function formatTime(timestamp: number): string {
 }
 
 export function SystemMessage({ message }: SystemMessageProps) {
-  const icon = message.type === 'user_joined' ? '→' : '←';
-  const action = message.type === 'user_joined' ? 'joined' : 'left';
+  let icon = '•';
+  let text = <>{message.content}</>;
+  if (message.type === 'user_joined' || message.type === 'user_left') {
+    const action = message.type === 'user_joined' ? 'joined' : 'left';
+    icon = message.type === 'user_joined' ? '→' : '←';
+    text = <><strong>{message.userName}</strong> {action} the room</>;
+  }
 
   return (
     <div className="system-message">
       <span className="system-icon">{icon}</span>
-      <span className="system-text">
-        <strong>{message.userName}</strong> {action} the room
-      </span>
+      <span className="system-text">{text}</span>
       <span className="system-time">{formatTime(message.timestamp)}</span>
 
       <style>{`
@@ -3,13 +3,17 @@ package main
 import (
 	"context"
 	"io"
+	"io/fs"
 	"log"
 	"net/http"
 	"os"
 	"os/signal"
+	"path/filepath"
 	"strings"
+	"sync"
 	"syscall"
 	"time"
+	_ "time/tzdata" // time zone names work in images without zoneinfo
 
 	"voice-chat/internal/domain/entity"
 	"voice-chat/internal/domain/repository"
@@ -18,8 +22,14 @@ import (
 	"voice-chat/internal/infrastructure/persistence"
 	"voice-chat/internal/interface/handler"
 	"voice-chat/internal/usecase/admin"
+	"voice-chat/internal/usecase/attachment"
+	"voice-chat/internal/usecase/chat"
+	"voice-chat/internal/usecase/command"
+	"voice-chat/internal/usecase/dm"
+	"voice-chat/internal/usecase/moderation"
 	"voice-chat/internal/usecase/room"
 	"voice-chat/pkg/config"
+	"voice-chat/pkg/i18n"
 )
 
 func main() {
@@ -35,6 +45,36 @@ func main() {
 	banRepo := persistence.NewInMemoryBanRepository()
 	activityRepo := persistence.NewInMemoryActivityRepository()
 	analyticsRepo := persistence.NewInMemoryAnalyticsRepository()
+	readMarkerRepo := persistence.NewInMemoryReadMarkerRepository()
+	dmRepo := persistence.NewInMemoryDirectMessageRepository()
+	blockRepo := persistence.NewInMemoryBlockRepository()
+	dmReportRepo := persistence.NewInMemoryDMReportRepository()
+
+	// Initialize announcement repository (file-backed so schedules survive restarts)
+	announcementRepo, err := persistence.NewFileAnnouncementRepository(filepath.Join(cfg.DataDir, "announcements.json"))
+	if err != nil {
+		log.Fatalf("Failed to initialize announcement repository: %v", err)
+	}
+
+	// Initialize attachment storage (local disk; any BlobStore such as S3 can replace it)
+	uploadDir := cfg.UploadDir
+	if uploadDir == "" {
+		uploadDir = filepath.Join(cfg.DataDir, "uploads")
+	}
+	blobStore, err := persistence.NewLocalBlobStore(uploadDir)
+	if err != nil {
+		log.Fatalf("Failed to initialize upload storage: %v", err)
+	}
+
+	// Initialize chat archive store (transcripts of rooms with the archive retention policy)
+	archiveDir := cfg.ChatArchiveDir
+	if archiveDir == "" {
+		archiveDir = filepath.Join(cfg.DataDir, "archives")
+	}
+	archiveRepo, err := persistence.NewFileChatArchiveRepository(archiveDir)
+	if err != nil {
+		log.Fatalf("Failed to initialize chat archive store: %v", err)
+	}
 
 	// Initialize notification service
 	notifyService := notification.NewNotificationService()
@@ -43,19 +83,44 @@ func main() {
 		log.Printf("Notification webhook configured: %s", cfg.WebhookURL)
 	}
 
-	// Initialize chat repository (Redis if enabled, otherwise in-memory)
+	// Initialize chat repository and search index (Redis if enabled, otherwise in-memory or file logs)
 	var chatRepo repository.ChatRepository
+	var searchRepo repository.ChatSearchRepository
+	var fileChatRepo *persistence.FileChatRepository
 	if cfg.RedisEnabled {
 		log.Printf("Initializing Redis chat repository at %s", cfg.RedisAddr)
 		redisRepo, err := persistence.NewRedisChatRepository(cfg.RedisAddr, cfg.RedisPassword, cfg.RedisDB)
 		if err != nil {
 			log.Fatalf("Failed to initialize Redis chat repository: %v", err)
 		}
+		if migrated, err := redisRepo.MigrateLegacyMessages(); err != nil {
+			log.Printf("Warning: failed to migrate chat messages to the per-room layout: %v", err)
+		} else if migrated > 0 {
+			log.Printf("Migrated %d chat messages to the per-room layout", migrated)
+		}
 		chatRepo = redisRepo
+		redisSearchRepo, err := persistence.NewRedisChatSearchRepository(cfg.RedisAddr, cfg.RedisPassword, cfg.RedisDB)
+		if err != nil {
+			log.Fatalf("Failed to initialize Redis chat search repository: %v", err)
+		}
+		searchRepo = redisSearchRepo
 		log.Println("Redis chat repository initialized successfully")
+	} else if cfg.ChatStore == "file" {
+		chatLogDir := cfg.ChatLogDir
+		if chatLogDir == "" {
+			chatLogDir = filepath.Join(cfg.DataDir, "chat")
+		}
+		log.Printf("Initializing file chat repository at %s", chatLogDir)
+		fileChatRepo, err = persistence.NewFileChatRepository(chatLogDir, cfg.ChatLogSyncMillis <= 0)
+		if err != nil {
+			log.Fatalf("Failed to initialize file chat repository: %v", err)
+		}
+		chatRepo = fileChatRepo
+		searchRepo = persistence.NewInMemoryChatSearchRepository()
 	} else {
 		log.Println("Using in-memory chat repository")
 		chatRepo = persistence.NewInMemoryChatRepository()
+		searchRepo = persistence.NewInMemoryChatSearchRepository()
 	}
 
 	// Initialize services
@@ -67,22 +132,80 @@ func main() {
 	leaveRoomUC := room.NewLeaveRoomUseCase(roomRepo, userRepo, activityRepo)
 	listRoomsUC := room.NewListRoomsUseCase(roomRepo)
 	getRoomUC := room.NewGetRoomUseCase(roomRepo)
+	listenRoomUC := room.NewListenRoomUseCase(roomRepo, banRepo, activityRepo, cfg.ListenRoomRoles, cfg.MaxListenRooms)
+	// Chat filters (a broken moderation file is fatal only here)
+	moderationConfig, err := loadModerationConfig(cfg.ModerationFile)
+	if err != nil {
+		log.Fatalf("Failed to load moderation file: %v", err)
+	}
+	moderationUC := moderation.NewModerationUseCase(moderationConfig, roomRepo, activityRepo)
+
+	// Server message catalog (a broken locale file is fatal)
+	catalog, err := loadCatalog(cfg.DefaultLocale, cfg.LocaleDir)
+	if err != nil {
+		log.Fatalf("Failed to load locales: %v", err)
+	}
+	log.Printf("Locales: %s (default %s)", strings.Join(catalog.Locales(), ", "), catalog.Fallback())
+
+	chatSendUC := chat.NewSendMessageUseCase(chatRepo, roomRepo, searchRepo, moderationUC)
+	chatEditUC := chat.NewEditMessageUseCase(chatRepo, searchRepo, moderationUC, time.Duration(cfg.ChatEditWindowMinutes)*time.Minute)
+	chatDeleteUC := chat.NewDeleteMessageUseCase(chatRepo, roomRepo, searchRepo, activityRepo, blobStore)
+	reactionUC := chat.NewReactionUseCase(chatRepo, cfg.ReactionEmojis, cfg.MaxReactionsPerMsg)
+	typingUC := chat.NewTypingUseCase()
+	readStateUC := chat.NewReadStateUseCase(readMarkerRepo, chatRepo)
+	searchUC := chat.NewSearchUseCase(searchRepo)
+	floodConfig, err := loadFloodControlConfig(cfg.FloodControlFile)
+	if err != nil {
+		log.Fatalf("Failed to load flood control file: %v", err)
+	}
+	floodUC := chat.NewFloodControlUseCase(floodConfig)
+	slowModeUC := chat.NewSlowModeUseCase(roomRepo)
+	roomMuteUC := chat.NewRoomMuteUseCase()
+	commandUC := command.NewCommandUseCase(roomRepo, userRepo, chatRepo, roomMuteUC)
+	attachUC := attachment.NewAttachmentUseCase(blobStore, int64(cfg.MaxUploadMB)<<20)
+	pinUC := chat.NewPinUseCase(chatRepo, roomRepo, cfg.MaxPinnedMessages)
+	topicUC := chat.NewTopicUseCase(roomRepo)
+	pollUC := chat.NewPollUseCase(chatRepo, roomRepo)
+	exportUC := chat.NewExportUseCase(chatRepo, roomRepo, cfg.OwnerChatExport)
+	retentionUC := chat.NewRetentionUseCase(chatRepo, roomRepo, searchRepo, archiveRepo)
+	lobbyChatUC := chat.NewLobbyChatUseCase(chatRepo, moderationUC, roomMuteUC, cfg.LobbyChatEnabled, time.Duration(cfg.LobbyChatCooldownSeconds)*time.Second)
+	dmUC := dm.NewDirectMessageUseCase(dmRepo, blockRepo, moderationUC)
+	dmBlockUC := dm.NewBlockUseCase(blockRepo)
+	dmReportUC := dm.NewReportUseCase(dmRepo, dmReportRepo, activityRepo)
 	adminAuthUC := admin.NewAdminAuthUseCase(cfg.AdminPassword, cfg.AdminAllowedIPs)
 	adminActionsUC := admin.NewAdminActionsUseCase(roomRepo, userRepo, banRepo, activityRepo)
+	announcementUC := admin.NewAnnouncementUseCase(roomRepo, chatRepo, activityRepo)
+	scheduleLocation, err := loadLocation(cfg.AnnouncementTimezone)
+	if err != nil {
+		log.Fatalf("Invalid announcement time zone: %v", err)
+	}
+	scheduleUC := admin.NewScheduledAnnouncementsUseCase(announcementRepo, announcementUC, scheduleLocation)
+	maintenanceUC := admin.NewMaintenanceUseCase(activityRepo)
 	getStatsUC := admin.NewGetStatsUseCase(roomRepo, userRepo, banRepo, activityRepo)
+	syncPresetsUC := room.NewSyncPresetsUseCase(roomRepo, chatRepo, retentionUC, blobStore, activityRepo)
 
-	// Create persistent Lobby room on startup
-	lobbyInput := room.CreateRoomInput{
-		Name:      "Lobby",
-		Type:      entity.RoomTypePublic,
-		CreatedBy: "admin", // Admin-created rooms are not cleaned up
-		Capacity:  100,
+	// Create persistent preset rooms on startup (a broken rooms file is fatal only here)
+	presets, err := loadRoomPresets(cfg.RoomsFile)
+	if err != nil {
+		log.Fatalf("Failed to load rooms file: %v", err)
 	}
-	if _, err := createRoomUC.Execute(lobbyInput); err != nil {
-		// Lobby may already exist if server restarted quickly
-		log.Printf("Lobby room creation: %v", err)
-	} else {
-		log.Println("Created persistent Lobby room")
+	if _, err := syncPresetRooms(syncPresetsUC, presets); err != nil {
+		log.Fatalf("Failed to create preset rooms: %v", err)
+	}
+
+	// Only preset rooms and the lobby chat survive a restart; drop the chat logs of the others
+	if fileChatRepo != nil {
+		for _, roomID := range fileChatRepo.RoomIDs() {
+			if roomID == entity.LobbyChatRoomID {
+				continue
+			}
+			if r, err := roomRepo.GetByID(roomID); err == nil && r != nil {
+				continue
+			}
+			if err := fileChatRepo.DeleteRoomMessages(roomID); err != nil {
+				log.Printf("Error deleting chat log for room %s: %v", roomID, err)
+			}
+		}
 	}
 
 	// Initialize WebSocket handler
@@ -92,11 +215,37 @@ func main() {
 		leaveRoomUC,
 		listRoomsUC,
 		getRoomUC,
+		listenRoomUC,
+		chatSendUC,
+		chatEditUC,
+		chatDeleteUC,
+		reactionUC,
+		typingUC,
+		readStateUC,
+		searchUC,
+		floodUC,
+		slowModeUC,
+		roomMuteUC,
+		commandUC,
+		attachUC,
+		pinUC,
+		topicUC,
+		pollUC,
+		exportUC,
+		retentionUC,
+		lobbyChatUC,
+		dmUC,
+		dmBlockUC,
+		dmReportUC,
 		adminAuthUC,
 		adminActionsUC,
+		announcementUC,
+		scheduleUC,
+		maintenanceUC,
 		getStatsUC,
 		tokenService,
 		chatRepo,
+		catalog,
 		cfg,
 	)
 
@@ -126,6 +275,14 @@ func main() {
 	// Analytics endpoint (get room duration stats)
 	mux.HandleFunc("/api/analytics", webhookHandler.GetAnalytics)
 
+	// Chat attachment upload and download
+	mux.HandleFunc("/api/uploads", wsHandler.HandleUploads)
+	mux.HandleFunc("/api/uploads/", wsHandler.HandleUploads)
+
+	// Chat transcript download for admins and, if enabled, room owners
+	mux.HandleFunc("/api/export/", wsHandler.HandleChatExport)
+	mux.HandleFunc("/api/archives/", wsHandler.HandleArchiveDownload)
+
 	// Auth proxy endpoint to bypass CORS (proxies /api/* to configured auth API)
 	mux.HandleFunc("/api/", func(w http.ResponseWriter, r *http.Request) {
 		handleAuthProxy(w, r, cfg)
@@ -143,17 +300,90 @@ func main() {
 		IdleTimeout:  60 * time.Second,
 	}
 
+	// Background goroutines stop when bgCtx is cancelled on shutdown
+	bgCtx, stopBackground := context.WithCancel(context.Background())
+	var bgWG sync.WaitGroup
+	runBackground := func(fn func(ctx context.Context)) {
+		bgWG.Add(1)
+		go func() {
+			defer bgWG.Done()
+			fn(bgCtx)
+		}()
+	}
+
 	// Start room cleanup goroutine
-	go startRoomCleanup(roomRepo, chatRepo, cfg.RoomCleanupMinutes)
+	runBackground(func(ctx context.Context) {
+		startRoomCleanup(ctx, roomRepo, retentionUC, readMarkerRepo, attachUC, pollUC, wsHandler, cfg.RoomCleanupMinutes)
+	})
 
 	// Start ban cleanup goroutine
-	go startBanCleanup(banRepo)
+	runBackground(func(ctx context.Context) {
+		startBanCleanup(ctx, banRepo)
+	})
 
 	// Start activity log cleanup goroutine
-	go startActivityCleanup(activityRepo, cfg.ActivityLogHours)
+	runBackground(func(ctx context.Context) {
+		startActivityCleanup(ctx, activityRepo, cfg.ActivityLogHours)
+	})
 
 	// Start analytics cleanup goroutine
-	go startAnalyticsCleanup(analyticsRepo, cfg.ActivityLogHours)
+	runBackground(func(ctx context.Context) {
+		startAnalyticsCleanup(ctx, analyticsRepo, cfg.ActivityLogHours)
+	})
+
+	// Start read marker cleanup goroutine
+	runBackground(func(ctx context.Context) {
+		startReadMarkerCleanup(ctx, readMarkerRepo, cfg.ReadMarkerHours)
+	})
+
+	// Start per-room chat retention goroutine
+	runBackground(func(ctx context.Context) {
+		startChatRetention(ctx, retentionUC, cfg.RetentionPruneMinutes)
+	})
+
+	// Start chat search index retention goroutine
+	runBackground(func(ctx context.Context) {
+		startSearchIndexCleanup(ctx, searchRepo, cfg.SearchRetentionHours)
+	})
+
+	// Start direct message retention goroutine
+	runBackground(func(ctx context.Context) {
+		startDirectMessageCleanup(ctx, dmRepo, cfg.DMRetentionHours)
+	})
+
+	// Start scheduled announcement goroutine
+	runBackground(func(ctx context.Context) {
+		startAnnouncementScheduler(ctx, scheduleUC, wsHandler)
+	})
+
+	// Start maintenance countdown goroutine
+	runBackground(wsHandler.RunMaintenanceCountdown)
+
+	// Start typing indicator expiry goroutine
+	runBackground(wsHandler.RunTypingExpiry)
+
+	// Start flood control cleanup goroutine
+	runBackground(wsHandler.RunFloodCleanup)
+
+	// Close polls when their timer runs out
+	runBackground(wsHandler.RunPollExpiry)
+
+	// Start chat log fsync and compaction goroutine
+	if fileChatRepo != nil {
+		runBackground(func(ctx context.Context) {
+			startChatLogMaintenance(ctx, fileChatRepo, cfg.ChatLogSyncMillis, cfg.ChatLogCompactMinutes)
+		})
+	}
+
+	// Reload the rooms file on SIGHUP
+	runBackground(func(ctx context.Context) {
+		startRoomsFileReloader(ctx, cfg.RoomsFile, syncPresetsUC, wsHandler)
+	})
+
+	// Reload the moderation file on SIGHUP
+	runBackground(func(ctx context.Context) {
+		startModerationFileReloader(ctx, cfg.ModerationFile, moderationUC)
+	})
 
 	// Start server in goroutine
 	go func() {
@@ -170,14 +400,29 @@ func main() {
 
 	log.Println("Shutting down server...")
 
+	// Stop background goroutines
+	stopBackground()
+	bgWG.Wait()
+
 	// Graceful shutdown
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
+	// Stop accepting new connections (hijacked WebSocket connections are not tracked by the server)
 	if err := server.Shutdown(ctx); err != nil {
 		log.Fatalf("Server forced to shutdown: %v", err)
 	}
 
+	// Tell WebSocket clients when to reconnect and close their sockets
+	wsHandler.Shutdown(ctx, time.Duration(cfg.ShutdownReconnectSeconds)*time.Second)
+
+	// Flush chat logs written while clients disconnected
+	if fileChatRepo != nil {
+		if err := fileChatRepo.Close(); err != nil {
+			log.Printf("Error closing chat logs: %v", err)
+		}
+	}
+
 	log.Println("Server stopped")
 }
 
@@ -196,11 +441,17 @@ func corsMiddleware(next http.Handler) http.Handler {
 	})
 }
 
-func startRoomCleanup(roomRepo *persistence.InMemoryRoomRepository, chatRepo repository.ChatRepository, intervalMinutes int) {
+func startRoomCleanup(ctx context.Context, roomRepo *persistence.InMemoryRoomRepository, retentionUC *chat.RetentionUseCase, readMarkerRepo repository.ReadMarkerRepository, attachUC *attachment.AttachmentUseCase, pollUC *chat.PollUseCase, wsHandler *handler.WebSocketHandler, intervalMinutes int) {
 	ticker := time.NewTicker(time.Duration(intervalMinutes) * time.Minute)
 	defer ticker.Stop()
 
-	for range ticker.C {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
 		rooms, err := roomRepo.GetEmptyRoomsSince(intervalMinutes)
 		if err != nil {
 			log.Printf("Error getting empty rooms: %v", err)
@@ -208,26 +459,98 @@ func startRoomCleanup(roomRepo *persistence.InMemoryRoomRepository, chatRepo rep
 		}
 
 		for _, r := range rooms {
-			// Delete chat messages for the room
-			if err := chatRepo.DeleteRoomMessages(r.ID); err != nil {
-				log.Printf("Error deleting chat messages for room %s: %v", r.Name, err)
+			// Nobody is left to see the results, so polls are closed quietly
+			pollUC.CloseRoom(r.ID, time.Now())
+
+			// Archive the chat if the room's policy asks for it, then delete it
+			archive, err := retentionUC.ReleaseRoom(r, time.Now())
+			if err != nil {
+				log.Printf("Error releasing chat messages for room %s: %v", r.Name, err)
+			} else if archive != nil {
+				log.Printf("Archived %d chat messages of room %s", archive.MessageCount, r.Name)
+			}
+			if err := readMarkerRepo.DeleteRoom(r.ID); err != nil {
+				log.Printf("Error deleting read markers for room %s: %v", r.Name, err)
+			}
+			if err := attachUC.DeleteRoom(r.ID); err != nil {
+				log.Printf("Error deleting attachments for room %s: %v", r.Name, err)
 			}
 
-			// Delete the room
+			// Delete the room; anyone still listening loses access
+			r.RemoveAllListeners()
 			if err := roomRepo.Delete(r.ID); err != nil {
 				log.Printf("Error deleting room %s: %v", r.Name, err)
 			} else {
 				log.Printf("Cleaned up empty room: %s", r.Name)
 			}
+			wsHandler.ReleaseListeners(r.ID)
 		}
 	}
 }
 
-func startBanCleanup(banRepo *persistence.InMemoryBanRepository) {
+func startChatRetention(ctx context.Context, retentionUC *chat.RetentionUseCase, intervalMinutes int) {
+	ticker := time.NewTicker(time.Duration(intervalMinutes) * time.Minute)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		count, err := retentionUC.Prune(time.Now())
+		if err != nil {
+			log.Printf("Error pruning chat messages: %v", err)
+			continue
+		}
+		if count > 0 {
+			log.Printf("Pruned %d chat messages past their room's retention", count)
+		}
+	}
+}
+
+func startChatLogMaintenance(ctx context.Context, fileChatRepo *persistence.FileChatRepository, syncMillis, compactMinutes int) {
+	// With a sync interval of zero every write is fsynced already
+	var syncC <-chan time.Time
+	if syncMillis > 0 {
+		syncTicker := time.NewTicker(time.Duration(syncMillis) * time.Millisecond)
+		defer syncTicker.Stop()
+		syncC = syncTicker.C
+	}
+	compactTicker := time.NewTicker(time.Duration(compactMinutes) * time.Minute)
+	defer compactTicker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-syncC:
+			fileChatRepo.Sync()
+		case <-compactTicker.C:
+			count, err := fileChatRepo.Compact()
+			if err != nil {
+				log.Printf("Error compacting chat logs: %v", err)
+				continue
+			}
+			if count > 0 {
+				log.Printf("Compacted %d chat logs", count)
+			}
+		}
+	}
+}
+
+func startBanCleanup(ctx context.Context, banRepo *persistence.InMemoryBanRepository) {
 	ticker := time.NewTicker(5 * time.Minute)
 	defer ticker.Stop()
 
-	for range ticker.C {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
 		count, err := banRepo.DeleteExpired()
 		if err != nil {
 			log.Printf("Error cleaning up expired bans: %v", err)
@@ -239,11 +562,17 @@ func startBanCleanup(banRepo *persistence.InMemoryBanRepository) {
 	}
 }
 
-func startActivityCleanup(activityRepo *persistence.InMemoryActivityRepository, hoursToKeep int) {
+func startActivityCleanup(ctx context.Context, activityRepo *persistence.InMemoryActivityRepository, hoursToKeep int) {
 	ticker := time.NewTicker(1 * time.Hour)
 	defer ticker.Stop()
 
-	for range ticker.C {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
 		count := activityRepo.Cleanup(hoursToKeep)
 		if count > 0 {
 			log.Printf("Cleaned up %d old activity logs", count)
@@ -251,11 +580,17 @@ func startActivityCleanup(activityRepo *persistence.InMemoryActivityRepository,
 	}
 }
 
-func startAnalyticsCleanup(analyticsRepo *persistence.InMemoryAnalyticsRepository, hoursToKeep int) {
+func startAnalyticsCleanup(ctx context.Context, analyticsRepo *persistence.InMemoryAnalyticsRepository, hoursToKeep int) {
 	ticker := time.NewTicker(1 * time.Hour)
 	defer ticker.Stop()
 
-	for range ticker.C {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
 		count := analyticsRepo.Cleanup(hoursToKeep)
 		if count > 0 {
 			log.Printf("Cleaned up %d old analytics records", count)
@@ -263,6 +598,191 @@ func startAnalyticsCleanup(analyticsRepo *persistence.InMemoryAnalyticsRepositor
 	}
 }
 
+func startReadMarkerCleanup(ctx context.Context, readMarkerRepo *persistence.InMemoryReadMarkerRepository, hoursToKeep int) {
+	ticker := time.NewTicker(1 * time.Hour)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		count := readMarkerRepo.Cleanup(time.Duration(hoursToKeep) * time.Hour)
+		if count > 0 {
+			log.Printf("Cleaned up %d idle read marker sessions", count)
+		}
+	}
+}
+
+func startSearchIndexCleanup(ctx context.Context, searchRepo repository.ChatSearchRepository, hoursToKeep int) {
+	ticker := time.NewTicker(1 * time.Hour)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		count, err := searchRepo.Prune(time.Now().Add(-time.Duration(hoursToKeep) * time.Hour))
+		if err != nil {
+			log.Printf("Error pruning chat search index: %v", err)
+			continue
+		}
+		if count > 0 {
+			log.Printf("Pruned %d messages from the chat search index", count)
+		}
+	}
+}
+
+func startDirectMessageCleanup(ctx context.Context, dmRepo *persistence.InMemoryDirectMessageRepository, hoursToKeep int) {
+	ticker := time.NewTicker(1 * time.Hour)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		count := dmRepo.Cleanup(time.Duration(hoursToKeep) * time.Hour)
+		if count > 0 {
+			log.Printf("Cleaned up %d expired direct messages", count)
+		}
+	}
+}
+
+func startAnnouncementScheduler(ctx context.Context, scheduleUC *admin.ScheduledAnnouncementsUseCase, wsHandler *handler.WebSocketHandler) {
+	ticker := time.NewTicker(15 * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			for _, result := range scheduleUC.RunDue(now) {
+				wsHandler.DeliverAnnouncement(result)
+			}
+		}
+	}
+}
+
+// loadRoomPresets reads the rooms file, falling back to the default Lobby when none is configured
+func loadRoomPresets(path string) ([]*entity.RoomPreset, error) {
+	if path == "" {
+		return room.DefaultPresets(), nil
+	}
+	return persistence.LoadRoomPresets(path)
+}
+
+// syncPresetRooms reconciles rooms with the presets and logs the changes
+func syncPresetRooms(syncPresetsUC *room.SyncPresetsUseCase, presets []*entity.RoomPreset) (*room.SyncPresetsOutput, error) {
+	result, err := syncPresetsUC.Execute(presets)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("Preset rooms synced: %d created, %d updated, %d closed, %d deleted",
+		len(result.Created), len(result.Updated), len(result.Closed), len(result.Deleted))
+	return result, nil
+}
+
+func startRoomsFileReloader(ctx context.Context, path string, syncPresetsUC *room.SyncPresetsUseCase, wsHandler *handler.WebSocketHandler) {
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	defer signal.Stop(hup)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-hup:
+		}
+
+		log.Println("Reloading rooms file...")
+
+		// Keep the current rooms if the file is broken
+		presets, err := loadRoomPresets(path)
+		if err != nil {
+			log.Printf("Error reloading rooms file: %v", err)
+			continue
+		}
+
+		result, err := syncPresetRooms(syncPresetsUC, presets)
+		if err != nil {
+			log.Printf("Error syncing preset rooms: %v", err)
+			continue
+		}
+		wsHandler.NotifyPresetsSynced(result)
+	}
+}
+
+// loadModerationConfig reads the moderation file, falling back to the length limit alone when none is configured
+func loadModerationConfig(path string) (*entity.ModerationConfig, error) {
+	if path == "" {
+		return entity.DefaultModerationConfig(), nil
+	}
+	return persistence.LoadModerationConfig(path)
+}
+
+// loadFloodControlConfig reads the flood control file, falling back to the built-in limits when none is configured
+func loadFloodControlConfig(path string) (*entity.FloodControlConfig, error) {
+	if path == "" {
+		return entity.DefaultFloodControlConfig(), nil
+	}
+	return persistence.LoadFloodControlConfig(path)
+}
+
+// loadLocation resolves a time zone name, keeping the server's local time when none is set
+func loadLocation(name string) (*time.Location, error) {
+	if name == "" {
+		return time.Local, nil
+	}
+	return time.LoadLocation(name)
+}
+
+// loadCatalog reads the built-in locale files and any extra ones from dir
+func loadCatalog(defaultLocale, dir string) (*i18n.Catalog, error) {
+	dirs := []fs.FS{i18n.Builtin()}
+	if dir != "" {
+		dirs = append(dirs, os.DirFS(dir))
+	}
+	return i18n.Load(defaultLocale, dirs...)
+}
+
+func startModerationFileReloader(ctx context.Context, path string, moderationUC *moderation.ModerationUseCase) {
+	if path == "" {
+		return
+	}
+
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	defer signal.Stop(hup)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-hup:
+		}
+
+		// Keep the current filters if the file is broken
+		config, err := loadModerationConfig(path)
+		if err != nil {
+			log.Printf("Error reloading moderation file: %v", err)
+			continue
+		}
+
+		moderationUC.SetConfig(config)
+		log.Println("Moderation file reloaded")
+	}
+}
+
 // handleAuthProxy proxies auth requests to configured auth API to bypass CORS
 func handleAuthProxy(w http.ResponseWriter, r *http.Request, cfg *config.Config) {
 	// Extract the path after /api/
@@ -12,10 +12,20 @@ const (
 	ActivityTypeUserLeave    ActivityType = "user_leave"
 	ActivityTypeRoomCreate   ActivityType = "room_create"
 	ActivityTypeRoomClose    ActivityType = "room_close"
+	ActivityTypeRoomUpdate   ActivityType = "room_update"
 	ActivityTypeAdminMute    ActivityType = "admin_mute"
 	ActivityTypeAdminKick    ActivityType = "admin_kick"
 	ActivityTypeAdminBan     ActivityType = "admin_ban"
 	ActivityTypeAdminStealth ActivityType = "admin_stealth"
+	ActivityTypeRoomListen   ActivityType = "room_listen"
+	ActivityTypeRoomUnlisten ActivityType = "room_unlisten"
+	ActivityTypeAnnouncement ActivityType = "announcement"
+	ActivityTypeVoicePA      ActivityType = "voice_pa"
+	ActivityTypeMaintenance  ActivityType = "maintenance"
+	ActivityTypeChatDelete   ActivityType = "chat_delete"
+	ActivityTypeDMReport     ActivityType = "dm_report"
+	ActivityTypeChatFlag     ActivityType = "chat_flag"
+	ActivityTypeChatWarn     ActivityType = "chat_warn"
 )
 
 // ActivityLog represents a logged activity for analytics
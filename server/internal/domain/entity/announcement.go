@@ -0,0 +1,81 @@
+package entity
+
+import (
+	"time"
+)
+
+// AnnouncementSenderName is shown as the sender of announcement system messages
+const AnnouncementSenderName = "Announcement"
+
+// AnnouncementTarget represents who receives an announcement
+type AnnouncementTarget string
+
+const (
+	AnnouncementTargetAll   AnnouncementTarget = "all"   // Every client, in the lobby or in a room
+	AnnouncementTargetRooms AnnouncementTarget = "rooms" // Only the listed rooms
+	AnnouncementTargetLobby AnnouncementTarget = "lobby" // Only clients not in a room
+)
+
+// IsValid checks if the target is a known announcement target
+func (t AnnouncementTarget) IsValid() bool {
+	switch t {
+	case AnnouncementTargetAll, AnnouncementTargetRooms, AnnouncementTargetLobby:
+		return true
+	}
+	return false
+}
+
+// Announcement represents a café-wide message from staff
+type Announcement struct {
+	ID        string
+	Content   string
+	Target    AnnouncementTarget
+	RoomNames []string // Only used with AnnouncementTargetRooms
+	CreatedBy string   // Admin ID
+	CreatedAt time.Time
+}
+
+// NewAnnouncement creates a new announcement for every client
+func NewAnnouncement(id, content, createdBy string) *Announcement {
+	return &Announcement{
+		ID:        id,
+		Content:   content,
+		Target:    AnnouncementTargetAll,
+		CreatedBy: createdBy,
+		CreatedAt: time.Now(),
+	}
+}
+
+// Clone copies the schedule so callers can change it without touching the stored one
+func (s *ScheduledAnnouncement) Clone() *ScheduledAnnouncement {
+	c := *s
+	c.RoomNames = append([]string(nil), s.RoomNames...)
+	return &c
+}
+
+// ScheduledAnnouncement represents a recurring announcement driven by a cron expression
+type ScheduledAnnouncement struct {
+	ID        string             `json:"id"`
+	Content   string             `json:"content"`
+	Cron      string             `json:"cron"` // Five-field cron expression (in the schedule time zone)
+	Target    AnnouncementTarget `json:"target"`
+	RoomNames []string           `json:"room_names,omitempty"` // Room names survive restarts, IDs don't
+	Enabled   bool               `json:"enabled"`
+	CreatedBy string             `json:"created_by"`
+	CreatedAt time.Time          `json:"created_at"`
+	LastRunAt time.Time          `json:"last_run_at"`
+}
+
+// NewScheduledAnnouncement creates a new enabled scheduled announcement
+func NewScheduledAnnouncement(id, content, cron string, target AnnouncementTarget, roomNames []string, createdBy string) *ScheduledAnnouncement {
+	return &ScheduledAnnouncement{
+		ID:        id,
+		Content:   content,
+		Cron:      cron,
+		Target:    target,
+		RoomNames: roomNames,
+		Enabled:   true,
+		CreatedBy: createdBy,
+		CreatedAt: time.Now(),
+	}
+}
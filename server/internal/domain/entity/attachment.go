@@ -0,0 +1,53 @@
+package entity
+
+import (
+	"strings"
+	"time"
+)
+
+// MaxAttachmentsPerMessage is how many files a single chat message can carry
+const MaxAttachmentsPerMessage = 4
+
+// Attachment is an uploaded file attached to a chat message
+type Attachment struct {
+	ID           string    `json:"id"`
+	RoomID       string    `json:"room_id"`
+	UploaderID   string    `json:"uploader_id"`
+	FileName     string    `json:"file_name"`
+	ContentType  string    `json:"content_type"`
+	Size         int64     `json:"size"`
+	Width        int       `json:"width,omitempty"`  // Images only
+	Height       int       `json:"height,omitempty"` // Images only
+	HasThumbnail bool      `json:"has_thumbnail,omitempty"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// IsImage checks if the attachment is an image shown inline
+func (a *Attachment) IsImage() bool {
+	return strings.HasPrefix(a.ContentType, "image/")
+}
+
+// BlobKey is where the file is stored
+func (a *Attachment) BlobKey() string {
+	return AttachmentBlobKey(a.RoomID, a.ID)
+}
+
+// ThumbnailKey is where the thumbnail is stored
+func (a *Attachment) ThumbnailKey() string {
+	return AttachmentThumbnailKey(a.RoomID, a.ID)
+}
+
+// RoomAttachmentPrefix is the blob key prefix shared by every file uploaded to a room
+func RoomAttachmentPrefix(roomID string) string {
+	return "rooms/" + roomID + "/"
+}
+
+// AttachmentBlobKey builds the blob key of an uploaded file
+func AttachmentBlobKey(roomID, attachmentID string) string {
+	return RoomAttachmentPrefix(roomID) + attachmentID
+}
+
+// AttachmentThumbnailKey builds the blob key of an uploaded image's thumbnail
+func AttachmentThumbnailKey(roomID, attachmentID string) string {
+	return AttachmentBlobKey(roomID, attachmentID) + ".thumb"
+}
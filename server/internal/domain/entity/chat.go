@@ -1,6 +1,7 @@
 package entity
 
 import (
+	"errors"
 	"time"
 )
 
@@ -10,18 +11,106 @@ type ChatMessageType string
 const (
 	ChatMessageTypeUser   ChatMessageType = "chat"
 	ChatMessageTypeSystem ChatMessageType = "system"
+	ChatMessageTypePoll   ChatMessageType = "poll"
 )
 
+// System message codes. Clients and the server's message catalog localise these from the
+// message's params; the stored content is the text in the server's fallback locale.
+const (
+	SystemCodeUserJoined    = "user_joined"    // Params: name
+	SystemCodeUserLeft      = "user_left"      // Params: name
+	SystemCodeRoomRemoved   = "room_removed"   // No params
+	SystemCodeAnnouncement  = "announcement"   // Params: text (the admin's own words, not translated)
+	SystemCodeCommandResult = "command_result" // Params: text, for commands without a code of their own
+
+	// Results of built-in chat commands, shown after the caller's name
+	SystemCodeCommandRoll         = "command_roll"          // Params: dice ("2d6"), result ("3 + 4 = 7")
+	SystemCodeCommandFlipHeads    = "command_flip_heads"    // No params
+	SystemCodeCommandFlipTails    = "command_flip_tails"    // No params
+	SystemCodeCommandTeams        = "command_teams"         // Params: count, teams
+	SystemCodeCommandBack         = "command_back"          // No params
+	SystemCodeCommandAway         = "command_away"          // No params
+	SystemCodeCommandAwayMessage  = "command_away_message"  // Params: message
+	SystemCodeCommandTopicCleared = "command_topic_cleared" // No params
+	SystemCodeCommandTopicSet     = "command_topic_set"     // Params: topic
+	SystemCodeCommandMute         = "command_mute"          // Params: name, minutes
+	SystemCodeCommandUnmute       = "command_unmute"        // Params: name
+)
+
+var (
+	ErrTooManyReactions   = errors.New("message has too many different reactions")
+	ErrReactionOnDeleted  = errors.New("deleted messages cannot be reacted to")
+	ErrMessageDeleted     = errors.New("message has been deleted")
+	ErrNotMessageAuthor   = errors.New("only the author can edit this message")
+	ErrEditWindowExpired  = errors.New("message can no longer be edited")
+	ErrSystemMessage      = errors.New("system messages cannot be changed")
+	ErrNotAllowedToDelete = errors.New("only the author or a moderator can delete this message")
+	ErrPollNotEditable    = errors.New("polls cannot be edited")
+)
+
+// The lobby chat is a café-wide channel outside of rooms. Its messages are stored under a
+// reserved room ID that generated room IDs (UUIDs) can't clash with.
+const (
+	LobbyChatRoomID = "lobby"
+	LobbyChatName   = "Lobby chat" // Moderation policies for the channel are set under this name
+)
+
+// MaxChatRevisions is the number of previous versions kept for an edited message
+const MaxChatRevisions = 20
+
+// ChatRevision is a previous version of an edited message
+type ChatRevision struct {
+	Content  string    `json:"content"`
+	EditedAt time.Time `json:"edited_at"` // When this version was replaced
+}
+
+// QuoteSnippetLength is the maximum number of characters quoted from a parent message
+const QuoteSnippetLength = 100
+
+// ChatQuote is a snippet of the parent message shown above a reply
+type ChatQuote struct {
+	MessageID  string `json:"message_id"`
+	SenderName string `json:"sender_name"`
+	Snippet    string `json:"snippet"`
+	Deleted    bool   `json:"deleted,omitempty"` // The parent was deleted after the reply was sent
+}
+
+// NewChatQuote creates a quote of a message, truncating long content
+func NewChatQuote(parent *ChatMessage) *ChatQuote {
+	snippet := []rune(parent.Content)
+	if len(snippet) > QuoteSnippetLength {
+		snippet = append(snippet[:QuoteSnippetLength-1], '…')
+	}
+	return &ChatQuote{
+		MessageID:  parent.ID,
+		SenderName: parent.SenderName,
+		Snippet:    string(snippet),
+	}
+}
+
 // ChatMessage represents a chat message in a room
 type ChatMessage struct {
-	ID        string          `json:"id"`
-	RoomID    string          `json:"room_id"`
-	Type      ChatMessageType `json:"type"`
-	SenderID  string          `json:"sender_id"`
-	SenderName string         `json:"sender_name"`
-	Content   string          `json:"content"`
-	Timestamp time.Time       `json:"timestamp"`
-	Reactions map[string][]string `json:"reactions"` // emoji -> []userID
+	ID          string              `json:"id"`
+	RoomID      string              `json:"room_id"`
+	Type        ChatMessageType     `json:"type"`
+	SenderID    string              `json:"sender_id"`
+	SenderName  string              `json:"sender_name"`
+	Content     string              `json:"content"`
+	Timestamp   time.Time           `json:"timestamp"`
+	Reactions   map[string][]string `json:"reactions"` // emoji -> []userID
+	EditedAt    *time.Time          `json:"edited_at,omitempty"`
+	Revisions   []ChatRevision      `json:"revisions,omitempty"` // Oldest first
+	DeletedAt   *time.Time          `json:"deleted_at,omitempty"`
+	DeletedBy   string              `json:"deleted_by,omitempty"` // User ID of the author or moderator
+	ReplyTo     string              `json:"reply_to,omitempty"`   // ID of the thread's root message
+	ReplyCount  int                 `json:"reply_count,omitempty"`
+	Quote       *ChatQuote          `json:"quote,omitempty"`    // Parent snippet at the time of the reply
+	Mentions    []string            `json:"mentions,omitempty"` // Mentioned user IDs
+	AtHere      bool                `json:"at_here,omitempty"`  // A moderator pinged the whole room
+	Attachments []*Attachment       `json:"attachments,omitempty"`
+	Poll        *Poll               `json:"poll,omitempty"`   // Set on poll messages
+	Code        string              `json:"code,omitempty"`   // Set on structured system messages
+	Params      map[string]string   `json:"params,omitempty"` // Parameters for the code
 }
 
 // NewChatMessage creates a new user chat message
@@ -52,6 +141,23 @@ func NewSystemMessage(id, roomID, userID, userName, content string) *ChatMessage
 	}
 }
 
+// NewSystemEvent creates a structured system message from a code and its parameters; content
+// is the message rendered in the fallback locale, kept for exports and older clients
+func NewSystemEvent(id, roomID, userID, userName, code string, params map[string]string, content string) *ChatMessage {
+	msg := NewSystemMessage(id, roomID, userID, userName, content)
+	msg.Code = code
+	msg.Params = params
+	return msg
+}
+
+// NewPollMessage creates a message carrying a poll; its content is the question
+func NewPollMessage(id, roomID, senderID, senderName string, poll *Poll) *ChatMessage {
+	msg := NewChatMessage(id, roomID, senderID, senderName, poll.Question)
+	msg.Type = ChatMessageTypePoll
+	msg.Poll = poll
+	return msg
+}
+
 // AddReaction adds a reaction from a user
 func (m *ChatMessage) AddReaction(emoji, userID string) {
 	if m.Reactions == nil {
@@ -68,6 +174,59 @@ func (m *ChatMessage) AddReaction(emoji, userID string) {
 	m.Reactions[emoji] = append(m.Reactions[emoji], userID)
 }
 
+// React adds a user's reaction unless it would take the message past maxEmojis different
+// reactions (0 = no cap). The reactions map is replaced rather than changed, so readers holding
+// the old map are never raced.
+func (m *ChatMessage) React(emoji, userID string, maxEmojis int) error {
+	if m.IsDeleted() {
+		return ErrReactionOnDeleted
+	}
+
+	users, exists := m.Reactions[emoji]
+	for _, uid := range users {
+		if uid == userID {
+			return nil // Already reacted
+		}
+	}
+	if !exists && maxEmojis > 0 && len(m.Reactions) >= maxEmojis {
+		return ErrTooManyReactions
+	}
+
+	reactions := m.cloneReactions()
+	reactions[emoji] = append(append([]string(nil), users...), userID)
+	m.Reactions = reactions
+	return nil
+}
+
+// Unreact removes a user's reaction, replacing the reactions map like React
+func (m *ChatMessage) Unreact(emoji, userID string) {
+	users := m.Reactions[emoji]
+	for i, uid := range users {
+		if uid != userID {
+			continue
+		}
+
+		reactions := m.cloneReactions()
+		remaining := append(append([]string(nil), users[:i]...), users[i+1:]...)
+		if len(remaining) == 0 {
+			delete(reactions, emoji)
+		} else {
+			reactions[emoji] = remaining
+		}
+		m.Reactions = reactions
+		return
+	}
+}
+
+// cloneReactions copies the reactions map (the user lists are shared until replaced)
+func (m *ChatMessage) cloneReactions() map[string][]string {
+	reactions := make(map[string][]string, len(m.Reactions)+1)
+	for emoji, users := range m.Reactions {
+		reactions[emoji] = users
+	}
+	return reactions
+}
+
 // RemoveReaction removes a reaction from a user
 func (m *ChatMessage) RemoveReaction(emoji, userID string) {
 	if m.Reactions == nil {
@@ -87,3 +246,128 @@ func (m *ChatMessage) RemoveReaction(emoji, userID string) {
 		delete(m.Reactions, emoji)
 	}
 }
+
+// Edit replaces the content, keeping the previous version in the revision history
+func (m *ChatMessage) Edit(content string, now time.Time) {
+	m.Revisions = append(m.Revisions, ChatRevision{Content: m.Content, EditedAt: now})
+	if len(m.Revisions) > MaxChatRevisions {
+		m.Revisions = m.Revisions[len(m.Revisions)-MaxChatRevisions:]
+	}
+	m.Content = content
+	m.EditedAt = &now
+}
+
+// CheckEdit reports why a user may not edit the message, if anything; window is how long after
+// sending a message can be edited
+func (m *ChatMessage) CheckEdit(userID string, window time.Duration, now time.Time) error {
+	switch {
+	case m.Type == ChatMessageTypeSystem:
+		return ErrSystemMessage
+	case m.Type == ChatMessageTypePoll:
+		return ErrPollNotEditable
+	case m.IsDeleted():
+		return ErrMessageDeleted
+	case m.SenderID != userID:
+		return ErrNotMessageAuthor
+	case now.Sub(m.Timestamp) > window:
+		return ErrEditWindowExpired
+	}
+	return nil
+}
+
+// CheckDelete reports why a user may not delete the message, if anything; moderators may
+// delete any message, authors their own
+func (m *ChatMessage) CheckDelete(userID string, moderator bool) error {
+	if m.IsDeleted() {
+		return ErrMessageDeleted
+	}
+	if moderator || m.IsAuthor(userID) {
+		return nil
+	}
+	if m.Type == ChatMessageTypeSystem {
+		return ErrSystemMessage
+	}
+	return ErrNotAllowedToDelete
+}
+
+// IsAuthor checks if a user wrote the message; nobody writes system messages
+func (m *ChatMessage) IsAuthor(userID string) bool {
+	return m.Type != ChatMessageTypeSystem && m.SenderID == userID
+}
+
+// Delete turns the message into a tombstone, dropping its content, history and reactions
+func (m *ChatMessage) Delete(deletedBy string, now time.Time) {
+	m.Content = ""
+	m.Revisions = nil
+	m.Reactions = make(map[string][]string)
+	m.Attachments = nil
+	m.Poll = nil
+	m.DeletedAt = &now
+	m.DeletedBy = deletedBy
+}
+
+// ClearQuote hides the quoted snippet after the parent was deleted
+func (m *ChatMessage) ClearQuote() {
+	if m.Quote == nil {
+		return
+	}
+	m.Quote.Snippet = ""
+	m.Quote.Deleted = true
+}
+
+// IsReply checks if the message belongs to a thread
+func (m *ChatMessage) IsReply() bool {
+	return m.ReplyTo != ""
+}
+
+// IsDeleted checks if the message is a tombstone
+func (m *ChatMessage) IsDeleted() bool {
+	return m.DeletedAt != nil
+}
+
+// Clone returns a copy of the message that shares nothing it could change
+func (m *ChatMessage) Clone() *ChatMessage {
+	c := *m
+	if m.Reactions != nil {
+		c.Reactions = make(map[string][]string, len(m.Reactions))
+		for emoji, users := range m.Reactions {
+			c.Reactions[emoji] = append([]string(nil), users...)
+		}
+	}
+	if m.EditedAt != nil {
+		editedAt := *m.EditedAt
+		c.EditedAt = &editedAt
+	}
+	if m.DeletedAt != nil {
+		deletedAt := *m.DeletedAt
+		c.DeletedAt = &deletedAt
+	}
+	c.Revisions = append([]ChatRevision(nil), m.Revisions...)
+	if m.Quote != nil {
+		quote := *m.Quote
+		c.Quote = &quote
+	}
+	c.Mentions = append([]string(nil), m.Mentions...)
+	if m.Attachments != nil {
+		c.Attachments = make([]*Attachment, len(m.Attachments))
+		for i, att := range m.Attachments {
+			copied := *att
+			c.Attachments[i] = &copied
+		}
+	}
+	if m.Poll != nil {
+		c.Poll = m.Poll.Clone()
+	}
+	if m.Params != nil {
+		c.Params = make(map[string]string, len(m.Params))
+		for key, value := range m.Params {
+			c.Params[key] = value
+		}
+	}
+	return &c
+}
+
+// IsEdited checks if the message has been edited
+func (m *ChatMessage) IsEdited() bool {
+	return m.EditedAt != nil
+}
@@ -0,0 +1,78 @@
+package entity
+
+import (
+	"time"
+)
+
+// MaxDMReportContext is the number of earlier conversation messages kept with a report
+const MaxDMReportContext = 10
+
+// DirectMessagesName is the name moderation policies for direct messages are set under
+const DirectMessagesName = "Direct messages"
+
+// DirectMessage represents a private message between two users
+type DirectMessage struct {
+	ID            string    `json:"id"`
+	SenderID      string    `json:"sender_id"`
+	SenderName    string    `json:"sender_name"`
+	RecipientID   string    `json:"recipient_id"`
+	RecipientName string    `json:"recipient_name"`
+	Content       string    `json:"content"`
+	Timestamp     time.Time `json:"timestamp"`
+}
+
+// NewDirectMessage creates a new direct message
+func NewDirectMessage(id, senderID, senderName, recipientID, recipientName, content string) *DirectMessage {
+	return &DirectMessage{
+		ID:            id,
+		SenderID:      senderID,
+		SenderName:    senderName,
+		RecipientID:   recipientID,
+		RecipientName: recipientName,
+		Content:       content,
+		Timestamp:     time.Now(),
+	}
+}
+
+// ConversationID returns the key shared by both directions of a conversation
+func (m *DirectMessage) ConversationID() string {
+	return ConversationID(m.SenderID, m.RecipientID)
+}
+
+// IsParticipant checks if a user sent or received the message
+func (m *DirectMessage) IsParticipant(userID string) bool {
+	return m.SenderID == userID || m.RecipientID == userID
+}
+
+// ConversationID returns the key for the conversation between two users, independent of order
+func ConversationID(userA, userB string) string {
+	if userA > userB {
+		userA, userB = userB, userA
+	}
+	return userA + ":" + userB
+}
+
+// DMReport is an audit record of a direct message reported to admins. It keeps a copy of the
+// message and its context so the report outlives DM retention.
+type DMReport struct {
+	ID           string
+	ReporterID   string
+	ReporterName string
+	Reason       string
+	Message      *DirectMessage
+	Context      []*DirectMessage // Earlier messages in the conversation, oldest first
+	CreatedAt    time.Time
+}
+
+// NewDMReport creates a new report of a direct message
+func NewDMReport(id, reporterID, reporterName, reason string, message *DirectMessage, context []*DirectMessage) *DMReport {
+	return &DMReport{
+		ID:           id,
+		ReporterID:   reporterID,
+		ReporterName: reporterName,
+		Reason:       reason,
+		Message:      message,
+		Context:      context,
+		CreatedAt:    time.Now(),
+	}
+}
@@ -17,6 +17,8 @@ const (
 	EventTypeUpdateProfile EventType = "update_profile"
 	EventTypeMuteSelf      EventType = "mute_self"
 	EventTypeUnmuteSelf    EventType = "unmute_self"
+	EventTypeListenRoom    EventType = "listen_room"
+	EventTypeUnlistenRoom  EventType = "unlisten_room"
 
 	// Server -> Client events
 	EventTypeRoomJoined       EventType = "room_joined"
@@ -32,6 +34,10 @@ const (
 	EventTypeError            EventType = "error"
 	EventTypeConnected        EventType = "connected"
 	EventTypeParticipantsList EventType = "participants_list"
+	EventTypeRoomListening    EventType = "room_listening"
+	EventTypeRoomUnlistened   EventType = "room_unlistened"
+	EventTypeListenerJoined   EventType = "listener_joined"
+	EventTypeListenerLeft     EventType = "listener_left"
 
 	// Admin events
 	EventTypeAdminAuth        EventType = "admin_auth"
@@ -44,9 +50,87 @@ const (
 	EventTypeAdminGetStats    EventType = "admin_get_stats"
 	EventTypeAdminStats       EventType = "admin_stats"
 	EventTypeAdminJoinStealth EventType = "admin_join_stealth"
+	EventTypeAdminListenRoles EventType = "admin_set_listen_roles"
 	EventTypeUserKicked       EventType = "user_kicked"
 	EventTypeUserBanned       EventType = "user_banned"
 	EventTypeRoomClosed       EventType = "room_closed"
+
+	// Announcement events
+	EventTypeBroadcastAnnouncement EventType = "broadcast_announcement"
+	EventTypeStopVoicePA           EventType = "stop_voice_pa"
+	EventTypeAnnouncement          EventType = "announcement"
+	EventTypeVoicePATokens         EventType = "voice_pa_tokens"
+	EventTypeVoicePAStarted        EventType = "voice_pa_started"
+	EventTypeVoicePAEnded          EventType = "voice_pa_ended"
+
+	// Scheduled announcement and message-of-the-day events (admin)
+	EventTypeAdminScheduleAnnouncement EventType = "admin_schedule_announcement"
+	EventTypeAdminListScheduled        EventType = "admin_list_scheduled_announcements"
+	EventTypeAdminSetScheduledEnabled  EventType = "admin_set_scheduled_announcement_enabled"
+	EventTypeAdminDeleteScheduled      EventType = "admin_delete_scheduled_announcement"
+	EventTypeAdminSetMessageOfTheDay   EventType = "admin_set_motd"
+	EventTypeScheduledAnnouncements    EventType = "scheduled_announcements"
+	EventTypeMessageOfTheDay           EventType = "motd"
+
+	// Maintenance and shutdown events
+	EventTypeAdminScheduleMaintenance EventType = "admin_schedule_maintenance"
+	EventTypeAdminCancelMaintenance   EventType = "admin_cancel_maintenance"
+	EventTypeMaintenanceScheduled     EventType = "maintenance_scheduled"
+	EventTypeMaintenanceStarted       EventType = "maintenance_started"
+	EventTypeMaintenanceCancelled     EventType = "maintenance_cancelled"
+	EventTypeServerShutdown           EventType = "server_shutdown"
+
+	// Direct message events
+	EventTypeDMSend           EventType = "dm_send"
+	EventTypeDMMessage        EventType = "dm_message"
+	EventTypeGetDMHistory     EventType = "get_dm_history"
+	EventTypeDMHistory        EventType = "dm_history"
+	EventTypeDMBlock          EventType = "dm_block"
+	EventTypeDMUnblock        EventType = "dm_unblock"
+	EventTypeGetDMBlocks      EventType = "get_dm_blocks"
+	EventTypeDMBlockList      EventType = "dm_block_list"
+	EventTypeDMReport         EventType = "dm_report"
+	EventTypeDMReportResult   EventType = "dm_report_result"
+	EventTypeAdminDMReports   EventType = "admin_list_dm_reports"
+	EventTypeDMReports        EventType = "dm_reports"
+	EventTypeDMReportReceived EventType = "dm_reported"
+
+	// Chat moderation events
+	EventTypeChatWarning     EventType = "chat_warning"
+	EventTypeChatFlagged     EventType = "chat_flagged"
+	EventTypeChatMuted       EventType = "chat_muted"
+	EventTypeSetSlowMode     EventType = "set_slow_mode"
+	EventTypeSlowModeChanged EventType = "slow_mode_changed"
+	EventTypeCommandReply    EventType = "command_reply"
+
+	// Room topic and pinned message events
+	EventTypeSetTopic     EventType = "set_topic"
+	EventTypePinMessage   EventType = "pin_message"
+	EventTypeUnpinMessage EventType = "unpin_message"
+	EventTypeRoomUpdated  EventType = "room_updated"
+
+	// Poll events
+	EventTypeCreatePoll  EventType = "create_poll"
+	EventTypePollVote    EventType = "poll_vote"
+	EventTypeClosePoll   EventType = "close_poll"
+	EventTypePollUpdated EventType = "poll_updated"
+
+	// Chat retention events
+	EventTypeAdminSetRetention EventType = "admin_set_retention"
+	EventTypeAdminListArchives EventType = "admin_list_archives"
+	EventTypeRoomRetention     EventType = "room_retention"
+	EventTypeChatArchives      EventType = "chat_archives"
+
+	// Lobby chat events
+	EventTypeLobbyChatJoin           EventType = "lobby_chat_join"
+	EventTypeLobbyChatLeave          EventType = "lobby_chat_leave"
+	EventTypeLobbyChatMessage        EventType = "lobby_chat_message"
+	EventTypeLobbyChatDelete         EventType = "lobby_chat_delete"
+	EventTypeLobbyChatMute           EventType = "lobby_chat_mute"
+	EventTypeLobbyChatJoined         EventType = "lobby_chat_joined"
+	EventTypeLobbyChatLeft           EventType = "lobby_chat_left"
+	EventTypeLobbyChatMessageDeleted EventType = "lobby_chat_message_deleted"
+	EventTypeLobbyChatMuted          EventType = "lobby_chat_muted"
 )
 
 // Event represents a WebSocket message
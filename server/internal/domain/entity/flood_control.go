@@ -0,0 +1,107 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrInvalidFloodControl is returned for flood control settings that can't be applied
+var ErrInvalidFloodControl = errors.New("invalid flood control settings")
+
+// FloodBucket is a token bucket: Burst messages at once, refilled at Rate messages per second.
+// A zero bucket doesn't limit anything.
+type FloodBucket struct {
+	Burst int     `json:"burst" yaml:"burst"`
+	Rate  float64 `json:"rate" yaml:"rate"`
+}
+
+// FloodLimit holds the buckets for one message type. The IP bucket is shared by everyone
+// behind the same address (a whole café on one NAT), so it should be much looser.
+type FloodLimit struct {
+	Connection FloodBucket `json:"connection" yaml:"connection"`
+	IP         FloodBucket `json:"ip" yaml:"ip"`
+}
+
+// FloodEscalation decides how repeat offenders are treated. Each rejected message is a strike;
+// strikes are forgotten once a sender stays within limits for the strike window.
+type FloodEscalation struct {
+	WarnAfter           int `json:"warn_after" yaml:"warn_after"` // Strikes before the sender is warned instead of silently dropped
+	MuteAfter           int `json:"mute_after" yaml:"mute_after"` // Strikes before the sender is muted (0 never mutes)
+	StrikeWindowSeconds int `json:"strike_window_seconds" yaml:"strike_window_seconds"`
+	MuteSeconds         int `json:"mute_seconds" yaml:"mute_seconds"`
+}
+
+// FloodControlConfig holds the rate limits for incoming messages
+type FloodControlConfig struct {
+	Default    FloodLimit            `json:"default" yaml:"default"` // Every message type without limits of its own
+	Types      map[string]FloodLimit `json:"types" yaml:"types"`     // Message type -> limits, replacing the default
+	Escalation FloodEscalation       `json:"escalation" yaml:"escalation"`
+}
+
+// DefaultFloodControlConfig keeps chat-like messages tight and everything else loose enough
+// for normal use. Uploads and exports are HTTP requests, limited as "upload" and "export".
+func DefaultFloodControlConfig() *FloodControlConfig {
+	chat := FloodLimit{Connection: FloodBucket{Burst: 5, Rate: 1}, IP: FloodBucket{Burst: 30, Rate: 6}}
+	reaction := FloodLimit{Connection: FloodBucket{Burst: 10, Rate: 2}, IP: FloodBucket{Burst: 60, Rate: 12}}
+
+	return &FloodControlConfig{
+		Default: FloodLimit{Connection: FloodBucket{Burst: 20, Rate: 5}, IP: FloodBucket{Burst: 200, Rate: 50}},
+		Types: map[string]FloodLimit{
+			"chat_message":       chat,
+			"chat_edit":          chat,
+			"chat_delete":        chat,
+			"dm_send":            chat,
+			"lobby_chat_message": chat,
+			"chat_reaction_add":  reaction,
+			"poll_vote":          reaction,
+			"typing":             reaction,
+			"search_chat":        {Connection: FloodBucket{Burst: 5, Rate: 0.5}, IP: FloodBucket{Burst: 30, Rate: 3}},
+			"join_room":          {Connection: FloodBucket{Burst: 3, Rate: 0.2}, IP: FloodBucket{Burst: 20, Rate: 2}},
+			"listen_room":        {Connection: FloodBucket{Burst: 3, Rate: 0.2}, IP: FloodBucket{Burst: 20, Rate: 2}},
+			"upload":             {Connection: FloodBucket{Burst: 5, Rate: 0.2}, IP: FloodBucket{Burst: 20, Rate: 1}},
+			"create_poll":        {Connection: FloodBucket{Burst: 2, Rate: 0.05}, IP: FloodBucket{Burst: 10, Rate: 0.5}},
+			"export":             {Connection: FloodBucket{Burst: 3, Rate: 0.05}, IP: FloodBucket{Burst: 10, Rate: 0.2}},
+		},
+		Escalation: FloodEscalation{
+			WarnAfter:           2,
+			MuteAfter:           10,
+			StrikeWindowSeconds: 60,
+			MuteSeconds:         120,
+		},
+	}
+}
+
+// Limit returns the limits for a message type
+func (c *FloodControlConfig) Limit(msgType string) FloodLimit {
+	if l, exists := c.Types[msgType]; exists {
+		return l
+	}
+	return c.Default
+}
+
+// Validate checks that no limit or escalation setting is negative
+func (c *FloodControlConfig) Validate() error {
+	check := func(scope string, l FloodLimit) error {
+		for _, b := range []FloodBucket{l.Connection, l.IP} {
+			if b.Burst < 0 || b.Rate < 0 {
+				return fmt.Errorf("%w: %s has a negative burst or rate", ErrInvalidFloodControl, scope)
+			}
+		}
+		return nil
+	}
+
+	if err := check("default", c.Default); err != nil {
+		return err
+	}
+	for msgType, l := range c.Types {
+		if err := check(fmt.Sprintf("type %q", msgType), l); err != nil {
+			return err
+		}
+	}
+
+	e := c.Escalation
+	if e.WarnAfter < 0 || e.MuteAfter < 0 || e.StrikeWindowSeconds < 0 || e.MuteSeconds < 0 {
+		return fmt.Errorf("%w: escalation settings can't be negative", ErrInvalidFloodControl)
+	}
+	return nil
+}
@@ -0,0 +1,38 @@
+package entity
+
+import (
+	"time"
+)
+
+// MaintenanceWindow represents a scheduled maintenance period
+type MaintenanceWindow struct {
+	Message     string
+	ScheduledBy string // Admin ID
+	ScheduledAt time.Time
+	StartsAt    time.Time
+}
+
+// NewMaintenanceWindow creates a maintenance window starting after the given delay
+func NewMaintenanceWindow(message, scheduledBy string, delay time.Duration) *MaintenanceWindow {
+	now := time.Now()
+	return &MaintenanceWindow{
+		Message:     message,
+		ScheduledBy: scheduledBy,
+		ScheduledAt: now,
+		StartsAt:    now.Add(delay),
+	}
+}
+
+// IsActive checks if maintenance has started
+func (m *MaintenanceWindow) IsActive(now time.Time) bool {
+	return !now.Before(m.StartsAt)
+}
+
+// TimeRemaining returns the time until maintenance starts
+func (m *MaintenanceWindow) TimeRemaining(now time.Time) time.Duration {
+	remaining := m.StartsAt.Sub(now)
+	if remaining < 0 {
+		return 0
+	}
+	return remaining
+}
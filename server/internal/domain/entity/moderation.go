@@ -0,0 +1,213 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var ErrInvalidModerationPolicy = errors.New("invalid moderation policy")
+
+// ModerationAction is what happens to a chat message that trips a filter
+type ModerationAction string
+
+const (
+	ModerationActionOff   ModerationAction = "off"   // Filter disabled
+	ModerationActionBlock ModerationAction = "block" // Message is rejected
+	ModerationActionMask  ModerationAction = "mask"  // Offending text is rewritten and the message is sent
+	ModerationActionFlag  ModerationAction = "flag"  // Message is sent and recorded for admin review
+	ModerationActionWarn  ModerationAction = "warn"  // Message is sent and the sender is warned
+)
+
+// IsValid checks if the action is a known moderation action
+func (a ModerationAction) IsValid() bool {
+	switch a {
+	case ModerationActionOff, ModerationActionBlock, ModerationActionMask, ModerationActionFlag, ModerationActionWarn:
+		return true
+	}
+	return false
+}
+
+// Moderation filter defaults
+const (
+	DefaultMaxMessageLength = 2000
+	DefaultMaxRepeatedRun   = 6
+	DefaultCapsMinLetters   = 10
+	DefaultCapsMaxRatio     = 0.7
+)
+
+// DefaultInvitePatterns are the invite link hosts blocked unless the policy lists its own
+var DefaultInvitePatterns = []string{
+	"discord.gg",
+	"discord.com/invite",
+	"discordapp.com/invite",
+	"t.me",
+	"chat.whatsapp.com",
+}
+
+// MaxLengthRule limits message length in characters; masking truncates
+type MaxLengthRule struct {
+	Action ModerationAction `json:"action" yaml:"action"`
+	Limit  int              `json:"limit" yaml:"limit"`
+}
+
+// ProfanityRule matches listed words, including leetspeak spellings; masking stars them out
+type ProfanityRule struct {
+	Action ModerationAction `json:"action" yaml:"action"`
+	Words  []string         `json:"words" yaml:"words"`
+}
+
+// LinkRule restricts links by domain; masking removes them. With an allow list only those
+// domains (and their subdomains) pass; deny-listed domains never pass.
+type LinkRule struct {
+	Action ModerationAction `json:"action" yaml:"action"`
+	Allow  []string         `json:"allow" yaml:"allow"`
+	Deny   []string         `json:"deny" yaml:"deny"`
+}
+
+// RepeatedCharsRule limits runs of the same character; masking shortens them
+type RepeatedCharsRule struct {
+	Action ModerationAction `json:"action" yaml:"action"`
+	MaxRun int              `json:"max_run" yaml:"max_run"`
+}
+
+// CapsRule limits shouting; masking lowercases the message
+type CapsRule struct {
+	Action     ModerationAction `json:"action" yaml:"action"`
+	MinLetters int              `json:"min_letters" yaml:"min_letters"` // Shorter messages are never checked
+	MaxRatio   float64          `json:"max_ratio" yaml:"max_ratio"`     // Largest allowed share of uppercase letters
+}
+
+// InviteRule catches invite links to other chat services; masking removes them
+type InviteRule struct {
+	Action   ModerationAction `json:"action" yaml:"action"`
+	Patterns []string         `json:"patterns" yaml:"patterns"`
+}
+
+// ModerationPolicy configures the chat filters. A nil rule is inherited from the default
+// policy when used as a room override, and disabled otherwise.
+type ModerationPolicy struct {
+	MaxLength     *MaxLengthRule     `json:"max_length,omitempty" yaml:"max_length,omitempty"`
+	Invites       *InviteRule        `json:"invites,omitempty" yaml:"invites,omitempty"`
+	Links         *LinkRule          `json:"links,omitempty" yaml:"links,omitempty"`
+	Profanity     *ProfanityRule     `json:"profanity,omitempty" yaml:"profanity,omitempty"`
+	RepeatedChars *RepeatedCharsRule `json:"repeated_chars,omitempty" yaml:"repeated_chars,omitempty"`
+	Caps          *CapsRule          `json:"caps,omitempty" yaml:"caps,omitempty"`
+}
+
+// ModerationConfig is the default policy plus per-room overrides keyed by room name
+type ModerationConfig struct {
+	Default ModerationPolicy            `json:"default" yaml:"default"`
+	Rooms   map[string]ModerationPolicy `json:"rooms" yaml:"rooms"`
+}
+
+// DefaultModerationConfig only enforces the maximum message length
+func DefaultModerationConfig() *ModerationConfig {
+	return &ModerationConfig{
+		Default: ModerationPolicy{
+			MaxLength: &MaxLengthRule{Action: ModerationActionBlock, Limit: DefaultMaxMessageLength},
+		},
+	}
+}
+
+// ForRoom returns the room's policy with unset rules taken from the default policy
+func (c *ModerationConfig) ForRoom(roomName string) ModerationPolicy {
+	policy := c.Default
+	for name, override := range c.Rooms {
+		if !strings.EqualFold(name, roomName) {
+			continue
+		}
+		if override.MaxLength != nil {
+			policy.MaxLength = override.MaxLength
+		}
+		if override.Invites != nil {
+			policy.Invites = override.Invites
+		}
+		if override.Links != nil {
+			policy.Links = override.Links
+		}
+		if override.Profanity != nil {
+			policy.Profanity = override.Profanity
+		}
+		if override.RepeatedChars != nil {
+			policy.RepeatedChars = override.RepeatedChars
+		}
+		if override.Caps != nil {
+			policy.Caps = override.Caps
+		}
+	}
+	return policy
+}
+
+// Normalize fills in defaults and validates every policy
+func (c *ModerationConfig) Normalize() error {
+	if err := c.Default.normalize("default"); err != nil {
+		return err
+	}
+	for name, policy := range c.Rooms {
+		if err := policy.normalize(fmt.Sprintf("room %q", name)); err != nil {
+			return err
+		}
+		c.Rooms[name] = policy
+	}
+	return nil
+}
+
+func (p *ModerationPolicy) normalize(scope string) error {
+	check := func(filter string, action *ModerationAction) error {
+		if *action == "" {
+			*action = ModerationActionBlock
+		}
+		if !action.IsValid() {
+			return fmt.Errorf("%w: %s %s filter has unknown action %q", ErrInvalidModerationPolicy, scope, filter, *action)
+		}
+		return nil
+	}
+
+	if r := p.MaxLength; r != nil {
+		if err := check("max_length", &r.Action); err != nil {
+			return err
+		}
+		if r.Limit <= 0 {
+			r.Limit = DefaultMaxMessageLength
+		}
+	}
+	if r := p.Invites; r != nil {
+		if err := check("invites", &r.Action); err != nil {
+			return err
+		}
+		if len(r.Patterns) == 0 {
+			r.Patterns = DefaultInvitePatterns
+		}
+	}
+	if r := p.Links; r != nil {
+		if err := check("links", &r.Action); err != nil {
+			return err
+		}
+	}
+	if r := p.Profanity; r != nil {
+		if err := check("profanity", &r.Action); err != nil {
+			return err
+		}
+	}
+	if r := p.RepeatedChars; r != nil {
+		if err := check("repeated_chars", &r.Action); err != nil {
+			return err
+		}
+		if r.MaxRun <= 0 {
+			r.MaxRun = DefaultMaxRepeatedRun
+		}
+	}
+	if r := p.Caps; r != nil {
+		if err := check("caps", &r.Action); err != nil {
+			return err
+		}
+		if r.MinLetters <= 0 {
+			r.MinLetters = DefaultCapsMinLetters
+		}
+		if r.MaxRatio <= 0 || r.MaxRatio > 1 {
+			r.MaxRatio = DefaultCapsMaxRatio
+		}
+	}
+	return nil
+}
@@ -0,0 +1,163 @@
+package entity
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Poll limits
+const (
+	MinPollOptions        = 2
+	MaxPollOptions        = 10
+	MaxPollQuestionLength = 200
+	MaxPollOptionLength   = 100
+	MaxPollDuration       = 24 * time.Hour
+)
+
+var (
+	ErrInvalidPoll = errors.New("a poll needs a question and 2 to 10 distinct options")
+	ErrPollClosed  = errors.New("poll is closed")
+	ErrInvalidVote = errors.New("invalid poll choice")
+	ErrNotAPoll    = errors.New("message is not a poll")
+)
+
+// Poll is a question posted to a room chat with options to vote on
+type Poll struct {
+	Question    string           `json:"question"`
+	Options     []string         `json:"options"`
+	MultiChoice bool             `json:"multi_choice,omitempty"`
+	Anonymous   bool             `json:"anonymous,omitempty"` // Voters are never shown, only counts
+	ClosesAt    *time.Time       `json:"closes_at,omitempty"`
+	ClosedAt    *time.Time       `json:"closed_at,omitempty"`
+	Votes       map[string][]int `json:"votes"` // userID -> chosen option indexes
+}
+
+// NewPoll creates a poll after validating its question and options; a zero duration never expires
+func NewPoll(question string, options []string, multiChoice, anonymous bool, duration time.Duration, now time.Time) (*Poll, error) {
+	question = strings.TrimSpace(question)
+	if question == "" || len([]rune(question)) > MaxPollQuestionLength {
+		return nil, ErrInvalidPoll
+	}
+	if len(options) < MinPollOptions || len(options) > MaxPollOptions {
+		return nil, ErrInvalidPoll
+	}
+	if duration < 0 || duration > MaxPollDuration {
+		return nil, ErrInvalidPoll
+	}
+
+	seen := make(map[string]bool, len(options))
+	cleaned := make([]string, len(options))
+	for i, option := range options {
+		option = strings.TrimSpace(option)
+		key := strings.ToLower(option)
+		if option == "" || len([]rune(option)) > MaxPollOptionLength || seen[key] {
+			return nil, ErrInvalidPoll
+		}
+		seen[key] = true
+		cleaned[i] = option
+	}
+
+	poll := &Poll{
+		Question:    question,
+		Options:     cleaned,
+		MultiChoice: multiChoice,
+		Anonymous:   anonymous,
+		Votes:       make(map[string][]int),
+	}
+	if duration > 0 {
+		closesAt := now.Add(duration)
+		poll.ClosesAt = &closesAt
+	}
+	return poll, nil
+}
+
+// IsClosed checks if the poll was closed or its timer ran out
+func (p *Poll) IsClosed(now time.Time) bool {
+	return p.ClosedAt != nil || (p.ClosesAt != nil && !now.Before(*p.ClosesAt))
+}
+
+// Vote replaces a user's choices; no choices withdraws the vote
+func (p *Poll) Vote(userID string, choices []int, now time.Time) error {
+	if p.IsClosed(now) {
+		return ErrPollClosed
+	}
+	if len(choices) > 1 && !p.MultiChoice {
+		return ErrInvalidVote
+	}
+
+	seen := make(map[int]bool, len(choices))
+	for _, c := range choices {
+		if c < 0 || c >= len(p.Options) || seen[c] {
+			return ErrInvalidVote
+		}
+		seen[c] = true
+	}
+
+	if p.Votes == nil {
+		p.Votes = make(map[string][]int)
+	}
+	if len(choices) == 0 {
+		delete(p.Votes, userID)
+		return nil
+	}
+
+	sorted := append([]int(nil), choices...)
+	sort.Ints(sorted)
+	p.Votes[userID] = sorted
+	return nil
+}
+
+// Close ends voting, reporting whether the poll was still open
+func (p *Poll) Close(now time.Time) bool {
+	if p.ClosedAt != nil {
+		return false
+	}
+	// A poll whose timer ran out is closed at that time, not when someone noticed
+	if p.ClosesAt != nil && now.After(*p.ClosesAt) {
+		now = *p.ClosesAt
+	}
+	p.ClosedAt = &now
+	return true
+}
+
+// Tally counts the votes for each option
+func (p *Poll) Tally() []int {
+	counts := make([]int, len(p.Options))
+	for _, choices := range p.Votes {
+		for _, c := range choices {
+			if c >= 0 && c < len(counts) {
+				counts[c]++
+			}
+		}
+	}
+	return counts
+}
+
+// Voters lists who chose each option, sorted by user ID
+func (p *Poll) Voters() [][]string {
+	voters := make([][]string, len(p.Options))
+	for userID, choices := range p.Votes {
+		for _, c := range choices {
+			if c >= 0 && c < len(voters) {
+				voters[c] = append(voters[c], userID)
+			}
+		}
+	}
+	for _, v := range voters {
+		sort.Strings(v)
+	}
+	return voters
+}
+
+// Clone copies the poll so it can be read while votes keep changing the original
+func (p *Poll) Clone() *Poll {
+	c := *p
+	c.Options = append([]string(nil), p.Options...)
+	c.Votes = make(map[string][]int, len(p.Votes))
+	for userID, choices := range p.Votes {
+		c.Votes[userID] = append([]int(nil), choices...)
+	}
+	return &c
+}
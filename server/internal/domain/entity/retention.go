@@ -0,0 +1,106 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// RetentionMode selects what happens to a room's chat over time and when the room goes away
+type RetentionMode string
+
+const (
+	RetentionEphemeral RetentionMode = "ephemeral" // Default limits, deleted with the room
+	RetentionMessages  RetentionMode = "messages"  // Keep the newest N messages
+	RetentionDays      RetentionMode = "days"      // Keep messages for N days
+	RetentionArchive   RetentionMode = "archive"   // Kept while the room exists, archived when it closes
+)
+
+// Retention limits
+const (
+	MaxRetainedMessages = 10000
+	MaxRetentionDays    = 365
+
+	// RetainedChatTTL is how long kept messages live in stores that expire data, for modes that
+	// are not limited by age
+	RetainedChatTTL = 30 * 24 * time.Hour
+)
+
+var ErrInvalidRetention = errors.New("invalid chat retention policy")
+
+// ChatRetention is a room's chat retention policy
+type ChatRetention struct {
+	Mode     RetentionMode `json:"mode" yaml:"mode"`
+	Messages int           `json:"messages,omitempty" yaml:"messages"` // For RetentionMessages
+	Days     int           `json:"days,omitempty" yaml:"days"`         // For RetentionDays
+}
+
+// Normalize fills in the default mode and validates the policy
+func (p *ChatRetention) Normalize() error {
+	p.Mode = RetentionMode(strings.ToLower(strings.TrimSpace(string(p.Mode))))
+	switch p.Mode {
+	case "", RetentionEphemeral:
+		*p = ChatRetention{Mode: RetentionEphemeral}
+	case RetentionArchive:
+		*p = ChatRetention{Mode: RetentionArchive}
+	case RetentionMessages:
+		if p.Messages < 1 || p.Messages > MaxRetainedMessages {
+			return fmt.Errorf("%w: messages must be between 1 and %d", ErrInvalidRetention, MaxRetainedMessages)
+		}
+		p.Days = 0
+	case RetentionDays:
+		if p.Days < 1 || p.Days > MaxRetentionDays {
+			return fmt.Errorf("%w: days must be between 1 and %d", ErrInvalidRetention, MaxRetentionDays)
+		}
+		p.Messages = 0
+	default:
+		return fmt.Errorf("%w: unknown mode %q", ErrInvalidRetention, p.Mode)
+	}
+	return nil
+}
+
+// Limits returns how many messages a chat store should keep and for how long; zero values
+// leave the store's defaults in place
+func (p ChatRetention) Limits() (maxMessages int, ttl time.Duration) {
+	switch p.Mode {
+	case RetentionMessages:
+		return p.Messages, RetainedChatTTL
+	case RetentionDays:
+		return MaxRetainedMessages, p.MaxAge()
+	case RetentionArchive:
+		return MaxRetainedMessages, RetainedChatTTL
+	default:
+		return 0, 0
+	}
+}
+
+// MaxAge returns how old messages may get before they are pruned (0 = no age limit)
+func (p ChatRetention) MaxAge() time.Duration {
+	if p.Mode != RetentionDays {
+		return 0
+	}
+	return time.Duration(p.Days) * 24 * time.Hour
+}
+
+// Archives checks if the room's chat is archived when the room closes
+func (p ChatRetention) Archives() bool {
+	return p.Mode == RetentionArchive
+}
+
+// IsDefault checks if the policy leaves the store's defaults in place
+func (p ChatRetention) IsDefault() bool {
+	return p.Mode == "" || p.Mode == RetentionEphemeral
+}
+
+// ChatArchive describes a closed room's transcript kept in the archive store
+type ChatArchive struct {
+	ID             string    `json:"id"`
+	RoomID         string    `json:"room_id"`
+	RoomName       string    `json:"room_name"`
+	MessageCount   int       `json:"message_count"`
+	FirstMessageAt time.Time `json:"first_message_at"`
+	LastMessageAt  time.Time `json:"last_message_at"`
+	ArchivedAt     time.Time `json:"archived_at"`
+	Size           int64     `json:"size"` // Compressed bytes
+}
@@ -11,6 +11,10 @@ var (
 	ErrUserAlreadyInRoom = errors.New("user is already in this room")
 	ErrUserNotInRoom     = errors.New("user is not in this room")
 	ErrRoomClosed        = errors.New("room is closed")
+	ErrUserNotListening  = errors.New("user is not listening to this room")
+	ErrAlreadyPinned     = errors.New("message is already pinned")
+	ErrTooManyPins       = errors.New("room has too many pinned messages, unpin one first")
+	ErrNotPinned         = errors.New("message is not pinned")
 )
 
 // RoomType represents the type of room
@@ -21,14 +25,56 @@ const (
 	RoomTypePrivate RoomType = "private" // Hidden, join by name only
 )
 
+// VoicePolicy restricts which voice modes participants may use in a room
+type VoicePolicy string
+
+const (
+	VoicePolicyAny VoicePolicy = "any" // Participants choose PTT or VAD
+	VoicePolicyPTT VoicePolicy = "ptt" // Push-to-talk only
+	VoicePolicyVAD VoicePolicy = "vad" // Voice activity detection only
+)
+
+// IsValid checks if the policy is a known voice policy
+func (p VoicePolicy) IsValid() bool {
+	switch p {
+	case VoicePolicyAny, VoicePolicyPTT, VoicePolicyVAD:
+		return true
+	}
+	return false
+}
+
+// Apply returns the voice mode a participant ends up with under this policy
+func (p VoicePolicy) Apply(mode VoiceMode) VoiceMode {
+	switch p {
+	case VoicePolicyPTT:
+		return VoiceModePTT
+	case VoicePolicyVAD:
+		return VoiceModeVAD
+	}
+	return mode
+}
+
+// PresetRoomCreator is the CreatedBy value of rooms defined in the rooms file
+const PresetRoomCreator = "preset"
+
 // Room represents a voice chat room
 type Room struct {
 	ID           string
 	Name         string
 	Type         RoomType
 	Capacity     int
+	VoicePolicy  VoicePolicy
+	Description  string
+	Category     string
+	SortOrder    int
+	Persistent   bool             // Never cleaned up when empty (preset rooms)
+	SlowMode     time.Duration    // Minimum time between a member's chat messages (0 = off)
+	Topic        string           // Set by moderators with /topic
+	Pinned       []*PinnedMessage // Oldest first
+	Retention    ChatRetention    // What happens to the chat over time and when the room closes
 	Participants map[string]*User // userID -> User
-	CreatedBy    string           // "admin" or userID
+	Listeners    map[string]*User // userID -> listen-only User (not counted towards capacity)
+	CreatedBy    string           // "admin", "preset" or userID
 	CreatedAt    time.Time
 	LastActivity time.Time
 	IsClosed     bool
@@ -37,6 +83,20 @@ type Room struct {
 
 const DefaultRoomCapacity = 15
 
+// MaxTopicLength is the longest room topic in characters
+const MaxTopicLength = 200
+
+// DefaultMaxPins is how many messages a room can have pinned unless configured otherwise
+const DefaultMaxPins = 5
+
+// PinnedMessage is a chat message pinned to the top of a room. It holds a copy of the message
+// so the pin outlives chat history trimming.
+type PinnedMessage struct {
+	Message  *ChatMessage
+	PinnedBy string // Name of the moderator who pinned it
+	PinnedAt time.Time
+}
+
 // NewRoom creates a new room with default settings
 func NewRoom(id, name string, roomType RoomType, createdBy string) *Room {
 	now := time.Now()
@@ -45,7 +105,9 @@ func NewRoom(id, name string, roomType RoomType, createdBy string) *Room {
 		Name:         name,
 		Type:         roomType,
 		Capacity:     DefaultRoomCapacity,
+		VoicePolicy:  VoicePolicyAny,
 		Participants: make(map[string]*User),
+		Listeners:    make(map[string]*User),
 		CreatedBy:    createdBy,
 		CreatedAt:    now,
 		LastActivity: now,
@@ -99,6 +161,81 @@ func (r *Room) RemoveParticipant(userID string) error {
 	return nil
 }
 
+// AddListener adds a listen-only user to the room
+func (r *Room) AddListener(user *User) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.IsClosed {
+		return ErrRoomClosed
+	}
+
+	if _, exists := r.Participants[user.ID]; exists {
+		return ErrUserAlreadyInRoom
+	}
+
+	if _, exists := r.Listeners[user.ID]; exists {
+		return ErrUserAlreadyInRoom
+	}
+
+	if r.Listeners == nil {
+		r.Listeners = make(map[string]*User)
+	}
+	user.IsListener = true
+	r.Listeners[user.ID] = user
+	return nil
+}
+
+// RemoveListener removes a listen-only user from the room
+func (r *Room) RemoveListener(userID string) (*User, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	user, exists := r.Listeners[userID]
+	if !exists {
+		return nil, ErrUserNotListening
+	}
+
+	delete(r.Listeners, userID)
+	return user, nil
+}
+
+// RemoveAllListeners removes every listen-only user (e.g. when the room closes) and returns them
+func (r *Room) RemoveAllListeners() []*User {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	removed := make([]*User, 0, len(r.Listeners))
+	for userID, user := range r.Listeners {
+		removed = append(removed, user)
+		delete(r.Listeners, userID)
+	}
+	return removed
+}
+
+// IsListening checks if a user is listening to the room
+func (r *Room) IsListening(userID string) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	_, exists := r.Listeners[userID]
+	return exists
+}
+
+// GetVisibleListeners returns listeners visible to a specific viewer
+func (r *Room) GetVisibleListeners(viewer *User) []*User {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	visible := make([]*User, 0)
+	for _, listener := range r.Listeners {
+		if listener.CanBeSeenBy(viewer) {
+			visible = append(visible, listener)
+		}
+	}
+	return visible
+}
+
 // GetParticipant returns a participant by ID
 func (r *Room) GetParticipant(userID string) (*User, bool) {
 	r.mu.RLock()
@@ -187,11 +324,153 @@ func (r *Room) SetCapacity(capacity int) {
 	r.Capacity = capacity
 }
 
+// SetSlowMode changes the minimum time between a member's chat messages
+func (r *Room) SetSlowMode(cooldown time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.SlowMode = cooldown
+}
+
+// GetSlowMode returns the room's chat cooldown
+func (r *Room) GetSlowMode() time.Duration {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.SlowMode
+}
+
+// SetTopic changes the room's topic (empty clears it)
+func (r *Room) SetTopic(topic string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.Topic = topic
+}
+
+// GetTopic returns the room's topic
+func (r *Room) GetTopic() string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.Topic
+}
+
+// SetRetention changes the room's chat retention policy
+func (r *Room) SetRetention(policy ChatRetention) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.Retention = policy
+}
+
+// GetRetention returns the room's chat retention policy
+func (r *Room) GetRetention() ChatRetention {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.Retention
+}
+
+// Pin adds a copy of a message to the room's pins, up to maxPins
+func (r *Room) Pin(msg *ChatMessage, pinnedBy string, now time.Time, maxPins int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, p := range r.Pinned {
+		if p.Message.ID == msg.ID {
+			return ErrAlreadyPinned
+		}
+	}
+	if len(r.Pinned) >= maxPins {
+		return ErrTooManyPins
+	}
+
+	r.Pinned = append(r.Pinned, &PinnedMessage{Message: snapshot(msg), PinnedBy: pinnedBy, PinnedAt: now})
+	return nil
+}
+
+// Unpin removes a message from the room's pins
+func (r *Room) Unpin(messageID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, p := range r.Pinned {
+		if p.Message.ID == messageID {
+			r.Pinned = append(r.Pinned[:i:i], r.Pinned[i+1:]...)
+			return nil
+		}
+	}
+	return ErrNotPinned
+}
+
+// RefreshPin replaces a pinned message's copy after it was edited, reporting whether it is pinned
+func (r *Room) RefreshPin(msg *ChatMessage) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, p := range r.Pinned {
+		if p.Message.ID == msg.ID {
+			p.Message = snapshot(msg)
+			return true
+		}
+	}
+	return false
+}
+
+// GetPinned returns the room's pinned messages, oldest first
+func (r *Room) GetPinned() []*PinnedMessage {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	pinned := make([]*PinnedMessage, len(r.Pinned))
+	copy(pinned, r.Pinned)
+	return pinned
+}
+
+// snapshot copies the parts of a message shown in a pin
+func snapshot(msg *ChatMessage) *ChatMessage {
+	c := *msg
+	c.Revisions = nil
+	c.Reactions = make(map[string][]string)
+	return &c
+}
+
 // IsPublic checks if the room is publicly visible
 func (r *Room) IsPublic() bool {
 	return r.Type == RoomTypePublic
 }
 
+// ApplyPreset updates the room's settings from a preset and marks it persistent
+func (r *Room) ApplyPreset(preset *RoomPreset) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.Type = preset.Type
+	r.Capacity = preset.Capacity
+	r.VoicePolicy = preset.VoicePolicy
+	r.Description = preset.Description
+	r.Category = preset.Category
+	r.SortOrder = preset.SortOrder
+	r.Retention = preset.Retention
+	r.Persistent = true
+	r.IsClosed = false
+}
+
+// RetirePreset closes a room that was removed from the rooms file; it is cleaned up once empty
+func (r *Room) RetirePreset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.Persistent = false
+	r.IsClosed = true
+}
+
+// IsPersistent checks if the room should survive being empty
+func (r *Room) IsPersistent() bool {
+	return r.Persistent || r.IsAdminCreated()
+}
+
 // IsAdminCreated checks if the room was created by an admin
 func (r *Room) IsAdminCreated() bool {
 	return r.CreatedBy == "admin"
@@ -0,0 +1,72 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var ErrInvalidRoomPreset = errors.New("invalid room preset")
+
+// RoomPreset defines a persistent room loaded from the rooms file
+type RoomPreset struct {
+	Name        string        `json:"name" yaml:"name"`
+	Type        RoomType      `json:"type" yaml:"type"`
+	Capacity    int           `json:"capacity" yaml:"capacity"`
+	VoicePolicy VoicePolicy   `json:"voice_policy" yaml:"voice_policy"`
+	Description string        `json:"description" yaml:"description"`
+	Category    string        `json:"category" yaml:"category"`
+	SortOrder   int           `json:"sort_order" yaml:"sort_order"`
+	Retention   ChatRetention `json:"retention" yaml:"retention"`
+}
+
+// Normalize fills in defaults and validates the preset
+func (p *RoomPreset) Normalize() error {
+	p.Name = strings.TrimSpace(p.Name)
+	if p.Name == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidRoomPreset)
+	}
+
+	if p.Type == "" {
+		p.Type = RoomTypePublic
+	}
+	if p.Type != RoomTypePublic && p.Type != RoomTypePrivate {
+		return fmt.Errorf("%w: room %q has unknown type %q", ErrInvalidRoomPreset, p.Name, p.Type)
+	}
+
+	if p.Capacity < 0 {
+		return fmt.Errorf("%w: room %q has negative capacity", ErrInvalidRoomPreset, p.Name)
+	}
+	if p.Capacity == 0 {
+		p.Capacity = DefaultRoomCapacity
+	}
+
+	if p.VoicePolicy == "" {
+		p.VoicePolicy = VoicePolicyAny
+	}
+	if !p.VoicePolicy.IsValid() {
+		return fmt.Errorf("%w: room %q has unknown voice policy %q", ErrInvalidRoomPreset, p.Name, p.VoicePolicy)
+	}
+
+	if err := p.Retention.Normalize(); err != nil {
+		return fmt.Errorf("%w: room %q: %v", ErrInvalidRoomPreset, p.Name, err)
+	}
+
+	return nil
+}
+
+// Matches checks if the room already has the preset's settings
+func (p *RoomPreset) Matches(room *Room) bool {
+	room.mu.RLock()
+	defer room.mu.RUnlock()
+
+	return room.Persistent &&
+		!room.IsClosed &&
+		room.Type == p.Type &&
+		room.Capacity == p.Capacity &&
+		room.VoicePolicy == p.VoicePolicy &&
+		room.Description == p.Description &&
+		room.Category == p.Category &&
+		room.SortOrder == p.SortOrder &&
+		room.Retention == p.Retention
+}
@@ -168,3 +168,138 @@ func TestRoom_TimeSinceLastActivity(t *testing.T) {
 	room.LastActivity = time.Now().Add(-5 * time.Minute)
 	assert.GreaterOrEqual(t, room.TimeSinceLastActivity(), 5*time.Minute)
 }
+
+func TestRoom_AddListener(t *testing.T) {
+	room := NewRoomWithCapacity("room-1", "Test Room", RoomTypePublic, "user-1", 1)
+	participant := NewUser("user-1", "User1", "127.0.0.1", VoiceModePTT)
+	listener := NewUser("user-2", "Caster", "127.0.0.1", VoiceModePTT)
+
+	room.AddParticipant(participant)
+	err := room.AddListener(listener)
+
+	assert.NoError(t, err)
+	assert.True(t, listener.IsListener)
+	assert.True(t, room.IsListening("user-2"))
+	assert.Equal(t, 1, room.ParticipantCount()) // Listeners don't use capacity
+	assert.Empty(t, listener.RoomID)            // Primary room is untouched
+}
+
+func TestRoom_AddListener_AlreadyParticipant(t *testing.T) {
+	room := NewRoom("room-1", "Test Room", RoomTypePublic, "user-1")
+	user := NewUser("user-1", "User1", "127.0.0.1", VoiceModePTT)
+
+	room.AddParticipant(user)
+	err := room.AddListener(NewUser("user-1", "User1", "127.0.0.1", VoiceModePTT))
+
+	assert.ErrorIs(t, err, ErrUserAlreadyInRoom)
+}
+
+func TestRoom_RemoveListener(t *testing.T) {
+	room := NewRoom("room-1", "Test Room", RoomTypePublic, "user-1")
+	listener := NewUser("user-2", "Caster", "127.0.0.1", VoiceModePTT)
+	room.AddListener(listener)
+
+	removed, err := room.RemoveListener("user-2")
+
+	assert.NoError(t, err)
+	assert.Equal(t, listener, removed)
+	assert.False(t, room.IsListening("user-2"))
+
+	_, err = room.RemoveListener("user-2")
+	assert.ErrorIs(t, err, ErrUserNotListening)
+}
+
+func TestRoom_RemoveAllListeners(t *testing.T) {
+	room := NewRoom("room-1", "Test Room", RoomTypePublic, "user-1")
+	room.AddListener(NewUser("user-2", "Caster", "127.0.0.1", VoiceModePTT))
+	room.AddListener(NewUser("user-3", "Fan", "127.0.0.1", VoiceModePTT))
+
+	removed := room.RemoveAllListeners()
+
+	assert.Len(t, removed, 2)
+	assert.False(t, room.IsListening("user-2"))
+	assert.False(t, room.IsListening("user-3"))
+	assert.Empty(t, room.RemoveAllListeners())
+}
+
+func TestRoom_GetVisibleListeners_Stealth(t *testing.T) {
+	room := NewRoom("room-1", "Test Room", RoomTypePublic, "user-1")
+	listener := NewUser("user-2", "Caster", "127.0.0.1", VoiceModePTT)
+	stealth := NewUser("admin-1", "Admin", "127.0.0.1", VoiceModePTT)
+	stealth.IsAdmin = true
+	stealth.IsStealth = true
+	room.AddListener(listener)
+	room.AddListener(stealth)
+
+	viewer := NewUser("user-3", "Viewer", "127.0.0.1", VoiceModePTT)
+	adminViewer := NewUser("admin-2", "Admin2", "127.0.0.1", VoiceModePTT)
+	adminViewer.IsAdmin = true
+
+	assert.Len(t, room.GetVisibleListeners(viewer), 1)
+	assert.Len(t, room.GetVisibleListeners(adminViewer), 2)
+}
+
+func TestVoicePolicy_Apply(t *testing.T) {
+	assert.Equal(t, VoiceModeVAD, VoicePolicyAny.Apply(VoiceModeVAD))
+	assert.Equal(t, VoiceModePTT, VoicePolicyPTT.Apply(VoiceModeVAD))
+	assert.Equal(t, VoiceModeVAD, VoicePolicyVAD.Apply(VoiceModePTT))
+}
+
+func TestRoomPreset_Normalize(t *testing.T) {
+	preset := &RoomPreset{Name: "  Lobby "}
+
+	assert.NoError(t, preset.Normalize())
+	assert.Equal(t, "Lobby", preset.Name)
+	assert.Equal(t, RoomTypePublic, preset.Type)
+	assert.Equal(t, DefaultRoomCapacity, preset.Capacity)
+	assert.Equal(t, VoicePolicyAny, preset.VoicePolicy)
+
+	assert.ErrorIs(t, (&RoomPreset{}).Normalize(), ErrInvalidRoomPreset)
+	assert.ErrorIs(t, (&RoomPreset{Name: "A", Type: "secret"}).Normalize(), ErrInvalidRoomPreset)
+	assert.ErrorIs(t, (&RoomPreset{Name: "A", VoicePolicy: "loud"}).Normalize(), ErrInvalidRoomPreset)
+}
+
+func TestRoom_RetirePreset(t *testing.T) {
+	room := NewRoom("room-1", "Lobby", RoomTypePublic, PresetRoomCreator)
+	preset := &RoomPreset{Name: "Lobby"}
+	preset.Normalize()
+	room.ApplyPreset(preset)
+
+	assert.True(t, room.IsPersistent())
+	assert.True(t, preset.Matches(room))
+
+	room.RetirePreset()
+
+	assert.False(t, room.IsPersistent())
+	assert.True(t, room.IsClosed)
+	assert.False(t, preset.Matches(room))
+}
+
+func TestChatRetention_Normalize(t *testing.T) {
+	policy := ChatRetention{}
+	assert.NoError(t, policy.Normalize())
+	assert.Equal(t, RetentionEphemeral, policy.Mode)
+	maxMessages, ttl := policy.Limits()
+	assert.Zero(t, maxMessages)
+	assert.Zero(t, ttl)
+
+	policy = ChatRetention{Mode: " Days ", Days: 7, Messages: 50}
+	assert.NoError(t, policy.Normalize())
+	assert.Equal(t, ChatRetention{Mode: RetentionDays, Days: 7}, policy)
+	assert.Equal(t, 7*24*time.Hour, policy.MaxAge())
+
+	policy = ChatRetention{Mode: RetentionMessages, Messages: 200}
+	assert.NoError(t, policy.Normalize())
+	maxMessages, ttl = policy.Limits()
+	assert.Equal(t, 200, maxMessages)
+	assert.Equal(t, RetainedChatTTL, ttl)
+	assert.False(t, policy.Archives())
+
+	policy = ChatRetention{Mode: RetentionArchive, Days: 3}
+	assert.NoError(t, policy.Normalize())
+	assert.True(t, policy.Archives())
+	assert.Zero(t, policy.Days)
+
+	assert.ErrorIs(t, (&ChatRetention{Mode: RetentionMessages, Messages: MaxRetainedMessages + 1}).Normalize(), ErrInvalidRetention)
+	assert.ErrorIs(t, (&ChatRetention{Mode: "keep"}).Normalize(), ErrInvalidRetention)
+}
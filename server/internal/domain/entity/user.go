@@ -12,17 +12,28 @@ const (
 	VoiceModeVAD VoiceMode = "vad" // Voice activity detection
 )
 
+// UserRole represents a user's permission role
+type UserRole string
+
+const (
+	UserRoleUser  UserRole = "user"
+	UserRoleAdmin UserRole = "admin"
+)
+
 // User represents a connected user in the voice chat system
 type User struct {
-	ID        string
-	Name      string
-	RoomID    string
-	VoiceMode VoiceMode
-	IsMuted   bool
-	IsAdmin   bool
-	IsStealth bool // Admin-only: invisible in participant list
-	JoinedAt  time.Time
-	IP        string
+	ID         string
+	Name       string
+	RoomID     string
+	VoiceMode  VoiceMode
+	IsMuted    bool
+	IsAdmin    bool
+	IsStealth  bool   // Admin-only: invisible in participant list
+	IsListener bool   // Listen-only: subscribed to the room's audio without being a participant
+	IsAFK      bool   // Away from keyboard, set with /afk
+	AFKMessage string // Optional reason shown while away
+	JoinedAt   time.Time
+	IP         string
 }
 
 // NewUser creates a new user with the given parameters
@@ -64,6 +75,18 @@ func (u *User) ToggleMute() {
 	u.IsMuted = !u.IsMuted
 }
 
+// SetAFK marks the user as away with an optional message
+func (u *User) SetAFK(message string) {
+	u.IsAFK = true
+	u.AFKMessage = message
+}
+
+// ClearAFK marks the user as back
+func (u *User) ClearAFK() {
+	u.IsAFK = false
+	u.AFKMessage = ""
+}
+
 // SetVoiceMode changes the user's voice mode
 func (u *User) SetVoiceMode(mode VoiceMode) {
 	u.VoiceMode = mode
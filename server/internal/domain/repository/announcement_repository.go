@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"voice-chat/internal/domain/entity"
+)
+
+// AnnouncementRepository defines the interface for scheduled announcement and message-of-the-day persistence
+type AnnouncementRepository interface {
+	// SaveSchedule creates or replaces a scheduled announcement
+	SaveSchedule(schedule *entity.ScheduledAnnouncement) error
+
+	// GetSchedule retrieves a scheduled announcement by ID. Schedules are handed out as copies;
+	// changes take effect through SaveSchedule.
+	GetSchedule(id string) (*entity.ScheduledAnnouncement, error)
+
+	// GetSchedules retrieves all scheduled announcements
+	GetSchedules() ([]*entity.ScheduledAnnouncement, error)
+
+	// DeleteSchedule removes a scheduled announcement by ID
+	DeleteSchedule(id string) error
+
+	// GetMessageOfTheDay returns the current message of the day (empty if unset)
+	GetMessageOfTheDay() (string, error)
+
+	// SetMessageOfTheDay replaces the message of the day (empty clears it)
+	SetMessageOfTheDay(motd string) error
+}
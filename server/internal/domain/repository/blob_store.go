@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"io"
+	"time"
+)
+
+// BlobStore defines the interface for storing uploaded files.
+// Keys are slash-separated paths such as "rooms/<roomID>/<attachmentID>".
+type BlobStore interface {
+	// Put stores data under key, replacing any existing blob
+	Put(key string, data []byte) error
+
+	// Open returns a blob's contents and when it was stored
+	Open(key string) (io.ReadSeekCloser, time.Time, error)
+
+	// Delete removes a blob; deleting a missing blob is not an error
+	Delete(key string) error
+
+	// DeletePrefix removes every blob whose key starts with prefix
+	DeletePrefix(prefix string) error
+}
@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"voice-chat/internal/domain/entity"
+)
+
+// ChatArchiveRepository defines the interface for storing transcripts of closed rooms
+type ChatArchiveRepository interface {
+	// Save compresses and stores a room's messages, filling in the archive's size
+	Save(archive *entity.ChatArchive, messages []*entity.ChatMessage) error
+
+	// List retrieves every archive's metadata (newest first)
+	List() ([]*entity.ChatArchive, error)
+
+	// Get retrieves an archive and its messages (chronological order)
+	Get(id string) (*entity.ChatArchive, []*entity.ChatMessage, error)
+}
@@ -1,25 +1,89 @@
 package repository
 
 import (
+	"time"
+
 	"voice-chat/internal/domain/entity"
 )
 
-const DefaultChatHistoryLimit = 100
+const (
+	DefaultChatHistoryLimit = 100 // Messages sent when joining a room
+	MaxChatHistoryPageSize  = 100 // Largest page a client may request
+	DefaultChatRetention    = 100 // Messages kept per room
+)
+
+// ChatHistoryCursor selects messages older than a message ID or a timestamp.
+// The zero cursor selects the newest messages.
+type ChatHistoryCursor struct {
+	BeforeID string
+	Before   time.Time
+}
+
+// ParseChatHistoryCursor interprets a client cursor as an RFC 3339 timestamp, or otherwise a message ID
+func ParseChatHistoryCursor(before string) ChatHistoryCursor {
+	if before == "" {
+		return ChatHistoryCursor{}
+	}
+	if t, err := time.Parse(time.RFC3339Nano, before); err == nil {
+		return ChatHistoryCursor{Before: t}
+	}
+	return ChatHistoryCursor{BeforeID: before}
+}
 
 // ChatRepository defines the interface for chat message persistence
 type ChatRepository interface {
-	// AddMessage stores a new chat message for a room
+	// AddMessage stores a new chat message for a room; replies are indexed under their parent,
+	// whose ReplyCount is updated
 	AddMessage(message *entity.ChatMessage) error
 
 	// GetMessages retrieves chat messages for a room (most recent first, up to limit)
 	GetMessages(roomID string, limit int) ([]*entity.ChatMessage, error)
 
-	// GetMessage retrieves a specific message by ID
+	// GetMessagesBefore retrieves up to limit messages older than the cursor (chronological order)
+	// and reports whether even older messages exist
+	GetMessagesBefore(roomID string, cursor ChatHistoryCursor, limit int) ([]*entity.ChatMessage, bool, error)
+
+	// CountMessagesAfter counts messages newer than messageID; an empty or expired ID counts every message
+	CountMessagesAfter(roomID, messageID string) (int, error)
+
+	// GetReplies retrieves up to limit of the newest replies to a message (chronological order)
+	GetReplies(roomID, parentID string, limit int) ([]*entity.ChatMessage, error)
+
+	// GetMessage retrieves a copy of a specific message by ID
 	GetMessage(roomID, messageID string) (*entity.ChatMessage, error)
 
-	// UpdateMessage updates a message (for reactions)
+	// UpdateMessage replaces a stored message; deleted messages are never written over
+	// (entity.ErrMessageDeleted)
 	UpdateMessage(message *entity.ChatMessage) error
 
-	// DeleteRoomMessages deletes all messages for a room (when room is destroyed)
+	// EditMessage atomically replaces the content of a message userID sent no longer than window
+	// ago, keeping the old content as a revision, and returns a copy of the edited message
+	EditMessage(roomID, messageID, userID, content string, window time.Duration, now time.Time) (*entity.ChatMessage, error)
+
+	// DeleteMessage atomically turns a message into a tombstone if userID wrote it or moderator is
+	// set, returning a copy of the message as it was before
+	DeleteMessage(roomID, messageID, userID string, moderator bool, now time.Time) (*entity.ChatMessage, error)
+
+	// AddReaction atomically adds a user's reaction unless the message already has maxEmojis
+	// different reactions (0 = no cap), returning everyone who reacted with that emoji
+	AddReaction(roomID, messageID, emoji, userID string, maxEmojis int) ([]string, error)
+
+	// RemoveReaction atomically removes a user's reaction, returning everyone still reacting with that emoji
+	RemoveReaction(roomID, messageID, emoji, userID string) ([]string, error)
+
+	// VotePoll atomically replaces a user's choices on a poll message and returns a copy of the poll
+	VotePoll(roomID, messageID, userID string, choices []int, now time.Time) (*entity.Poll, error)
+
+	// ClosePoll atomically closes a poll message, returning a copy of the poll and whether it was open
+	ClosePoll(roomID, messageID string, now time.Time) (*entity.Poll, bool, error)
+
+	// SetRoomLimits overrides how many messages a room keeps and how long they live in stores
+	// that expire data, trimming the room right away; zero values restore the defaults
+	SetRoomLimits(roomID string, maxMessages int, ttl time.Duration) error
+
+	// PruneMessagesBefore deletes a room's messages sent before the cutoff and reports how many were removed
+	PruneMessagesBefore(roomID string, before time.Time) (int, error)
+
+	// DeleteRoomMessages deletes all messages for a room (when room is destroyed) and its limits
 	DeleteRoomMessages(roomID string) error
 }
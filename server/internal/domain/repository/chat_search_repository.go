@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"strings"
+	"time"
+	"unicode"
+
+	"voice-chat/internal/domain/entity"
+)
+
+const (
+	DefaultChatSearchLimit = 50  // Results returned when no limit is given
+	MaxChatSearchLimit     = 100 // Largest result page a client may request
+	MinSearchTermLength    = 2   // Shorter words are not indexed
+)
+
+// ChatSearchQuery selects indexed messages. Every term in Text must match; empty fields do not filter.
+type ChatSearchQuery struct {
+	Text       string
+	RoomID     string
+	RoomName   string // Case-insensitive
+	SenderID   string
+	SenderName string // Case-insensitive
+	Since      time.Time
+	Until      time.Time
+	Limit      int
+}
+
+// ChatSearchResult is a matching message with the name its room had when it was sent
+type ChatSearchResult struct {
+	Message  *entity.ChatMessage
+	RoomName string
+}
+
+// ChatSearchRepository defines the interface for the chat full-text index.
+// The index keeps its own copy of messages so searches reach past the chat store's default cap;
+// retention policies drop the messages their room no longer keeps.
+type ChatSearchRepository interface {
+	// Index adds or replaces a message in the index; an empty roomName keeps the stored one
+	Index(message *entity.ChatMessage, roomName string) error
+
+	// Remove drops a message from the index
+	Remove(roomID, messageID string) error
+
+	// Search retrieves matching messages (newest first, up to query.Limit)
+	Search(query ChatSearchQuery) ([]*ChatSearchResult, error)
+
+	// Prune drops messages sent before the cutoff and returns how many were removed
+	Prune(before time.Time) (int, error)
+
+	// PruneRoom drops a room's messages sent before the cutoff and returns how many were removed
+	PruneRoom(roomID string, before time.Time) (int, error)
+
+	// RemoveRoom drops every message of a room
+	RemoveRoom(roomID string) error
+}
+
+// SearchTerms splits text into the lowercase, de-duplicated words used by the index
+func SearchTerms(text string) []string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	seen := make(map[string]bool, len(words))
+	terms := make([]string, 0, len(words))
+	for _, w := range words {
+		if len([]rune(w)) < MinSearchTermLength || seen[w] {
+			continue
+		}
+		seen[w] = true
+		terms = append(terms, w)
+	}
+	return terms
+}
+
+// Matches checks a result against the query's non-text filters
+func (q ChatSearchQuery) Matches(result *ChatSearchResult) bool {
+	msg := result.Message
+	switch {
+	case q.RoomID != "" && msg.RoomID != q.RoomID:
+		return false
+	case q.RoomName != "" && !strings.EqualFold(result.RoomName, q.RoomName):
+		return false
+	case q.SenderID != "" && msg.SenderID != q.SenderID:
+		return false
+	case q.SenderName != "" && !strings.EqualFold(msg.SenderName, q.SenderName):
+		return false
+	case !q.Since.IsZero() && msg.Timestamp.Before(q.Since):
+		return false
+	case !q.Until.IsZero() && msg.Timestamp.After(q.Until):
+		return false
+	}
+	return true
+}
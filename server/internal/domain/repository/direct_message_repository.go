@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"voice-chat/internal/domain/entity"
+)
+
+const (
+	DefaultDMHistoryLimit = 50  // Messages returned when opening a conversation
+	DefaultDMRetention    = 500 // Messages kept per conversation
+)
+
+// DirectMessageRepository defines the interface for direct message persistence
+type DirectMessageRepository interface {
+	// AddMessage stores a new direct message
+	AddMessage(message *entity.DirectMessage) error
+
+	// GetConversation retrieves up to limit of the newest messages between two users
+	// (chronological order), optionally only those older than beforeID
+	GetConversation(userA, userB, beforeID string, limit int) ([]*entity.DirectMessage, error)
+
+	// GetMessage retrieves a specific message by ID
+	GetMessage(messageID string) (*entity.DirectMessage, error)
+}
+
+// BlockRepository defines the interface for per-user block lists
+type BlockRepository interface {
+	// Block stops blockedID from messaging userID
+	Block(userID, blockedID string) error
+
+	// Unblock removes blockedID from userID's block list
+	Unblock(userID, blockedID string) error
+
+	// IsBlocked checks if userID has blocked blockedID
+	IsBlocked(userID, blockedID string) bool
+
+	// GetBlocked retrieves the users userID has blocked
+	GetBlocked(userID string) ([]string, error)
+}
+
+// DMReportRepository defines the interface for the reported direct message audit trail
+type DMReportRepository interface {
+	// Create stores a new report
+	Create(report *entity.DMReport) error
+
+	// GetRecent retrieves the most recent N reports (newest first)
+	GetRecent(limit int) ([]*entity.DMReport, error)
+}
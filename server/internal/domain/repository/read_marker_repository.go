@@ -0,0 +1,20 @@
+package repository
+
+// ReadMarkerRepository stores the last message each session has read in each room.
+// Sessions outlive a single WebSocket connection so markers survive reconnects.
+type ReadMarkerRepository interface {
+	// SetLastRead records the last message a session has read in a room
+	SetLastRead(sessionID, roomID, messageID string) error
+
+	// GetLastRead returns the last message a session has read in a room ("" if none)
+	GetLastRead(sessionID, roomID string) (string, error)
+
+	// HasSession reports whether a session is known
+	HasSession(sessionID string) bool
+
+	// Touch creates a session or marks it as recently used
+	Touch(sessionID string) error
+
+	// DeleteRoom removes every session's marker for a room
+	DeleteRoom(roomID string) error
+}
@@ -64,6 +64,26 @@ func (s *TokenService) GenerateAdminToken(roomName, adminID string) (string, err
 	return at.ToJWT()
 }
 
+// GenerateAnnouncerToken creates a visible publish token for a café-wide voice announcement
+func (s *TokenService) GenerateAnnouncerToken(roomName, identity string) (string, error) {
+	at := auth.NewAccessToken(s.apiKey, s.apiSecret)
+
+	grant := &auth.VideoGrant{
+		RoomJoin:       true,
+		Room:           roomName,
+		CanPublish:     boolPtr(true),
+		CanSubscribe:   boolPtr(false),
+		CanPublishData: boolPtr(false),
+	}
+
+	at.AddGrant(grant).
+		SetIdentity(identity).
+		SetName("Announcement").
+		SetValidFor(s.ttl)
+
+	return at.ToJWT()
+}
+
 // GenerateStealthToken creates a token for stealth listening (receive only)
 func (s *TokenService) GenerateStealthToken(roomName, adminID string) (string, error) {
 	at := auth.NewAccessToken(s.apiKey, s.apiSecret)
@@ -86,6 +106,28 @@ func (s *TokenService) GenerateStealthToken(roomName, adminID string) (string, e
 	return at.ToJWT()
 }
 
+// GenerateListenerToken creates a subscribe-only token for listening to an additional room
+func (s *TokenService) GenerateListenerToken(roomName, userID, userName string, hidden bool) (string, error) {
+	at := auth.NewAccessToken(s.apiKey, s.apiSecret)
+
+	canPublish := false
+	grant := &auth.VideoGrant{
+		RoomJoin:       true,
+		Room:           roomName,
+		CanPublish:     &canPublish,
+		CanSubscribe:   boolPtr(true),
+		CanPublishData: boolPtr(false),
+		Hidden:         hidden,
+	}
+
+	at.AddGrant(grant).
+		SetIdentity(userID).
+		SetName(userName).
+		SetValidFor(s.ttl)
+
+	return at.ToJWT()
+}
+
 // SetTTL sets the token time-to-live duration
 func (s *TokenService) SetTTL(ttl time.Duration) {
 	s.ttl = ttl
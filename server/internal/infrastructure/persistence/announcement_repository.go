@@ -0,0 +1,161 @@
+package persistence
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"voice-chat/internal/domain/entity"
+)
+
+var ErrScheduleNotFound = errors.New("scheduled announcement not found")
+
+// announcementFile is the on-disk layout of the announcement store
+type announcementFile struct {
+	MessageOfTheDay string                          `json:"motd"`
+	Schedules       []*entity.ScheduledAnnouncement `json:"schedules"`
+}
+
+// FileAnnouncementRepository is a JSON-file backed implementation of AnnouncementRepository
+type FileAnnouncementRepository struct {
+	path      string
+	motd      string
+	schedules map[string]*entity.ScheduledAnnouncement // id -> schedule
+	mu        sync.RWMutex
+}
+
+// NewFileAnnouncementRepository creates a FileAnnouncementRepository, loading existing data from path
+func NewFileAnnouncementRepository(path string) (*FileAnnouncementRepository, error) {
+	r := &FileAnnouncementRepository{
+		path:      path,
+		schedules: make(map[string]*entity.ScheduledAnnouncement),
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return r, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read announcements file: %w", err)
+	}
+
+	var file announcementFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse announcements file: %w", err)
+	}
+
+	r.motd = file.MessageOfTheDay
+	for _, s := range file.Schedules {
+		r.schedules[s.ID] = s
+	}
+
+	return r, nil
+}
+
+// SaveSchedule creates or replaces a scheduled announcement. The repository keeps its own copy,
+// so changes to a schedule only take effect once it is saved again.
+func (r *FileAnnouncementRepository) SaveSchedule(schedule *entity.ScheduledAnnouncement) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.schedules[schedule.ID] = schedule.Clone()
+	return r.persist()
+}
+
+// GetSchedule retrieves a scheduled announcement by ID
+func (r *FileAnnouncementRepository) GetSchedule(id string) (*entity.ScheduledAnnouncement, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	schedule, exists := r.schedules[id]
+	if !exists {
+		return nil, ErrScheduleNotFound
+	}
+	return schedule.Clone(), nil
+}
+
+// GetSchedules retrieves all scheduled announcements (oldest first)
+func (r *FileAnnouncementRepository) GetSchedules() ([]*entity.ScheduledAnnouncement, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	schedules := r.sortedSchedules()
+	for i, s := range schedules {
+		schedules[i] = s.Clone()
+	}
+	return schedules, nil
+}
+
+// DeleteSchedule removes a scheduled announcement by ID
+func (r *FileAnnouncementRepository) DeleteSchedule(id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.schedules[id]; !exists {
+		return ErrScheduleNotFound
+	}
+	delete(r.schedules, id)
+	return r.persist()
+}
+
+// GetMessageOfTheDay returns the current message of the day
+func (r *FileAnnouncementRepository) GetMessageOfTheDay() (string, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.motd, nil
+}
+
+// SetMessageOfTheDay replaces the message of the day
+func (r *FileAnnouncementRepository) SetMessageOfTheDay(motd string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.motd = motd
+	return r.persist()
+}
+
+// sortedSchedules returns schedules ordered by creation time (caller must hold the lock)
+func (r *FileAnnouncementRepository) sortedSchedules() []*entity.ScheduledAnnouncement {
+	schedules := make([]*entity.ScheduledAnnouncement, 0, len(r.schedules))
+	for _, s := range r.schedules {
+		schedules = append(schedules, s)
+	}
+	sort.Slice(schedules, func(i, j int) bool {
+		return schedules[i].CreatedAt.Before(schedules[j].CreatedAt)
+	})
+	return schedules
+}
+
+// persist writes the store to disk atomically (caller must hold the write lock)
+func (r *FileAnnouncementRepository) persist() error {
+	data, err := json.MarshalIndent(announcementFile{
+		MessageOfTheDay: r.motd,
+		Schedules:       r.sortedSchedules(),
+	}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal announcements: %w", err)
+	}
+
+	return writeFileAtomic(r.path, data)
+}
+
+// writeFileAtomic writes data to a temp file and renames it over path
+func writeFileAtomic(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", tmp, err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("failed to replace %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,155 @@
+package persistence
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"voice-chat/internal/domain/entity"
+)
+
+var ErrArchiveNotFound = errors.New("chat archive not found")
+
+// FileChatArchiveRepository keeps each archive as a gzipped JSON Lines file under a directory,
+// with an index file listing them
+type FileChatArchiveRepository struct {
+	dir      string
+	archives map[string]*entity.ChatArchive // id -> archive
+	mu       sync.RWMutex
+}
+
+// NewFileChatArchiveRepository creates a FileChatArchiveRepository, loading the index from dir
+func NewFileChatArchiveRepository(dir string) (*FileChatArchiveRepository, error) {
+	r := &FileChatArchiveRepository{
+		dir:      dir,
+		archives: make(map[string]*entity.ChatArchive),
+	}
+
+	data, err := os.ReadFile(r.indexPath())
+	if errors.Is(err, os.ErrNotExist) {
+		return r, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read chat archive index: %w", err)
+	}
+
+	var archives []*entity.ChatArchive
+	if err := json.Unmarshal(data, &archives); err != nil {
+		return nil, fmt.Errorf("failed to parse chat archive index: %w", err)
+	}
+	for _, a := range archives {
+		r.archives[a.ID] = a
+	}
+
+	return r, nil
+}
+
+func (r *FileChatArchiveRepository) indexPath() string {
+	return filepath.Join(r.dir, "index.json")
+}
+
+// transcriptPath returns where an archive's messages are stored; IDs are generated by the
+// server, but the base name keeps a bad one inside the directory
+func (r *FileChatArchiveRepository) transcriptPath(id string) string {
+	return filepath.Join(r.dir, filepath.Base(id)+".jsonl.gz")
+}
+
+// Save compresses and stores a room's messages, one JSON object per line
+func (r *FileChatArchiveRepository) Save(archive *entity.ChatArchive, messages []*entity.ChatMessage) error {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	enc := json.NewEncoder(gz)
+	for _, msg := range messages {
+		if err := enc.Encode(msg); err != nil {
+			return fmt.Errorf("failed to encode archived message: %w", err)
+		}
+	}
+	if err := gz.Close(); err != nil {
+		return fmt.Errorf("failed to compress chat archive: %w", err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := writeFileAtomic(r.transcriptPath(archive.ID), buf.Bytes()); err != nil {
+		return err
+	}
+
+	archive.Size = int64(buf.Len())
+	r.archives[archive.ID] = archive
+	return r.persist()
+}
+
+// List retrieves every archive's metadata (newest first)
+func (r *FileChatArchiveRepository) List() ([]*entity.ChatArchive, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.sortedArchives(), nil
+}
+
+// Get retrieves an archive and its messages
+func (r *FileChatArchiveRepository) Get(id string) (*entity.ChatArchive, []*entity.ChatMessage, error) {
+	r.mu.RLock()
+	archive, exists := r.archives[id]
+	r.mu.RUnlock()
+	if !exists {
+		return nil, nil, ErrArchiveNotFound
+	}
+
+	f, err := os.Open(r.transcriptPath(id))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil, ErrArchiveNotFound
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open chat archive: %w", err)
+	}
+	defer f.Close()
+
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read chat archive: %w", err)
+	}
+	defer gz.Close()
+
+	var messages []*entity.ChatMessage
+	dec := json.NewDecoder(bufio.NewReader(gz))
+	for dec.More() {
+		var msg entity.ChatMessage
+		if err := dec.Decode(&msg); err != nil {
+			return nil, nil, fmt.Errorf("failed to decode chat archive: %w", err)
+		}
+		messages = append(messages, &msg)
+	}
+
+	return archive, messages, nil
+}
+
+// sortedArchives returns archives newest first (caller must hold the lock)
+func (r *FileChatArchiveRepository) sortedArchives() []*entity.ChatArchive {
+	archives := make([]*entity.ChatArchive, 0, len(r.archives))
+	for _, a := range r.archives {
+		archives = append(archives, a)
+	}
+	sort.Slice(archives, func(i, j int) bool {
+		return archives[i].ArchivedAt.After(archives[j].ArchivedAt)
+	})
+	return archives
+}
+
+// persist writes the index to disk atomically (caller must hold the write lock)
+func (r *FileChatArchiveRepository) persist() error {
+	data, err := json.MarshalIndent(r.sortedArchives(), "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal chat archive index: %w", err)
+	}
+
+	return writeFileAtomic(r.indexPath(), data)
+}
@@ -2,7 +2,9 @@ package persistence
 
 import (
 	"errors"
+	"sort"
 	"sync"
+	"time"
 
 	"voice-chat/internal/domain/entity"
 	"voice-chat/internal/domain/repository"
@@ -10,14 +12,19 @@ import (
 
 var ErrMessageNotFound = errors.New("message not found")
 
-// InMemoryChatRepository is an in-memory implementation of ChatRepository
+// InMemoryChatRepository is an in-memory implementation of ChatRepository. Messages are copied
+// on the way in and out, so stored ones only ever change under the lock.
 type InMemoryChatRepository struct {
 	// roomID -> []messages (ordered by timestamp, newest last)
 	messages map[string][]*entity.ChatMessage
 	// roomID -> messageID -> message (for quick lookup)
 	messageIndex map[string]map[string]*entity.ChatMessage
-	mu           sync.RWMutex
-	maxMessages  int
+	// roomID -> parentID -> replies (ordered by timestamp, newest last)
+	replies map[string]map[string][]*entity.ChatMessage
+	// roomID -> messages kept, for rooms with their own retention
+	limits      map[string]int
+	mu          sync.RWMutex
+	maxMessages int
 }
 
 // NewInMemoryChatRepository creates a new InMemoryChatRepository
@@ -25,7 +32,9 @@ func NewInMemoryChatRepository() *InMemoryChatRepository {
 	return &InMemoryChatRepository{
 		messages:     make(map[string][]*entity.ChatMessage),
 		messageIndex: make(map[string]map[string]*entity.ChatMessage),
-		maxMessages:  repository.DefaultChatHistoryLimit,
+		replies:      make(map[string]map[string][]*entity.ChatMessage),
+		limits:       make(map[string]int),
+		maxMessages:  repository.DefaultChatRetention,
 	}
 }
 
@@ -35,28 +44,58 @@ func (r *InMemoryChatRepository) AddMessage(message *entity.ChatMessage) error {
 	defer r.mu.Unlock()
 
 	roomID := message.RoomID
+	message = message.Clone()
 
 	// Initialize room message slice if needed
 	if r.messages[roomID] == nil {
 		r.messages[roomID] = make([]*entity.ChatMessage, 0)
 		r.messageIndex[roomID] = make(map[string]*entity.ChatMessage)
+		r.replies[roomID] = make(map[string][]*entity.ChatMessage)
 	}
 
 	// Add message
 	r.messages[roomID] = append(r.messages[roomID], message)
 	r.messageIndex[roomID][message.ID] = message
 
-	// Trim if over max
-	if len(r.messages[roomID]) > r.maxMessages {
-		// Remove oldest message
-		oldest := r.messages[roomID][0]
-		delete(r.messageIndex[roomID], oldest.ID)
-		r.messages[roomID] = r.messages[roomID][1:]
+	// Index reply under its parent
+	if message.IsReply() {
+		replies := append(r.replies[roomID][message.ReplyTo], message)
+		r.replies[roomID][message.ReplyTo] = replies
+		if parent, exists := r.messageIndex[roomID][message.ReplyTo]; exists {
+			parent.ReplyCount = len(replies)
+		}
 	}
 
+	r.trim(roomID)
+
 	return nil
 }
 
+// trim removes the oldest messages of a room over its limit; the caller must hold the lock
+func (r *InMemoryChatRepository) trim(roomID string) {
+	maxMessages := r.maxMessages
+	if limit, exists := r.limits[roomID]; exists {
+		maxMessages = limit
+	}
+	for len(r.messages[roomID]) > maxMessages {
+		r.removeOldest(roomID)
+	}
+}
+
+// removeOldest removes a room's oldest message; the caller must hold the lock
+func (r *InMemoryChatRepository) removeOldest(roomID string) {
+	oldest := r.messages[roomID][0]
+	delete(r.messageIndex[roomID], oldest.ID)
+	delete(r.replies[roomID], oldest.ID)
+	if oldest.IsReply() {
+		// The oldest message is also the oldest reply in its thread
+		if replies := r.replies[roomID][oldest.ReplyTo]; len(replies) > 0 {
+			r.replies[roomID][oldest.ReplyTo] = replies[1:]
+		}
+	}
+	r.messages[roomID] = r.messages[roomID][1:]
+}
+
 // GetMessages retrieves chat messages for a room (chronological order)
 func (r *InMemoryChatRepository) GetMessages(roomID string, limit int) ([]*entity.ChatMessage, error) {
 	r.mu.RLock()
@@ -73,11 +112,78 @@ func (r *InMemoryChatRepository) GetMessages(roomID string, limit int) ([]*entit
 	}
 
 	// Return the last 'limit' messages
-	start := len(messages) - limit
-	result := make([]*entity.ChatMessage, limit)
-	copy(result, messages[start:])
+	return cloneMessages(messages[len(messages)-limit:]), nil
+}
+
+// GetMessagesBefore retrieves up to limit messages older than the cursor (chronological order)
+func (r *InMemoryChatRepository) GetMessagesBefore(roomID string, cursor repository.ChatHistoryCursor, limit int) ([]*entity.ChatMessage, bool, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
 
-	return result, nil
+	messages := r.messages[roomID]
+
+	// end is the index of the first message not included in the page
+	end := len(messages)
+	switch {
+	case cursor.BeforeID != "":
+		if _, exists := r.messageIndex[roomID][cursor.BeforeID]; !exists {
+			return nil, false, ErrMessageNotFound
+		}
+		for end > 0 && messages[end-1].ID != cursor.BeforeID {
+			end--
+		}
+		end--
+	case !cursor.Before.IsZero():
+		end = sort.Search(len(messages), func(i int) bool {
+			return !messages[i].Timestamp.Before(cursor.Before)
+		})
+	}
+
+	if limit <= 0 || limit > end {
+		limit = end
+	}
+
+	start := end - limit
+	return cloneMessages(messages[start:end]), start > 0, nil
+}
+
+// CountMessagesAfter counts messages newer than messageID; an empty or expired ID counts every message
+func (r *InMemoryChatRepository) CountMessagesAfter(roomID, messageID string) (int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	messages := r.messages[roomID]
+	if _, exists := r.messageIndex[roomID][messageID]; !exists {
+		return len(messages), nil
+	}
+
+	count := 0
+	for i := len(messages) - 1; i >= 0 && messages[i].ID != messageID; i-- {
+		count++
+	}
+	return count, nil
+}
+
+// GetReplies retrieves up to limit of the newest replies to a message (chronological order)
+func (r *InMemoryChatRepository) GetReplies(roomID, parentID string, limit int) ([]*entity.ChatMessage, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	replies := r.replies[roomID][parentID]
+	if limit <= 0 || limit > len(replies) {
+		limit = len(replies)
+	}
+
+	return cloneMessages(replies[len(replies)-limit:]), nil
+}
+
+// cloneMessages copies stored messages for a caller; the caller must hold the lock
+func cloneMessages(messages []*entity.ChatMessage) []*entity.ChatMessage {
+	result := make([]*entity.ChatMessage, len(messages))
+	for i, msg := range messages {
+		result[i] = msg.Clone()
+	}
+	return result
 }
 
 // GetMessage retrieves a specific message by ID
@@ -95,29 +201,169 @@ func (r *InMemoryChatRepository) GetMessage(roomID, messageID string) (*entity.C
 		return nil, ErrMessageNotFound
 	}
 
-	return message, nil
+	return message.Clone(), nil
 }
 
-// UpdateMessage updates a message (for reactions)
+// UpdateMessage replaces a stored message unless it has been deleted
 func (r *InMemoryChatRepository) UpdateMessage(message *entity.ChatMessage) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	roomIndex := r.messageIndex[message.RoomID]
-	if roomIndex == nil {
+	stored, exists := r.messageIndex[message.RoomID][message.ID]
+	if !exists {
 		return ErrMessageNotFound
 	}
+	if stored.IsDeleted() {
+		return entity.ErrMessageDeleted
+	}
 
-	if _, exists := roomIndex[message.ID]; !exists {
-		return ErrMessageNotFound
+	// Copied into the stored message, which the room's list and threads point to as well
+	*stored = *message.Clone()
+
+	return nil
+}
+
+// EditMessage edits a message under the repository lock
+func (r *InMemoryChatRepository) EditMessage(roomID, messageID, userID, content string, window time.Duration, now time.Time) (*entity.ChatMessage, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	message, exists := r.messageIndex[roomID][messageID]
+	if !exists {
+		return nil, ErrMessageNotFound
+	}
+	if err := message.CheckEdit(userID, window, now); err != nil {
+		return nil, err
+	}
+
+	message.Edit(content, now)
+	return message.Clone(), nil
+}
+
+// DeleteMessage turns a message into a tombstone under the repository lock
+func (r *InMemoryChatRepository) DeleteMessage(roomID, messageID, userID string, moderator bool, now time.Time) (*entity.ChatMessage, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	message, exists := r.messageIndex[roomID][messageID]
+	if !exists {
+		return nil, ErrMessageNotFound
 	}
+	if err := message.CheckDelete(userID, moderator); err != nil {
+		return nil, err
+	}
+
+	original := message.Clone()
+	message.Delete(userID, now)
+	return original, nil
+}
 
-	// Update in index (the slice reference is the same object)
-	roomIndex[message.ID] = message
+// AddReaction adds a user's reaction under the repository lock
+func (r *InMemoryChatRepository) AddReaction(roomID, messageID, emoji, userID string, maxEmojis int) ([]string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	message, exists := r.messageIndex[roomID][messageID]
+	if !exists {
+		return nil, ErrMessageNotFound
+	}
+	if err := message.React(emoji, userID, maxEmojis); err != nil {
+		return nil, err
+	}
+	return message.Reactions[emoji], nil
+}
+
+// RemoveReaction removes a user's reaction under the repository lock
+func (r *InMemoryChatRepository) RemoveReaction(roomID, messageID, emoji, userID string) ([]string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	message, exists := r.messageIndex[roomID][messageID]
+	if !exists {
+		return nil, ErrMessageNotFound
+	}
+	message.Unreact(emoji, userID)
+	return message.Reactions[emoji], nil
+}
+
+// VotePoll records a user's choices on a poll under the repository lock
+func (r *InMemoryChatRepository) VotePoll(roomID, messageID, userID string, choices []int, now time.Time) (*entity.Poll, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	message, err := r.pollMessage(roomID, messageID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Copy on write: messages handed out earlier may still be reading the old votes
+	poll := message.Poll.Clone()
+	if err := poll.Vote(userID, choices, now); err != nil {
+		return nil, err
+	}
+	message.Poll = poll
+	return poll.Clone(), nil
+}
+
+// ClosePoll closes a poll under the repository lock
+func (r *InMemoryChatRepository) ClosePoll(roomID, messageID string, now time.Time) (*entity.Poll, bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	message, err := r.pollMessage(roomID, messageID)
+	if err != nil {
+		return nil, false, err
+	}
+
+	poll := message.Poll.Clone()
+	if !poll.Close(now) {
+		return poll, false, nil
+	}
+	message.Poll = poll
+	return poll.Clone(), true, nil
+}
+
+// pollMessage finds a poll message; the caller must hold the lock
+func (r *InMemoryChatRepository) pollMessage(roomID, messageID string) (*entity.ChatMessage, error) {
+	message, exists := r.messageIndex[roomID][messageID]
+	if !exists {
+		return nil, ErrMessageNotFound
+	}
+	if message.Poll == nil || message.IsDeleted() {
+		return nil, entity.ErrNotAPoll
+	}
+	return message, nil
+}
+
+// SetRoomLimits overrides how many messages a room keeps; messages never expire in memory, so
+// the TTL is ignored
+func (r *InMemoryChatRepository) SetRoomLimits(roomID string, maxMessages int, ttl time.Duration) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if maxMessages <= 0 {
+		delete(r.limits, roomID)
+	} else {
+		r.limits[roomID] = maxMessages
+	}
+	r.trim(roomID)
 
 	return nil
 }
 
+// PruneMessagesBefore deletes a room's messages sent before the cutoff
+func (r *InMemoryChatRepository) PruneMessagesBefore(roomID string, before time.Time) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	count := 0
+	for len(r.messages[roomID]) > 0 && r.messages[roomID][0].Timestamp.Before(before) {
+		r.removeOldest(roomID)
+		count++
+	}
+	return count, nil
+}
+
 // DeleteRoomMessages deletes all messages for a room
 func (r *InMemoryChatRepository) DeleteRoomMessages(roomID string) error {
 	r.mu.Lock()
@@ -125,6 +371,8 @@ func (r *InMemoryChatRepository) DeleteRoomMessages(roomID string) error {
 
 	delete(r.messages, roomID)
 	delete(r.messageIndex, roomID)
+	delete(r.replies, roomID)
+	delete(r.limits, roomID)
 
 	return nil
 }
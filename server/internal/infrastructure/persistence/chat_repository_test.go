@@ -0,0 +1,150 @@
+package persistence
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"voice-chat/internal/domain/entity"
+	"voice-chat/internal/domain/repository"
+)
+
+// addTestMessages stores msg-1, msg-2, ... in a room, sent at the given times
+func addTestMessages(t *testing.T, repo repository.ChatRepository, roomID string, timestamps ...time.Time) {
+	for i, ts := range timestamps {
+		msg := entity.NewChatMessage(fmt.Sprintf("msg-%d", i+1), roomID, "user-1", "Alice", fmt.Sprintf("message %d", i+1))
+		msg.Timestamp = ts
+		assert.NoError(t, repo.AddMessage(msg))
+	}
+}
+
+// idsOf returns the IDs of messages in order
+func idsOf(messages []*entity.ChatMessage) []string {
+	ids := make([]string, len(messages))
+	for i, msg := range messages {
+		ids[i] = msg.ID
+	}
+	return ids
+}
+
+// assertGetMessagesBefore checks paging through a room whose middle messages share a timestamp
+func assertGetMessagesBefore(t *testing.T, repo repository.ChatRepository) {
+	t0 := time.Date(2026, 6, 1, 18, 0, 0, 0, time.UTC)
+	t1, t2 := t0.Add(time.Second), t0.Add(2*time.Second)
+	addTestMessages(t, repo, "room-1", t0, t1, t1, t1, t2)
+
+	page := func(cursor repository.ChatHistoryCursor, limit int) ([]string, bool) {
+		messages, hasMore, err := repo.GetMessagesBefore("room-1", cursor, limit)
+		assert.NoError(t, err)
+		return idsOf(messages), hasMore
+	}
+
+	ids, hasMore := page(repository.ChatHistoryCursor{}, 2)
+	assert.Equal(t, []string{"msg-4", "msg-5"}, ids)
+	assert.True(t, hasMore)
+
+	// Messages tied with the cursor on timestamp are neither skipped nor repeated
+	ids, hasMore = page(repository.ChatHistoryCursor{BeforeID: "msg-4"}, 2)
+	assert.Equal(t, []string{"msg-2", "msg-3"}, ids)
+	assert.True(t, hasMore)
+
+	ids, hasMore = page(repository.ChatHistoryCursor{BeforeID: "msg-3"}, 5)
+	assert.Equal(t, []string{"msg-1", "msg-2"}, ids)
+	assert.False(t, hasMore)
+
+	ids, hasMore = page(repository.ChatHistoryCursor{BeforeID: "msg-1"}, 5)
+	assert.Empty(t, ids)
+	assert.False(t, hasMore)
+
+	// A timestamp cursor is exclusive
+	ids, hasMore = page(repository.ChatHistoryCursor{Before: t2}, 2)
+	assert.Equal(t, []string{"msg-3", "msg-4"}, ids)
+	assert.True(t, hasMore)
+
+	ids, hasMore = page(repository.ChatHistoryCursor{Before: t1}, 5)
+	assert.Equal(t, []string{"msg-1"}, ids)
+	assert.False(t, hasMore)
+
+	_, _, err := repo.GetMessagesBefore("room-1", repository.ChatHistoryCursor{BeforeID: "unknown"}, 5)
+	assert.ErrorIs(t, err, ErrMessageNotFound)
+
+	// A cursor pruned away since the client received it is reported, not treated as the newest
+	count, err := repo.PruneMessagesBefore("room-1", t1)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+
+	_, _, err = repo.GetMessagesBefore("room-1", repository.ChatHistoryCursor{BeforeID: "msg-1"}, 5)
+	assert.ErrorIs(t, err, ErrMessageNotFound)
+
+	ids, hasMore = page(repository.ChatHistoryCursor{BeforeID: "msg-3"}, 5)
+	assert.Equal(t, []string{"msg-2"}, ids)
+	assert.False(t, hasMore)
+}
+
+func TestInMemoryChatRepository_GetMessagesBefore(t *testing.T) {
+	assertGetMessagesBefore(t, NewInMemoryChatRepository())
+}
+
+// assertEditAndDelete checks that messages are handed out as copies and that edits and
+// deletions check who makes them and never land on a deleted message
+func assertEditAndDelete(t *testing.T, repo repository.ChatRepository) {
+	now := time.Now()
+	addTestMessages(t, repo, "room-1", now, now)
+	_, err := repo.AddReaction("room-1", "msg-1", "👍", "user-2", 0)
+	assert.NoError(t, err)
+
+	// Changing a message read from the repository doesn't change the stored one
+	msg, err := repo.GetMessage("room-1", "msg-1")
+	assert.NoError(t, err)
+	msg.Content = "changed"
+	msg.Reactions["👎"] = []string{"user-3"}
+	stored, _ := repo.GetMessage("room-1", "msg-1")
+	assert.Equal(t, "message 1", stored.Content)
+	assert.Equal(t, map[string][]string{"👍": {"user-2"}}, stored.Reactions)
+
+	_, err = repo.EditMessage("room-1", "msg-1", "user-2", "pwned", time.Minute, now)
+	assert.ErrorIs(t, err, entity.ErrNotMessageAuthor)
+	_, err = repo.EditMessage("room-1", "msg-1", "user-1", "late", time.Minute, now.Add(time.Hour))
+	assert.ErrorIs(t, err, entity.ErrEditWindowExpired)
+	_, err = repo.EditMessage("room-1", "unknown", "user-1", "hi", time.Minute, now)
+	assert.ErrorIs(t, err, ErrMessageNotFound)
+
+	edited, err := repo.EditMessage("room-1", "msg-1", "user-1", "edited", time.Minute, now)
+	assert.NoError(t, err)
+	assert.Equal(t, "edited", edited.Content)
+	assert.Equal(t, []entity.ChatRevision{{Content: "message 1", EditedAt: edited.Revisions[0].EditedAt}}, edited.Revisions)
+	assert.Equal(t, []string{"user-2"}, edited.Reactions["👍"])
+
+	_, err = repo.DeleteMessage("room-1", "msg-1", "user-2", false, now)
+	assert.ErrorIs(t, err, entity.ErrNotAllowedToDelete)
+
+	original, err := repo.DeleteMessage("room-1", "msg-1", "user-1", false, now)
+	assert.NoError(t, err)
+	assert.Equal(t, "edited", original.Content)
+	assert.False(t, original.IsDeleted())
+
+	deleted, _ := repo.GetMessage("room-1", "msg-1")
+	assert.True(t, deleted.IsDeleted())
+	assert.Empty(t, deleted.Content)
+	assert.Empty(t, deleted.Reactions)
+
+	// Nothing brings a deleted message back
+	_, err = repo.EditMessage("room-1", "msg-1", "user-1", "back", time.Minute, now)
+	assert.ErrorIs(t, err, entity.ErrMessageDeleted)
+	_, err = repo.DeleteMessage("room-1", "msg-1", "user-1", true, now)
+	assert.ErrorIs(t, err, entity.ErrMessageDeleted)
+	err = repo.UpdateMessage(original)
+	assert.ErrorIs(t, err, entity.ErrMessageDeleted)
+	deleted, _ = repo.GetMessage("room-1", "msg-1")
+	assert.True(t, deleted.IsDeleted())
+
+	// Moderators may delete anyone's message
+	_, err = repo.DeleteMessage("room-1", "msg-2", "owner-1", true, now)
+	assert.NoError(t, err)
+}
+
+func TestInMemoryChatRepository_EditAndDelete(t *testing.T) {
+	assertEditAndDelete(t, NewInMemoryChatRepository())
+}
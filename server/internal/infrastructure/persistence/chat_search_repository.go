@@ -0,0 +1,182 @@
+package persistence
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"voice-chat/internal/domain/entity"
+	"voice-chat/internal/domain/repository"
+)
+
+// searchDoc is an indexed copy of a message
+type searchDoc struct {
+	result *repository.ChatSearchResult
+	terms  []string
+}
+
+// InMemoryChatSearchRepository is an in-memory inverted index implementing ChatSearchRepository
+type InMemoryChatSearchRepository struct {
+	docs  map[string]*searchDoc          // roomID:messageID -> document
+	terms map[string]map[string]struct{} // term -> document keys
+	mu    sync.RWMutex
+}
+
+// NewInMemoryChatSearchRepository creates a new InMemoryChatSearchRepository
+func NewInMemoryChatSearchRepository() *InMemoryChatSearchRepository {
+	return &InMemoryChatSearchRepository{
+		docs:  make(map[string]*searchDoc),
+		terms: make(map[string]map[string]struct{}),
+	}
+}
+
+func searchDocKey(roomID, messageID string) string {
+	return roomID + ":" + messageID
+}
+
+// Index adds or replaces a message in the index; an empty roomName keeps the stored one
+func (r *InMemoryChatSearchRepository) Index(message *entity.ChatMessage, roomName string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := searchDocKey(message.RoomID, message.ID)
+	if old, exists := r.docs[key]; exists && roomName == "" {
+		roomName = old.result.RoomName
+	}
+	r.remove(key)
+
+	// Copy so later edits only show up once they are re-indexed
+	snapshot := *message
+	doc := &searchDoc{
+		result: &repository.ChatSearchResult{Message: &snapshot, RoomName: roomName},
+		terms:  repository.SearchTerms(message.Content),
+	}
+	r.docs[key] = doc
+	for _, term := range doc.terms {
+		if r.terms[term] == nil {
+			r.terms[term] = make(map[string]struct{})
+		}
+		r.terms[term][key] = struct{}{}
+	}
+	return nil
+}
+
+// Remove drops a message from the index
+func (r *InMemoryChatSearchRepository) Remove(roomID, messageID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.remove(searchDocKey(roomID, messageID))
+	return nil
+}
+
+// remove drops a document and its postings (caller holds the write lock)
+func (r *InMemoryChatSearchRepository) remove(key string) {
+	doc, exists := r.docs[key]
+	if !exists {
+		return
+	}
+	for _, term := range doc.terms {
+		delete(r.terms[term], key)
+		if len(r.terms[term]) == 0 {
+			delete(r.terms, term)
+		}
+	}
+	delete(r.docs, key)
+}
+
+// Search retrieves matching messages (newest first, up to query.Limit)
+func (r *InMemoryChatSearchRepository) Search(query repository.ChatSearchQuery) ([]*repository.ChatSearchResult, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	terms := repository.SearchTerms(query.Text)
+
+	// Walk the rarest term's postings, or every document for filter-only queries
+	candidates := make([]string, 0)
+	if len(terms) == 0 {
+		for key := range r.docs {
+			candidates = append(candidates, key)
+		}
+	} else {
+		rarest := terms[0]
+		for _, term := range terms[1:] {
+			if len(r.terms[term]) < len(r.terms[rarest]) {
+				rarest = term
+			}
+		}
+		for key := range r.terms[rarest] {
+			candidates = append(candidates, key)
+		}
+	}
+
+	results := make([]*repository.ChatSearchResult, 0)
+	for _, key := range candidates {
+		doc := r.docs[key]
+		if !r.hasTerms(key, terms) || !query.Matches(doc.result) {
+			continue
+		}
+		results = append(results, doc.result)
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].Message.Timestamp.After(results[j].Message.Timestamp)
+	})
+	if query.Limit > 0 && len(results) > query.Limit {
+		results = results[:query.Limit]
+	}
+	return results, nil
+}
+
+// hasTerms checks if a document contains every term (caller holds the read lock)
+func (r *InMemoryChatSearchRepository) hasTerms(key string, terms []string) bool {
+	for _, term := range terms {
+		if _, ok := r.terms[term][key]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// Prune drops messages sent before the cutoff and returns how many were removed
+func (r *InMemoryChatSearchRepository) Prune(before time.Time) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	count := 0
+	for key, doc := range r.docs {
+		if doc.result.Message.Timestamp.Before(before) {
+			r.remove(key)
+			count++
+		}
+	}
+	return count, nil
+}
+
+// PruneRoom drops a room's messages sent before the cutoff and returns how many were removed
+func (r *InMemoryChatSearchRepository) PruneRoom(roomID string, before time.Time) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	count := 0
+	for key, doc := range r.docs {
+		if doc.result.Message.RoomID == roomID && doc.result.Message.Timestamp.Before(before) {
+			r.remove(key)
+			count++
+		}
+	}
+	return count, nil
+}
+
+// RemoveRoom drops every message of a room
+func (r *InMemoryChatSearchRepository) RemoveRoom(roomID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for key, doc := range r.docs {
+		if doc.result.Message.RoomID == roomID {
+			r.remove(key)
+		}
+	}
+	return nil
+}
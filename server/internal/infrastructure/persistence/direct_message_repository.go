@@ -0,0 +1,211 @@
+package persistence
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"voice-chat/internal/domain/entity"
+	"voice-chat/internal/domain/repository"
+)
+
+// InMemoryDirectMessageRepository is an in-memory implementation of DirectMessageRepository
+type InMemoryDirectMessageRepository struct {
+	// conversationID -> messages (ordered by timestamp, newest last)
+	conversations map[string][]*entity.DirectMessage
+	// messageID -> message (for quick lookup)
+	messageIndex map[string]*entity.DirectMessage
+	mu           sync.RWMutex
+	maxMessages  int
+}
+
+// NewInMemoryDirectMessageRepository creates a new InMemoryDirectMessageRepository
+func NewInMemoryDirectMessageRepository() *InMemoryDirectMessageRepository {
+	return &InMemoryDirectMessageRepository{
+		conversations: make(map[string][]*entity.DirectMessage),
+		messageIndex:  make(map[string]*entity.DirectMessage),
+		maxMessages:   repository.DefaultDMRetention,
+	}
+}
+
+// AddMessage stores a new direct message
+func (r *InMemoryDirectMessageRepository) AddMessage(message *entity.DirectMessage) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	convID := message.ConversationID()
+	messages := append(r.conversations[convID], message)
+	r.messageIndex[message.ID] = message
+
+	// Trim if over max
+	if len(messages) > r.maxMessages {
+		delete(r.messageIndex, messages[0].ID)
+		messages = messages[1:]
+	}
+	r.conversations[convID] = messages
+
+	return nil
+}
+
+// GetConversation retrieves up to limit of the newest messages between two users (chronological order)
+func (r *InMemoryDirectMessageRepository) GetConversation(userA, userB, beforeID string, limit int) ([]*entity.DirectMessage, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	messages := r.conversations[entity.ConversationID(userA, userB)]
+
+	// end is the index of the first message not included in the page
+	end := len(messages)
+	if beforeID != "" {
+		before, exists := r.messageIndex[beforeID]
+		if !exists || !before.IsParticipant(userA) || !before.IsParticipant(userB) {
+			return nil, ErrMessageNotFound
+		}
+		for end > 0 && messages[end-1].ID != beforeID {
+			end--
+		}
+		end--
+	}
+
+	if limit <= 0 || limit > end {
+		limit = end
+	}
+
+	result := make([]*entity.DirectMessage, limit)
+	copy(result, messages[end-limit:end])
+	return result, nil
+}
+
+// GetMessage retrieves a specific message by ID
+func (r *InMemoryDirectMessageRepository) GetMessage(messageID string) (*entity.DirectMessage, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	message, exists := r.messageIndex[messageID]
+	if !exists {
+		return nil, ErrMessageNotFound
+	}
+	return message, nil
+}
+
+// Cleanup removes messages older than maxAge
+func (r *InMemoryDirectMessageRepository) Cleanup(maxAge time.Duration) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	cutoff := time.Now().Add(-maxAge)
+	count := 0
+	for convID, messages := range r.conversations {
+		// Messages are ordered, so everything before the first recent one has expired
+		keep := sort.Search(len(messages), func(i int) bool {
+			return !messages[i].Timestamp.Before(cutoff)
+		})
+		for _, message := range messages[:keep] {
+			delete(r.messageIndex, message.ID)
+		}
+		count += keep
+
+		if keep == len(messages) {
+			delete(r.conversations, convID)
+		} else {
+			r.conversations[convID] = messages[keep:]
+		}
+	}
+	return count
+}
+
+// InMemoryBlockRepository is an in-memory implementation of BlockRepository
+type InMemoryBlockRepository struct {
+	blocks map[string]map[string]bool // userID -> blocked user IDs
+	mu     sync.RWMutex
+}
+
+// NewInMemoryBlockRepository creates a new InMemoryBlockRepository
+func NewInMemoryBlockRepository() *InMemoryBlockRepository {
+	return &InMemoryBlockRepository{
+		blocks: make(map[string]map[string]bool),
+	}
+}
+
+// Block stops blockedID from messaging userID
+func (r *InMemoryBlockRepository) Block(userID, blockedID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.blocks[userID] == nil {
+		r.blocks[userID] = make(map[string]bool)
+	}
+	r.blocks[userID][blockedID] = true
+	return nil
+}
+
+// Unblock removes blockedID from userID's block list
+func (r *InMemoryBlockRepository) Unblock(userID, blockedID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.blocks[userID], blockedID)
+	if len(r.blocks[userID]) == 0 {
+		delete(r.blocks, userID)
+	}
+	return nil
+}
+
+// IsBlocked checks if userID has blocked blockedID
+func (r *InMemoryBlockRepository) IsBlocked(userID, blockedID string) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.blocks[userID][blockedID]
+}
+
+// GetBlocked retrieves the users userID has blocked
+func (r *InMemoryBlockRepository) GetBlocked(userID string) ([]string, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make([]string, 0, len(r.blocks[userID]))
+	for blockedID := range r.blocks[userID] {
+		result = append(result, blockedID)
+	}
+	sort.Strings(result)
+	return result, nil
+}
+
+// InMemoryDMReportRepository is an in-memory implementation of DMReportRepository
+type InMemoryDMReportRepository struct {
+	reports []*entity.DMReport
+	mu      sync.RWMutex
+}
+
+// NewInMemoryDMReportRepository creates a new InMemoryDMReportRepository
+func NewInMemoryDMReportRepository() *InMemoryDMReportRepository {
+	return &InMemoryDMReportRepository{
+		reports: make([]*entity.DMReport, 0),
+	}
+}
+
+// Create stores a new report
+func (r *InMemoryDMReportRepository) Create(report *entity.DMReport) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.reports = append(r.reports, report)
+	return nil
+}
+
+// GetRecent retrieves the most recent N reports (newest first)
+func (r *InMemoryDMReportRepository) GetRecent(limit int) ([]*entity.DMReport, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if limit <= 0 || limit > len(r.reports) {
+		limit = len(r.reports)
+	}
+
+	result := make([]*entity.DMReport, 0, limit)
+	for i := len(r.reports) - 1; i >= 0 && len(result) < limit; i-- {
+		result = append(result, r.reports[i])
+	}
+	return result, nil
+}
@@ -0,0 +1,542 @@
+package persistence
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"voice-chat/internal/domain/entity"
+	"voice-chat/internal/domain/repository"
+)
+
+// Chat log entry operations
+const (
+	chatLogAdd       = "add"        // A new message
+	chatLogUpdate    = "update"     // An edited or deleted message, stored whole
+	chatLogReact     = "react"      // A reaction added
+	chatLogUnreact   = "unreact"    // A reaction removed
+	chatLogVote      = "vote"       // A poll vote
+	chatLogClosePoll = "close_poll" // A poll closed
+	chatLogLimits    = "limits"     // The room's message cap changed
+	chatLogPrune     = "prune"      // Messages before a cutoff removed
+)
+
+// chatLogCompactSlack is how many entries a log may hold beyond twice the room's messages
+// before it is compacted, so small rooms aren't rewritten after every few reactions
+const chatLogCompactSlack = 100
+
+// chatLogEntry is one line of a room's log
+type chatLogEntry struct {
+	Op        string              `json:"op"`
+	Message   *entity.ChatMessage `json:"message,omitempty"`
+	MessageID string              `json:"message_id,omitempty"`
+	Emoji     string              `json:"emoji,omitempty"`
+	UserID    string              `json:"user_id,omitempty"`
+	Choices   []int               `json:"choices,omitempty"`
+	Limit     int                 `json:"limit,omitempty"`
+	At        *time.Time          `json:"at,omitempty"`
+}
+
+// chatLog is a room's open log file
+type chatLog struct {
+	file    *os.File
+	entries int  // Lines in the file, to decide when to compact
+	dirty   bool // Written since the last fsync
+}
+
+// FileChatRepository is a ChatRepository for installs without Redis. History is served from
+// memory, and every change is appended to a per-room JSON Lines log under a directory that is
+// replayed at startup. Appends reach the OS right away, so they survive a crash of the server;
+// fsyncs are batched by Sync to bound what a power loss can take. Compact rewrites logs that
+// have grown well past the history they hold as a snapshot of it.
+type FileChatRepository struct {
+	dir      string
+	mem      *InMemoryChatRepository
+	logs     map[string]*chatLog // roomID -> log
+	limits   map[string]int      // roomID -> messages kept, carried into snapshots
+	syncEach bool                // fsync every append instead of batching
+	mu       sync.Mutex          // Serializes changes so each log records them in the order applied
+}
+
+// NewFileChatRepository creates a FileChatRepository, replaying the logs found in dir. With
+// syncEach every append is fsynced before it returns; otherwise call Sync periodically.
+func NewFileChatRepository(dir string, syncEach bool) (*FileChatRepository, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create chat log directory: %w", err)
+	}
+
+	r := &FileChatRepository{
+		dir:      dir,
+		mem:      NewInMemoryChatRepository(),
+		logs:     make(map[string]*chatLog),
+		limits:   make(map[string]int),
+		syncEach: syncEach,
+	}
+
+	paths, err := filepath.Glob(filepath.Join(dir, "*.log"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list chat logs: %w", err)
+	}
+	for _, path := range paths {
+		roomID, err := url.PathUnescape(strings.TrimSuffix(filepath.Base(path), ".log"))
+		if err != nil {
+			log.Printf("Skipping chat log %s: %v", path, err)
+			continue
+		}
+		if err := r.replay(roomID, path); err != nil {
+			return nil, err
+		}
+	}
+
+	return r, nil
+}
+
+// logPath returns where a room's log is kept; escaping keeps any room ID inside the directory
+func (r *FileChatRepository) logPath(roomID string) string {
+	return filepath.Join(r.dir, url.PathEscape(roomID)+".log")
+}
+
+// replay applies a room's log to memory. A torn last line from a crash mid-write is cut off
+// so new entries start on a clean line; other unreadable lines are skipped.
+func (r *FileChatRepository) replay(roomID, path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open chat log: %w", err)
+	}
+	defer file.Close()
+
+	reader := bufio.NewReader(file)
+	var offset int64
+	entries := 0
+	for {
+		line, err := reader.ReadBytes('\n')
+		if err == io.EOF {
+			if len(line) > 0 {
+				log.Printf("Truncating torn entry at the end of chat log %s", path)
+				if err := os.Truncate(path, offset); err != nil {
+					return fmt.Errorf("failed to truncate chat log: %w", err)
+				}
+			}
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read chat log: %w", err)
+		}
+		offset += int64(len(line))
+		entries++
+
+		var entry chatLogEntry
+		if err := json.Unmarshal(line, &entry); err != nil {
+			log.Printf("Skipping corrupted entry in chat log %s: %v", path, err)
+			continue
+		}
+		r.apply(roomID, entry)
+	}
+
+	appendFile, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open chat log: %w", err)
+	}
+	r.logs[roomID] = &chatLog{file: appendFile, entries: entries}
+	return nil
+}
+
+// apply replays one entry; entries for messages trimmed since are ignored
+func (r *FileChatRepository) apply(roomID string, entry chatLogEntry) {
+	switch entry.Op {
+	case chatLogAdd:
+		if entry.Message != nil {
+			r.mem.AddMessage(entry.Message)
+		}
+	case chatLogUpdate:
+		if entry.Message != nil {
+			r.mem.UpdateMessage(entry.Message)
+		}
+	case chatLogReact:
+		// The cap was checked when the reaction was added
+		r.mem.AddReaction(roomID, entry.MessageID, entry.Emoji, entry.UserID, 0)
+	case chatLogUnreact:
+		r.mem.RemoveReaction(roomID, entry.MessageID, entry.Emoji, entry.UserID)
+	case chatLogVote:
+		if entry.At != nil {
+			r.mem.VotePoll(roomID, entry.MessageID, entry.UserID, entry.Choices, *entry.At)
+		}
+	case chatLogClosePoll:
+		if entry.At != nil {
+			r.mem.ClosePoll(roomID, entry.MessageID, *entry.At)
+		}
+	case chatLogLimits:
+		r.setLimit(roomID, entry.Limit)
+		r.mem.SetRoomLimits(roomID, entry.Limit, 0)
+	case chatLogPrune:
+		if entry.At != nil {
+			r.mem.PruneMessagesBefore(roomID, *entry.At)
+		}
+	}
+}
+
+// setLimit records a room's message cap for snapshots; the caller must hold the lock
+func (r *FileChatRepository) setLimit(roomID string, limit int) {
+	if limit <= 0 {
+		delete(r.limits, roomID)
+	} else {
+		r.limits[roomID] = limit
+	}
+}
+
+// record appends an entry to a room's log, opening it on first use; the caller must hold the lock
+func (r *FileChatRepository) record(roomID string, entry chatLogEntry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to marshal chat log entry: %w", err)
+	}
+	data = append(data, '\n')
+
+	l, exists := r.logs[roomID]
+	if !exists {
+		file, err := os.OpenFile(r.logPath(roomID), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+		if err != nil {
+			return fmt.Errorf("failed to open chat log: %w", err)
+		}
+		l = &chatLog{file: file}
+		r.logs[roomID] = l
+	}
+
+	// A single write per entry, so a crash leaves at most the last line torn
+	if _, err := l.file.Write(data); err != nil {
+		return fmt.Errorf("failed to append to chat log: %w", err)
+	}
+	l.entries++
+	if r.syncEach {
+		return l.file.Sync()
+	}
+	l.dirty = true
+	return nil
+}
+
+// AddMessage stores a new chat message for a room
+func (r *FileChatRepository) AddMessage(message *entity.ChatMessage) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.mem.AddMessage(message); err != nil {
+		return err
+	}
+	return r.record(message.RoomID, chatLogEntry{Op: chatLogAdd, Message: message})
+}
+
+// GetMessages retrieves chat messages for a room (chronological order)
+func (r *FileChatRepository) GetMessages(roomID string, limit int) ([]*entity.ChatMessage, error) {
+	return r.mem.GetMessages(roomID, limit)
+}
+
+// GetMessagesBefore retrieves up to limit messages older than the cursor (chronological order)
+func (r *FileChatRepository) GetMessagesBefore(roomID string, cursor repository.ChatHistoryCursor, limit int) ([]*entity.ChatMessage, bool, error) {
+	return r.mem.GetMessagesBefore(roomID, cursor, limit)
+}
+
+// CountMessagesAfter counts messages newer than messageID; an empty or expired ID counts every message
+func (r *FileChatRepository) CountMessagesAfter(roomID, messageID string) (int, error) {
+	return r.mem.CountMessagesAfter(roomID, messageID)
+}
+
+// GetReplies retrieves up to limit of the newest replies to a message (chronological order)
+func (r *FileChatRepository) GetReplies(roomID, parentID string, limit int) ([]*entity.ChatMessage, error) {
+	return r.mem.GetReplies(roomID, parentID, limit)
+}
+
+// GetMessage retrieves a specific message by ID
+func (r *FileChatRepository) GetMessage(roomID, messageID string) (*entity.ChatMessage, error) {
+	return r.mem.GetMessage(roomID, messageID)
+}
+
+// UpdateMessage updates a message, logging it whole (edits and deletions)
+func (r *FileChatRepository) UpdateMessage(message *entity.ChatMessage) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.mem.UpdateMessage(message); err != nil {
+		return err
+	}
+	return r.record(message.RoomID, chatLogEntry{Op: chatLogUpdate, Message: message})
+}
+
+// EditMessage edits a message and logs it whole
+func (r *FileChatRepository) EditMessage(roomID, messageID, userID, content string, window time.Duration, now time.Time) (*entity.ChatMessage, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	edited, err := r.mem.EditMessage(roomID, messageID, userID, content, window, now)
+	if err != nil {
+		return nil, err
+	}
+	return edited, r.record(roomID, chatLogEntry{Op: chatLogUpdate, Message: edited})
+}
+
+// DeleteMessage turns a message into a tombstone and logs the tombstone whole
+func (r *FileChatRepository) DeleteMessage(roomID, messageID, userID string, moderator bool, now time.Time) (*entity.ChatMessage, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	original, err := r.mem.DeleteMessage(roomID, messageID, userID, moderator, now)
+	if err != nil {
+		return nil, err
+	}
+	// Every change goes through r.mu, so this is the tombstone just written
+	deleted, err := r.mem.GetMessage(roomID, messageID)
+	if err != nil {
+		return nil, err
+	}
+	return original, r.record(roomID, chatLogEntry{Op: chatLogUpdate, Message: deleted})
+}
+
+// AddReaction adds a user's reaction and logs it
+func (r *FileChatRepository) AddReaction(roomID, messageID, emoji, userID string, maxEmojis int) ([]string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	users, err := r.mem.AddReaction(roomID, messageID, emoji, userID, maxEmojis)
+	if err != nil {
+		return nil, err
+	}
+	return users, r.record(roomID, chatLogEntry{Op: chatLogReact, MessageID: messageID, Emoji: emoji, UserID: userID})
+}
+
+// RemoveReaction removes a user's reaction and logs it
+func (r *FileChatRepository) RemoveReaction(roomID, messageID, emoji, userID string) ([]string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	users, err := r.mem.RemoveReaction(roomID, messageID, emoji, userID)
+	if err != nil {
+		return nil, err
+	}
+	return users, r.record(roomID, chatLogEntry{Op: chatLogUnreact, MessageID: messageID, Emoji: emoji, UserID: userID})
+}
+
+// VotePoll records a user's choices on a poll and logs them with the vote's time, so replay
+// reaches the same result
+func (r *FileChatRepository) VotePoll(roomID, messageID, userID string, choices []int, now time.Time) (*entity.Poll, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	poll, err := r.mem.VotePoll(roomID, messageID, userID, choices, now)
+	if err != nil {
+		return nil, err
+	}
+	return poll, r.record(roomID, chatLogEntry{Op: chatLogVote, MessageID: messageID, UserID: userID, Choices: choices, At: &now})
+}
+
+// ClosePoll closes a poll and logs it when it was still open
+func (r *FileChatRepository) ClosePoll(roomID, messageID string, now time.Time) (*entity.Poll, bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	poll, closed, err := r.mem.ClosePoll(roomID, messageID, now)
+	if err != nil || !closed {
+		return poll, closed, err
+	}
+	return poll, true, r.record(roomID, chatLogEntry{Op: chatLogClosePoll, MessageID: messageID, At: &now})
+}
+
+// SetRoomLimits overrides how many messages a room keeps. Like in memory, messages don't
+// expire, so the TTL is ignored; age limits are enforced by pruning.
+func (r *FileChatRepository) SetRoomLimits(roomID string, maxMessages int, ttl time.Duration) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if maxMessages < 0 {
+		maxMessages = 0
+	}
+	if r.limits[roomID] == maxMessages {
+		return nil // Applied again after every restart and rooms file reload
+	}
+
+	r.setLimit(roomID, maxMessages)
+	if err := r.mem.SetRoomLimits(roomID, maxMessages, ttl); err != nil {
+		return err
+	}
+	return r.record(roomID, chatLogEntry{Op: chatLogLimits, Limit: maxMessages})
+}
+
+// PruneMessagesBefore deletes a room's messages sent before the cutoff
+func (r *FileChatRepository) PruneMessagesBefore(roomID string, before time.Time) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	count, err := r.mem.PruneMessagesBefore(roomID, before)
+	if err != nil || count == 0 {
+		return count, err
+	}
+	return count, r.record(roomID, chatLogEntry{Op: chatLogPrune, At: &before})
+}
+
+// DeleteRoomMessages deletes all messages for a room along with its log
+func (r *FileChatRepository) DeleteRoomMessages(roomID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.mem.DeleteRoomMessages(roomID); err != nil {
+		return err
+	}
+	delete(r.limits, roomID)
+
+	if l, exists := r.logs[roomID]; exists {
+		l.file.Close()
+		delete(r.logs, roomID)
+	}
+	if err := os.Remove(r.logPath(roomID)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to delete chat log: %w", err)
+	}
+	return nil
+}
+
+// RoomIDs lists the rooms that have a chat log
+func (r *FileChatRepository) RoomIDs() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	ids := make([]string, 0, len(r.logs))
+	for roomID := range r.logs {
+		ids = append(ids, roomID)
+	}
+	return ids
+}
+
+// Sync fsyncs every log written since the last call
+func (r *FileChatRepository) Sync() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var firstErr error
+	for roomID, l := range r.logs {
+		if !l.dirty {
+			continue
+		}
+		if err := l.file.Sync(); err != nil {
+			log.Printf("Error syncing chat log for room %s: %v", roomID, err)
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		l.dirty = false
+	}
+	return firstErr
+}
+
+// Compact rewrites every log holding more than twice its room's messages as a snapshot of the
+// room, and reports how many logs were rewritten
+func (r *FileChatRepository) Compact() (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	count := 0
+	for roomID, l := range r.logs {
+		messages, err := r.mem.GetMessages(roomID, 0)
+		if err != nil {
+			return count, err
+		}
+		if l.entries <= 2*len(messages)+chatLogCompactSlack {
+			continue
+		}
+		if err := r.compact(roomID, messages); err != nil {
+			return count, err
+		}
+		count++
+	}
+	return count, nil
+}
+
+// compact swaps a room's log for a snapshot written to a temporary file, fsynced and renamed
+// into place, so a crash leaves either the old log or the new one; the caller must hold the lock
+func (r *FileChatRepository) compact(roomID string, messages []*entity.ChatMessage) error {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	entries := 0
+	if limit, exists := r.limits[roomID]; exists {
+		if err := enc.Encode(chatLogEntry{Op: chatLogLimits, Limit: limit}); err != nil {
+			return fmt.Errorf("failed to encode chat snapshot: %w", err)
+		}
+		entries++
+	}
+	for _, msg := range messages {
+		if err := enc.Encode(chatLogEntry{Op: chatLogAdd, Message: msg}); err != nil {
+			return fmt.Errorf("failed to encode chat snapshot: %w", err)
+		}
+		entries++
+	}
+
+	path := r.logPath(roomID)
+	tmp := path + ".tmp"
+	file, err := os.OpenFile(tmp, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to write chat snapshot: %w", err)
+	}
+	if _, err := file.Write(buf.Bytes()); err != nil {
+		file.Close()
+		os.Remove(tmp)
+		return fmt.Errorf("failed to write chat snapshot: %w", err)
+	}
+	if err := file.Sync(); err != nil {
+		file.Close()
+		os.Remove(tmp)
+		return fmt.Errorf("failed to sync chat snapshot: %w", err)
+	}
+	file.Close()
+
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("failed to replace chat log: %w", err)
+	}
+	// The snapshot is in place either way; a failed directory sync only means the rename
+	// might not survive a power loss, so appends move on to the new file before reporting it
+	syncErr := r.syncDir()
+
+	// Appends continue on the new file
+	r.logs[roomID].file.Close()
+	appendFile, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		delete(r.logs, roomID)
+		return fmt.Errorf("failed to reopen chat log: %w", err)
+	}
+	r.logs[roomID] = &chatLog{file: appendFile, entries: entries}
+	return syncErr
+}
+
+// syncDir fsyncs the log directory so a rename survives a power loss
+func (r *FileChatRepository) syncDir() error {
+	dir, err := os.Open(r.dir)
+	if err != nil {
+		return fmt.Errorf("failed to open chat log directory: %w", err)
+	}
+	defer dir.Close()
+
+	if err := dir.Sync(); err != nil {
+		return fmt.Errorf("failed to sync chat log directory: %w", err)
+	}
+	return nil
+}
+
+// Close fsyncs and closes every log
+func (r *FileChatRepository) Close() error {
+	err := r.Sync()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for roomID, l := range r.logs {
+		l.file.Close()
+		delete(r.logs, roomID)
+	}
+	return err
+}
@@ -0,0 +1,198 @@
+package persistence
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"voice-chat/internal/domain/entity"
+)
+
+func openTestFileChatRepository(t *testing.T, dir string) *FileChatRepository {
+	repo, err := NewFileChatRepository(dir, false)
+	assert.NoError(t, err)
+	t.Cleanup(func() { repo.Close() })
+	return repo
+}
+
+// reopen closes a repository and replays its logs into a new one, like a restart
+func reopen(t *testing.T, repo *FileChatRepository) *FileChatRepository {
+	assert.NoError(t, repo.Close())
+	return openTestFileChatRepository(t, repo.dir)
+}
+
+// snapshot returns a room's messages as JSON, for comparing state across restarts
+func snapshot(t *testing.T, repo *FileChatRepository, roomID string) string {
+	messages, err := repo.GetMessages(roomID, 0)
+	assert.NoError(t, err)
+	data, err := json.Marshal(messages)
+	assert.NoError(t, err)
+	return string(data)
+}
+
+// logLines returns the lines of a room's log
+func logLines(t *testing.T, repo *FileChatRepository, roomID string) []string {
+	data, err := os.ReadFile(repo.logPath(roomID))
+	assert.NoError(t, err)
+	return strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+}
+
+// fillTestRoom stores a thread, a poll and a deleted message, and reacts to them
+func fillTestRoom(t *testing.T, repo *FileChatRepository) {
+	now := time.Date(2026, 6, 1, 18, 0, 0, 0, time.UTC)
+	addTestMessages(t, repo, "room-1", now, now.Add(time.Second), now.Add(2*time.Second))
+
+	for _, id := range []string{"reply-1", "reply-2"} {
+		reply := entity.NewChatMessage(id, "room-1", "user-2", "Bob", "agreed")
+		reply.ReplyTo = "msg-1"
+		assert.NoError(t, repo.AddMessage(reply))
+	}
+
+	_, err := repo.AddReaction("room-1", "msg-1", "👍", "user-2", 0)
+	assert.NoError(t, err)
+	_, err = repo.AddReaction("room-1", "msg-1", "🔥", "user-3", 0)
+	assert.NoError(t, err)
+	_, err = repo.RemoveReaction("room-1", "msg-1", "🔥", "user-3")
+	assert.NoError(t, err)
+
+	edited, _ := repo.GetMessage("room-1", "msg-2")
+	edited.Edit("message 2, edited", now.Add(time.Minute))
+	assert.NoError(t, repo.UpdateMessage(edited))
+
+	deleted, _ := repo.GetMessage("room-1", "msg-3")
+	deleted.Delete("user-1", now.Add(time.Minute))
+	assert.NoError(t, repo.UpdateMessage(deleted))
+
+	poll, err := entity.NewPoll("Map?", []string{"Dust", "Mirage"}, false, false, 0, now)
+	assert.NoError(t, err)
+	assert.NoError(t, repo.AddMessage(entity.NewPollMessage("poll-1", "room-1", "user-1", "Alice", poll)))
+	_, err = repo.VotePoll("room-1", "poll-1", "user-2", []int{1}, now.Add(time.Minute))
+	assert.NoError(t, err)
+	_, closed, err := repo.ClosePoll("room-1", "poll-1", now.Add(2*time.Minute))
+	assert.NoError(t, err)
+	assert.True(t, closed)
+}
+
+func TestFileChatRepository_ReplayRebuildsState(t *testing.T) {
+	repo := openTestFileChatRepository(t, t.TempDir())
+	fillTestRoom(t, repo)
+	before := snapshot(t, repo, "room-1")
+
+	repo = reopen(t, repo)
+	assert.Equal(t, before, snapshot(t, repo, "room-1"))
+	assert.Equal(t, []string{"room-1"}, repo.RoomIDs())
+
+	parent, err := repo.GetMessage("room-1", "msg-1")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, parent.ReplyCount)
+	assert.Equal(t, map[string][]string{"👍": {"user-2"}}, parent.Reactions)
+
+	replies, _ := repo.GetReplies("room-1", "msg-1", 10)
+	assert.Equal(t, []string{"reply-1", "reply-2"}, idsOf(replies))
+
+	edited, _ := repo.GetMessage("room-1", "msg-2")
+	assert.Equal(t, "message 2, edited", edited.Content)
+	assert.Len(t, edited.Revisions, 1)
+
+	deleted, _ := repo.GetMessage("room-1", "msg-3")
+	assert.True(t, deleted.IsDeleted())
+
+	poll, _ := repo.GetMessage("room-1", "poll-1")
+	assert.NotNil(t, poll.Poll.ClosedAt)
+	assert.Equal(t, []int{1}, poll.Poll.Votes["user-2"])
+}
+
+func TestFileChatRepository_GetMessagesBefore(t *testing.T) {
+	repo := openTestFileChatRepository(t, t.TempDir())
+	assertGetMessagesBefore(t, repo)
+}
+
+func TestFileChatRepository_EditAndDelete(t *testing.T) {
+	repo := openTestFileChatRepository(t, t.TempDir())
+	assertEditAndDelete(t, repo)
+
+	before := snapshot(t, repo, "room-1")
+	assert.Equal(t, before, snapshot(t, reopen(t, repo), "room-1"))
+}
+
+func TestFileChatRepository_TornLastLine(t *testing.T) {
+	repo := openTestFileChatRepository(t, t.TempDir())
+	fillTestRoom(t, repo)
+	before := snapshot(t, repo, "room-1")
+	lines := len(logLines(t, repo, "room-1"))
+	assert.NoError(t, repo.Close())
+
+	// A crash mid-write leaves half an entry without its newline
+	file, err := os.OpenFile(repo.logPath("room-1"), os.O_WRONLY|os.O_APPEND, 0o644)
+	assert.NoError(t, err)
+	file.WriteString(`{"op":"add","message":{"id":"msg-9","room_`)
+	file.Close()
+
+	repo = openTestFileChatRepository(t, repo.dir)
+	assert.Equal(t, before, snapshot(t, repo, "room-1"))
+	assert.Len(t, logLines(t, repo, "room-1"), lines)
+
+	// Appends continue on a clean line
+	assert.NoError(t, repo.AddMessage(entity.NewChatMessage("msg-10", "room-1", "user-1", "Alice", "back")))
+	repo = reopen(t, repo)
+	_, err = repo.GetMessage("room-1", "msg-10")
+	assert.NoError(t, err)
+	assert.Len(t, logLines(t, repo, "room-1"), lines+1)
+}
+
+func TestFileChatRepository_Compact(t *testing.T) {
+	repo := openTestFileChatRepository(t, t.TempDir())
+	fillTestRoom(t, repo)
+	assert.NoError(t, repo.SetRoomLimits("room-1", 6, 0))
+
+	// Not worth rewriting yet
+	count, err := repo.Compact()
+	assert.NoError(t, err)
+	assert.Zero(t, count)
+
+	for i := 0; i < chatLogCompactSlack; i++ {
+		repo.AddReaction("room-1", "msg-2", "👀", "user-4", 0)
+		repo.RemoveReaction("room-1", "msg-2", "👀", "user-4")
+	}
+	before := snapshot(t, repo, "room-1")
+
+	count, err = repo.Compact()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+	assert.Len(t, logLines(t, repo, "room-1"), 7) // The limits and six messages
+
+	repo = reopen(t, repo)
+	assert.Equal(t, before, snapshot(t, repo, "room-1"))
+	parent, _ := repo.GetMessage("room-1", "msg-1")
+	assert.Equal(t, 2, parent.ReplyCount)
+
+	// The room's cap survives compaction
+	assert.NoError(t, repo.AddMessage(entity.NewChatMessage("msg-10", "room-1", "user-1", "Alice", "one more")))
+	messages, _ := repo.GetMessages("room-1", 0)
+	assert.Len(t, messages, 6)
+}
+
+func TestFileChatRepository_PruneAndDeleteSurviveRestart(t *testing.T) {
+	repo := openTestFileChatRepository(t, t.TempDir())
+	now := time.Date(2026, 6, 1, 18, 0, 0, 0, time.UTC)
+	addTestMessages(t, repo, "room-1", now, now.Add(time.Second), now.Add(2*time.Second))
+	addTestMessages(t, repo, "room-2", now)
+
+	count, err := repo.PruneMessagesBefore("room-1", now.Add(2*time.Second))
+	assert.NoError(t, err)
+	assert.Equal(t, 2, count)
+	assert.NoError(t, repo.DeleteRoomMessages("room-2"))
+
+	repo = reopen(t, repo)
+	messages, _ := repo.GetMessages("room-1", 0)
+	assert.Equal(t, []string{"msg-3"}, idsOf(messages))
+	messages, _ = repo.GetMessages("room-2", 0)
+	assert.Empty(t, messages)
+	assert.Equal(t, []string{"room-1"}, repo.RoomIDs())
+	_, err = os.Stat(repo.logPath("room-2"))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
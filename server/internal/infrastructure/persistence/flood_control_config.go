@@ -0,0 +1,38 @@
+package persistence
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"voice-chat/internal/domain/entity"
+)
+
+// LoadFloodControlConfig reads and validates rate limits from a YAML or JSON file (chosen by
+// extension). Settings the file leaves out keep their defaults.
+func LoadFloodControlConfig(path string) (*entity.FloodControlConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read flood control file: %w", err)
+	}
+
+	config := entity.DefaultFloodControlConfig()
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		err = json.Unmarshal(data, config)
+	default:
+		err = yaml.Unmarshal(data, config)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse flood control file: %w", err)
+	}
+
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
@@ -0,0 +1,112 @@
+package persistence
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+var (
+	ErrBlobNotFound   = errors.New("blob not found")
+	ErrInvalidBlobKey = errors.New("invalid blob key")
+)
+
+// LocalBlobStore is a BlobStore that keeps blobs as files under a directory
+type LocalBlobStore struct {
+	root string
+}
+
+// NewLocalBlobStore creates a LocalBlobStore rooted at dir, creating it if needed
+func NewLocalBlobStore(dir string) (*LocalBlobStore, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create blob directory: %w", err)
+	}
+	return &LocalBlobStore{root: dir}, nil
+}
+
+// Put stores data under key, writing to a temporary file first so readers never see a partial blob
+func (s *LocalBlobStore) Put(key string, data []byte) error {
+	p, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+		return fmt.Errorf("failed to create blob directory: %w", err)
+	}
+
+	tmp := p + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write blob: %w", err)
+	}
+	if err := os.Rename(tmp, p); err != nil {
+		_ = os.Remove(tmp)
+		return fmt.Errorf("failed to write blob: %w", err)
+	}
+	return nil
+}
+
+// Open returns a blob's contents and modification time
+func (s *LocalBlobStore) Open(key string) (io.ReadSeekCloser, time.Time, error) {
+	p, err := s.path(key)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	f, err := os.Open(p)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, time.Time{}, ErrBlobNotFound
+	}
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	info, err := f.Stat()
+	if err != nil || info.IsDir() {
+		f.Close()
+		return nil, time.Time{}, ErrBlobNotFound
+	}
+	return f, info.ModTime(), nil
+}
+
+// Delete removes a blob
+func (s *LocalBlobStore) Delete(key string) error {
+	p, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(p); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// DeletePrefix removes every blob under prefix. Prefixes must end at a directory boundary
+// ("rooms/abc/"), which is how room attachments are laid out.
+func (s *LocalBlobStore) DeletePrefix(prefix string) error {
+	if !strings.HasSuffix(prefix, "/") {
+		return ErrInvalidBlobKey
+	}
+	p, err := s.path(strings.TrimSuffix(prefix, "/"))
+	if err != nil {
+		return err
+	}
+	return os.RemoveAll(p)
+}
+
+// path maps a key to a file under the root, rejecting keys that would escape it
+func (s *LocalBlobStore) path(key string) (string, error) {
+	if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, "\\") || path.Clean(key) != key {
+		return "", ErrInvalidBlobKey
+	}
+	for _, part := range strings.Split(key, "/") {
+		if part == "." || part == ".." {
+			return "", ErrInvalidBlobKey
+		}
+	}
+	return filepath.Join(s.root, filepath.FromSlash(key)), nil
+}
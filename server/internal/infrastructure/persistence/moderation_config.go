@@ -0,0 +1,37 @@
+package persistence
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"voice-chat/internal/domain/entity"
+)
+
+// LoadModerationConfig reads and validates chat filter settings from a YAML or JSON file (chosen by extension)
+func LoadModerationConfig(path string) (*entity.ModerationConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read moderation file: %w", err)
+	}
+
+	var config entity.ModerationConfig
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		err = json.Unmarshal(data, &config)
+	default:
+		err = yaml.Unmarshal(data, &config)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse moderation file: %w", err)
+	}
+
+	if err := config.Normalize(); err != nil {
+		return nil, err
+	}
+	return &config, nil
+}
@@ -0,0 +1,101 @@
+package persistence
+
+import (
+	"sync"
+	"time"
+)
+
+// readSession holds one session's read markers
+type readSession struct {
+	markers  map[string]string // roomID -> last read message ID
+	lastSeen time.Time
+}
+
+// InMemoryReadMarkerRepository is an in-memory implementation of ReadMarkerRepository
+type InMemoryReadMarkerRepository struct {
+	sessions map[string]*readSession // sessionID -> session
+	mu       sync.RWMutex
+}
+
+// NewInMemoryReadMarkerRepository creates a new InMemoryReadMarkerRepository
+func NewInMemoryReadMarkerRepository() *InMemoryReadMarkerRepository {
+	return &InMemoryReadMarkerRepository{
+		sessions: make(map[string]*readSession),
+	}
+}
+
+// session returns the session for an ID, creating it if needed (caller holds the write lock)
+func (r *InMemoryReadMarkerRepository) session(sessionID string) *readSession {
+	s, exists := r.sessions[sessionID]
+	if !exists {
+		s = &readSession{markers: make(map[string]string)}
+		r.sessions[sessionID] = s
+	}
+	s.lastSeen = time.Now()
+	return s
+}
+
+// SetLastRead records the last message a session has read in a room
+func (r *InMemoryReadMarkerRepository) SetLastRead(sessionID, roomID, messageID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.session(sessionID).markers[roomID] = messageID
+	return nil
+}
+
+// GetLastRead returns the last message a session has read in a room ("" if none)
+func (r *InMemoryReadMarkerRepository) GetLastRead(sessionID, roomID string) (string, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if s, exists := r.sessions[sessionID]; exists {
+		return s.markers[roomID], nil
+	}
+	return "", nil
+}
+
+// HasSession reports whether a session is known
+func (r *InMemoryReadMarkerRepository) HasSession(sessionID string) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	_, exists := r.sessions[sessionID]
+	return exists
+}
+
+// Touch creates a session or marks it as recently used
+func (r *InMemoryReadMarkerRepository) Touch(sessionID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.session(sessionID)
+	return nil
+}
+
+// DeleteRoom removes every session's marker for a room
+func (r *InMemoryReadMarkerRepository) DeleteRoom(roomID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, s := range r.sessions {
+		delete(s.markers, roomID)
+	}
+	return nil
+}
+
+// Cleanup removes sessions that have not been used within maxIdle
+func (r *InMemoryReadMarkerRepository) Cleanup(maxIdle time.Duration) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	cutoff := time.Now().Add(-maxIdle)
+	count := 0
+	for id, s := range r.sessions {
+		if s.lastSeen.Before(cutoff) {
+			delete(r.sessions, id)
+			count++
+		}
+	}
+	return count
+}
@@ -3,7 +3,11 @@ package persistence
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/redis/go-redis/v9"
@@ -12,11 +16,237 @@ import (
 	"voice-chat/internal/domain/repository"
 )
 
+// Each room keeps five keys that always share the room's TTL, so a room's chat expires as a
+// whole and no index outlives the messages it points to:
+//
+//	chat:room:<id>:messages      sorted set of message IDs scored by UnixNano timestamp
+//	chat:room:<id>:bodies        hash of message ID -> JSON message, as marshalled by Go
+//	chat:room:<id>:replies       sorted set of "<parentID>:<timestamp>:<replyID>" members, all
+//	                             with score 0, so a thread is a lexicographic range in send order
+//	chat:room:<id>:reply_counts  hash of message ID -> number of replies
+//	chat:room:<id>:reactions     hash of message ID -> JSON object of emoji -> user IDs
+//
+// Reply counts and reactions change inside Lua scripts, so they live apart from the bodies and
+// are merged in on read: cjson can't round-trip a Go-marshalled message (empty arrays become
+// objects, large integers lose precision), so scripts never re-encode a body.
+//
+// Before this layout each message was a chat:room:<id>:msg:<messageID> string and each thread
+// a chat:room:<id>:thread:<parentID> sorted set; MigrateLegacyMessages moves those over.
+
+// removeMessageLua defines remove_message for the scripts that drop messages: it deletes a
+// message with its reply count, reactions and thread, takes it out of its parent's thread and
+// updates the parent's reply count. KEYS are those of addMessageScript. The body is only read.
+const removeMessageLua = `
+local function remove_message(id)
+	local data = redis.call('HGET', KEYS[2], id)
+	redis.call('ZREM', KEYS[1], id)
+	redis.call('HDEL', KEYS[2], id)
+	redis.call('ZREMRANGEBYLEX', KEYS[3], '[' .. id .. ':', '(' .. id .. ';')
+	redis.call('HDEL', KEYS[4], id)
+	redis.call('HDEL', KEYS[5], id)
+	if not data then
+		return
+	end
+
+	local ok, msg = pcall(cjson.decode, data)
+	if not ok or type(msg.reply_to) ~= 'string' or msg.reply_to == '' then
+		return
+	end
+	local parent = msg.reply_to
+	local suffix = ':' .. id
+	for _, member in ipairs(redis.call('ZRANGEBYLEX', KEYS[3], '[' .. parent .. ':', '(' .. parent .. ';')) do
+		if string.sub(member, -#suffix) == suffix then
+			redis.call('ZREM', KEYS[3], member)
+		end
+	end
+	if redis.call('HEXISTS', KEYS[2], parent) == 1 then
+		local count = redis.call('ZLEXCOUNT', KEYS[3], '[' .. parent .. ':', '(' .. parent .. ';')
+		redis.call('HSET', KEYS[4], parent, count)
+	end
+end
+`
+
+// addMessageScript stores a message, trims the room to its cap, indexes replies and refreshes
+// the room's TTL in a single round trip.
+//
+//	KEYS    index, bodies, replies, reply counts, reactions
+//	ARGV    message ID, score, JSON message, max messages, TTL in milliseconds, parent ID (empty
+//	        for top-level messages), reply member
+var addMessageScript = redis.NewScript(removeMessageLua + `
+local id = ARGV[1]
+redis.call('HSET', KEYS[2], id, ARGV[3])
+redis.call('ZADD', KEYS[1], ARGV[2], id)
+
+local overflow = redis.call('ZRANGE', KEYS[1], 0, -tonumber(ARGV[4]) - 1)
+for _, old in ipairs(overflow) do
+	remove_message(old)
+end
+
+local parent = ARGV[6]
+if parent ~= '' then
+	redis.call('ZADD', KEYS[3], 0, ARGV[7])
+	if redis.call('HEXISTS', KEYS[2], parent) == 1 then
+		local count = redis.call('ZLEXCOUNT', KEYS[3], '[' .. parent .. ':', '(' .. parent .. ';')
+		redis.call('HSET', KEYS[4], parent, count)
+	end
+end
+
+for _, key in ipairs(KEYS) do
+	redis.call('PEXPIRE', key, ARGV[5])
+end
+return 0
+`)
+
+// removeMessagesScript drops messages the way trimming in addMessageScript does
+//
+//	KEYS    index, bodies, replies, reply counts, reactions
+//	ARGV    message IDs
+var removeMessagesScript = redis.NewScript(removeMessageLua + `
+for _, id in ipairs(ARGV) do
+	remove_message(id)
+end
+return 0
+`)
+
+// updateMessageScript replaces a message only if it is still stored and hasn't been deleted;
+// deleting a message also drops its reactions. It replies with 1 when the message was
+// replaced, 0 when it is gone and -1 when it was deleted. Like reactionScript, it only reads
+// the stored body.
+//
+//	KEYS    bodies, reactions
+//	ARGV    message ID, JSON message, "1" if the message was deleted
+var updateMessageScript = redis.NewScript(`
+local data = redis.call('HGET', KEYS[1], ARGV[1])
+if not data then
+	return 0
+end
+local stored = cjson.decode(data)
+if stored.deleted_at ~= nil and stored.deleted_at ~= cjson.null then
+	return -1
+end
+redis.call('HSET', KEYS[1], ARGV[1], ARGV[2])
+if ARGV[3] == '1' then
+	redis.call('HDEL', KEYS[2], ARGV[1])
+end
+return 1
+`)
+
+// reactionScript adds or removes a user's reaction inside Redis, so concurrent reactions to a
+// message are never lost to a read-modify-write race.
+//
+//	KEYS    bodies, reactions
+//	ARGV    message ID, emoji, user ID, max different emojis (0 = no cap), "add" or "remove"
+//
+// It replies with a status ("ok", "not_found", "deleted" or "too_many"), followed on success by
+// the users who reacted with the emoji. The body is only read; the reactions JSON holds nothing
+// but strings, so cjson round-trips it safely.
+var reactionScript = redis.NewScript(`
+local data = redis.call('HGET', KEYS[1], ARGV[1])
+if not data then
+	return {'not_found'}
+end
+
+local msg = cjson.decode(data)
+if msg.deleted_at ~= nil and msg.deleted_at ~= cjson.null then
+	return {'deleted'}
+end
+
+local reactions = {}
+local stored = redis.call('HGET', KEYS[2], ARGV[1])
+if stored then
+	reactions = cjson.decode(stored)
+end
+
+local emoji, user, maxEmojis = ARGV[2], ARGV[3], tonumber(ARGV[4])
+local users = reactions[emoji] or {}
+local index = nil
+for i, uid in ipairs(users) do
+	if uid == user then
+		index = i
+		break
+	end
+end
+
+local changed = false
+if ARGV[5] == 'add' then
+	if index == nil then
+		if reactions[emoji] == nil and maxEmojis > 0 then
+			local count = 0
+			for _ in pairs(reactions) do
+				count = count + 1
+			end
+			if count >= maxEmojis then
+				return {'too_many'}
+			end
+		end
+		table.insert(users, user)
+		reactions[emoji] = users
+		changed = true
+	end
+elseif index ~= nil then
+	table.remove(users, index)
+	if #users == 0 then
+		reactions[emoji] = nil
+	end
+	changed = true
+end
+
+if changed then
+	if next(reactions) == nil then
+		redis.call('HDEL', KEYS[2], ARGV[1])
+	else
+		redis.call('HSET', KEYS[2], ARGV[1], cjson.encode(reactions))
+		-- The hash may have just been created; give it the room's TTL
+		local ttl = redis.call('PTTL', KEYS[1])
+		if ttl > 0 then
+			redis.call('PEXPIRE', KEYS[2], ttl)
+		end
+	end
+end
+
+local reply = {'ok'}
+for _, uid in ipairs(users) do
+	table.insert(reply, uid)
+end
+return reply
+`)
+
+// messagesBeforeScript pages back from a message by its rank, so messages that share a
+// timestamp are neither skipped nor repeated
+//
+//	KEYS    index
+//	ARGV    cursor message ID, page size
+//
+// It replies with nil when the cursor message is gone, otherwise with up to page size + 1 IDs
+// (chronological order) sent before it; the extra ID tells whether older messages exist.
+var messagesBeforeScript = redis.NewScript(`
+local rank = redis.call('ZRANK', KEYS[1], ARGV[1])
+if not rank then
+	return false
+end
+if rank == 0 then
+	return {}
+end
+local start = rank - tonumber(ARGV[2]) - 1
+if start < 0 then
+	start = 0
+end
+return redis.call('ZRANGE', KEYS[1], start, rank - 1)
+`)
+
+// roomLimits overrides the default message cap and TTL for a room with its own retention
+type roomLimits struct {
+	maxMessages int
+	ttl         time.Duration
+}
+
 // RedisChatRepository is a Redis implementation of ChatRepository
 type RedisChatRepository struct {
 	client      *redis.Client
 	maxMessages int
-	ttl         time.Duration // TTL for room chat data
+	ttl         time.Duration         // TTL for room chat data
+	limits      map[string]roomLimits // roomID -> limits
+	limitsMu    sync.RWMutex
 }
 
 // NewRedisChatRepository creates a new RedisChatRepository
@@ -37,8 +267,9 @@ func NewRedisChatRepository(addr, password string, db int) (*RedisChatRepository
 
 	return &RedisChatRepository{
 		client:      client,
-		maxMessages: repository.DefaultChatHistoryLimit,
+		maxMessages: repository.DefaultChatRetention,
 		ttl:         24 * time.Hour, // Messages expire after 24 hours of inactivity
+		limits:      make(map[string]roomLimits),
 	}, nil
 }
 
@@ -47,14 +278,92 @@ func (r *RedisChatRepository) Close() error {
 	return r.client.Close()
 }
 
+// roomLimits returns the message cap and TTL that apply to a room
+func (r *RedisChatRepository) roomLimits(roomID string) (int, time.Duration) {
+	r.limitsMu.RLock()
+	defer r.limitsMu.RUnlock()
+
+	limits, exists := r.limits[roomID]
+	if !exists {
+		return r.maxMessages, r.ttl
+	}
+	return limits.maxMessages, limits.ttl
+}
+
 // roomKey returns the Redis key for a room's message list
 func (r *RedisChatRepository) roomKey(roomID string) string {
 	return fmt.Sprintf("chat:room:%s:messages", roomID)
 }
 
-// messageKey returns the Redis key for a specific message
-func (r *RedisChatRepository) messageKey(roomID, messageID string) string {
-	return fmt.Sprintf("chat:room:%s:msg:%s", roomID, messageID)
+// bodiesKey returns the Redis key for a room's message bodies
+func (r *RedisChatRepository) bodiesKey(roomID string) string {
+	return fmt.Sprintf("chat:room:%s:bodies", roomID)
+}
+
+// repliesKey returns the Redis key for a room's thread index
+func (r *RedisChatRepository) repliesKey(roomID string) string {
+	return fmt.Sprintf("chat:room:%s:replies", roomID)
+}
+
+// replyCountsKey returns the Redis key for a room's reply counts
+func (r *RedisChatRepository) replyCountsKey(roomID string) string {
+	return fmt.Sprintf("chat:room:%s:reply_counts", roomID)
+}
+
+// reactionsKey returns the Redis key for a room's reactions
+func (r *RedisChatRepository) reactionsKey(roomID string) string {
+	return fmt.Sprintf("chat:room:%s:reactions", roomID)
+}
+
+// roomKeys returns every key holding a room's chat, in the order the scripts expect
+func (r *RedisChatRepository) roomKeys(roomID string) []string {
+	return []string{
+		r.roomKey(roomID),
+		r.bodiesKey(roomID),
+		r.repliesKey(roomID),
+		r.replyCountsKey(roomID),
+		r.reactionsKey(roomID),
+	}
+}
+
+// encodeBody marshals a message without its reply count and reactions, which are kept in
+// their own hashes
+func encodeBody(message *entity.ChatMessage) ([]byte, error) {
+	body := *message
+	body.ReplyCount = 0
+	body.Reactions = nil
+	return json.Marshal(&body)
+}
+
+// decodeMessage unmarshals a body and merges in its reply count and reactions; either may be
+// nil when the message has none
+func decodeMessage(body string, replyCount, reactions interface{}) (*entity.ChatMessage, error) {
+	var msg entity.ChatMessage
+	if err := json.Unmarshal([]byte(body), &msg); err != nil {
+		return nil, err
+	}
+
+	if count, ok := replyCount.(string); ok {
+		msg.ReplyCount, _ = strconv.Atoi(count)
+	}
+	msg.Reactions = make(map[string][]string)
+	if data, ok := reactions.(string); ok {
+		if err := json.Unmarshal([]byte(data), &msg.Reactions); err != nil {
+			return nil, err
+		}
+	}
+	return &msg, nil
+}
+
+// replyMember returns a reply's member in the thread index; zero-padding the timestamp keeps
+// the lexicographic order chronological
+func replyMember(parentID string, timestamp time.Time, replyID string) string {
+	return fmt.Sprintf("%s:%020d:%s", parentID, timestamp.UnixNano(), replyID)
+}
+
+// threadRange returns the lexicographic bounds of a message's replies in the thread index
+func threadRange(parentID string) (string, string) {
+	return "[" + parentID + ":", "(" + parentID + ";"
 }
 
 // AddMessage stores a new chat message for a room
@@ -62,33 +371,21 @@ func (r *RedisChatRepository) AddMessage(message *entity.ChatMessage) error {
 	ctx := context.Background()
 
 	// Serialize message
-	data, err := json.Marshal(message)
+	data, err := encodeBody(message)
 	if err != nil {
 		return fmt.Errorf("failed to marshal message: %w", err)
 	}
 
-	roomKey := r.roomKey(message.RoomID)
-	msgKey := r.messageKey(message.RoomID, message.ID)
+	maxMessages, ttl := r.roomLimits(message.RoomID)
 
-	// Use pipeline for atomic operations
-	pipe := r.client.Pipeline()
-
-	// Store message data
-	pipe.Set(ctx, msgKey, data, r.ttl)
-
-	// Add message ID to room's list (sorted by timestamp)
-	pipe.ZAdd(ctx, roomKey, redis.Z{
-		Score:  float64(message.Timestamp.UnixNano()),
-		Member: message.ID,
-	})
-
-	// Trim to max messages (remove oldest)
-	pipe.ZRemRangeByRank(ctx, roomKey, 0, int64(-r.maxMessages-1))
-
-	// Refresh TTL on room key
-	pipe.Expire(ctx, roomKey, r.ttl)
+	var parentID, member string
+	if message.IsReply() {
+		parentID = message.ReplyTo
+		member = replyMember(parentID, message.Timestamp, message.ID)
+	}
 
-	_, err = pipe.Exec(ctx)
+	err = addMessageScript.Run(ctx, r.client, r.roomKeys(message.RoomID),
+		message.ID, message.Timestamp.UnixNano(), data, maxMessages, ttl.Milliseconds(), parentID, member).Err()
 	if err != nil {
 		return fmt.Errorf("failed to add message: %w", err)
 	}
@@ -113,27 +410,157 @@ func (r *RedisChatRepository) GetMessages(roomID string, limit int) ([]*entity.C
 		return nil, fmt.Errorf("failed to get message IDs: %w", err)
 	}
 
-	if len(messageIDs) == 0 {
-		return []*entity.ChatMessage{}, nil
+	return r.loadMessages(ctx, roomID, roomKey, messageIDs, messageIDs)
+}
+
+// GetMessagesBefore retrieves up to limit messages older than the cursor (chronological order)
+func (r *RedisChatRepository) GetMessagesBefore(roomID string, cursor repository.ChatHistoryCursor, limit int) ([]*entity.ChatMessage, bool, error) {
+	ctx := context.Background()
+
+	if limit <= 0 {
+		limit = r.maxMessages
 	}
 
-	// Fetch all messages
-	messages := make([]*entity.ChatMessage, 0, len(messageIDs))
-	for _, msgID := range messageIDs {
-		msgKey := r.messageKey(roomID, msgID)
-		data, err := r.client.Get(ctx, msgKey).Bytes()
+	roomKey := r.roomKey(roomID)
+
+	var messageIDs []string
+	if cursor.BeforeID != "" {
+		ids, err := messagesBeforeScript.Run(ctx, r.client, []string{roomKey}, cursor.BeforeID, limit).StringSlice()
 		if err == redis.Nil {
-			continue // Message expired or deleted
+			return nil, false, ErrMessageNotFound
 		}
 		if err != nil {
-			return nil, fmt.Errorf("failed to get message %s: %w", msgID, err)
+			return nil, false, fmt.Errorf("failed to get message IDs: %w", err)
+		}
+		messageIDs = ids
+	} else {
+		// Scores are UnixNano timestamps; a time cursor is an exclusive bound
+		upper := "+inf"
+		if !cursor.Before.IsZero() {
+			upper = "(" + strconv.FormatInt(cursor.Before.UnixNano(), 10)
+		}
+
+		// Fetch one extra ID to learn whether older messages exist
+		ids, err := r.client.ZRevRangeByScore(ctx, roomKey, &redis.ZRangeBy{
+			Min:   "-inf",
+			Max:   upper,
+			Count: int64(limit + 1),
+		}).Result()
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to get message IDs: %w", err)
+		}
+
+		// Newest first from Redis, callers expect chronological order
+		for i, j := 0, len(ids)-1; i < j; i, j = i+1, j-1 {
+			ids[i], ids[j] = ids[j], ids[i]
+		}
+		messageIDs = ids
+	}
+
+	// The extra ID, if any, is the oldest one
+	hasMore := len(messageIDs) > limit
+	if hasMore {
+		messageIDs = messageIDs[1:]
+	}
+
+	messages, err := r.loadMessages(ctx, roomID, roomKey, messageIDs, messageIDs)
+	if err != nil {
+		return nil, false, err
+	}
+	return messages, hasMore, nil
+}
+
+// CountMessagesAfter counts messages newer than messageID; an empty or expired ID counts every message
+func (r *RedisChatRepository) CountMessagesAfter(roomID, messageID string) (int, error) {
+	ctx := context.Background()
+	roomKey := r.roomKey(roomID)
+
+	lower := "-inf"
+	if messageID != "" {
+		score, err := r.client.ZScore(ctx, roomKey, messageID).Result()
+		if err != nil && err != redis.Nil {
+			return 0, fmt.Errorf("failed to get read marker message: %w", err)
+		}
+		if err == nil {
+			lower = "(" + strconv.FormatFloat(score, 'f', -1, 64)
 		}
+	}
+
+	count, err := r.client.ZCount(ctx, roomKey, lower, "+inf").Result()
+	if err != nil {
+		return 0, fmt.Errorf("failed to count messages: %w", err)
+	}
+	return int(count), nil
+}
+
+// GetReplies retrieves up to limit of the newest replies to a message (chronological order)
+func (r *RedisChatRepository) GetReplies(roomID, parentID string, limit int) ([]*entity.ChatMessage, error) {
+	ctx := context.Background()
+
+	if limit <= 0 {
+		limit = r.maxMessages
+	}
+
+	repliesKey := r.repliesKey(roomID)
+	lower, upper := threadRange(parentID)
+	members, err := r.client.ZRevRangeByLex(ctx, repliesKey, &redis.ZRangeBy{
+		Min:   lower,
+		Max:   upper,
+		Count: int64(limit),
+	}).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reply IDs: %w", err)
+	}
+
+	// Newest first from Redis, callers expect chronological order
+	for i, j := 0, len(members)-1; i < j; i, j = i+1, j-1 {
+		members[i], members[j] = members[j], members[i]
+	}
+
+	messageIDs := make([]string, len(members))
+	for i, member := range members {
+		messageIDs[i] = member[strings.LastIndexByte(member, ':')+1:]
+	}
+
+	return r.loadMessages(ctx, roomID, repliesKey, messageIDs, members)
+}
 
-		var msg entity.ChatMessage
-		if err := json.Unmarshal(data, &msg); err != nil {
+// loadMessages fetches message bodies in the given order, with their reply counts and
+// reactions, in a single pipelined round trip, skipping corrupted ones. IDs whose message is
+// gone are pruned from the index they were read from; members holds each ID's member in that
+// index.
+func (r *RedisChatRepository) loadMessages(ctx context.Context, roomID, indexKey string, messageIDs, members []string) ([]*entity.ChatMessage, error) {
+	messages := make([]*entity.ChatMessage, 0, len(messageIDs))
+	if len(messageIDs) == 0 {
+		return messages, nil
+	}
+
+	pipe := r.client.Pipeline()
+	bodies := pipe.HMGet(ctx, r.bodiesKey(roomID), messageIDs...)
+	replyCounts := pipe.HMGet(ctx, r.replyCountsKey(roomID), messageIDs...)
+	reactions := pipe.HMGet(ctx, r.reactionsKey(roomID), messageIDs...)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return nil, fmt.Errorf("failed to get messages: %w", err)
+	}
+
+	var dangling []interface{}
+	for i, value := range bodies.Val() {
+		data, ok := value.(string)
+		if !ok {
+			dangling = append(dangling, members[i]) // Trimmed, pruned or left over from an older layout
+			continue
+		}
+
+		msg, err := decodeMessage(data, replyCounts.Val()[i], reactions.Val()[i])
+		if err != nil {
 			continue // Skip corrupted messages
 		}
-		messages = append(messages, &msg)
+		messages = append(messages, msg)
+	}
+
+	// Best effort: a failed cleanup is retried on the next load
+	if len(dangling) > 0 {
+		r.client.ZRem(ctx, indexKey, dangling...)
 	}
 
 	return messages, nil
@@ -141,87 +568,417 @@ func (r *RedisChatRepository) GetMessages(roomID string, limit int) ([]*entity.C
 
 // GetMessage retrieves a specific message by ID
 func (r *RedisChatRepository) GetMessage(roomID, messageID string) (*entity.ChatMessage, error) {
-	ctx := context.Background()
+	return r.getMessage(context.Background(), r.client, roomID, messageID)
+}
 
-	msgKey := r.messageKey(roomID, messageID)
-	data, err := r.client.Get(ctx, msgKey).Bytes()
-	if err == redis.Nil {
-		return nil, ErrMessageNotFound
-	}
-	if err != nil {
+// getMessage reads a message with its reply count and reactions through c, which may be a
+// transaction watching the bodies
+func (r *RedisChatRepository) getMessage(ctx context.Context, c redis.Cmdable, roomID, messageID string) (*entity.ChatMessage, error) {
+	pipe := c.Pipeline()
+	body := pipe.HGet(ctx, r.bodiesKey(roomID), messageID)
+	replyCount := pipe.HGet(ctx, r.replyCountsKey(roomID), messageID)
+	reactions := pipe.HGet(ctx, r.reactionsKey(roomID), messageID)
+	if _, err := pipe.Exec(ctx); err != nil && err != redis.Nil {
 		return nil, fmt.Errorf("failed to get message: %w", err)
 	}
+	if body.Err() == redis.Nil {
+		return nil, ErrMessageNotFound
+	}
 
-	var msg entity.ChatMessage
-	if err := json.Unmarshal(data, &msg); err != nil {
+	msg, err := decodeMessage(body.Val(), optionalValue(replyCount), optionalValue(reactions))
+	if err != nil {
 		return nil, fmt.Errorf("failed to unmarshal message: %w", err)
 	}
 
-	return &msg, nil
+	return msg, nil
 }
 
-// UpdateMessage updates a message (for reactions)
+// optionalValue returns a hash field's value, or nil when the field doesn't exist
+func optionalValue(cmd *redis.StringCmd) interface{} {
+	if cmd.Err() != nil {
+		return nil
+	}
+	return cmd.Val()
+}
+
+// UpdateMessage replaces a stored message; the room's TTL is left as it is
 func (r *RedisChatRepository) UpdateMessage(message *entity.ChatMessage) error {
 	ctx := context.Background()
 
-	msgKey := r.messageKey(message.RoomID, message.ID)
+	// Serialize and update; reactions are changed through AddReaction and RemoveReaction only
+	data, err := encodeBody(message)
+	if err != nil {
+		return fmt.Errorf("failed to marshal message: %w", err)
+	}
 
-	// Check if message exists
-	exists, err := r.client.Exists(ctx, msgKey).Result()
+	deleted := "0"
+	if message.IsDeleted() {
+		deleted = "1"
+	}
+
+	keys := []string{r.bodiesKey(message.RoomID), r.reactionsKey(message.RoomID)}
+	updated, err := updateMessageScript.Run(ctx, r.client, keys, message.ID, data, deleted).Int()
 	if err != nil {
-		return fmt.Errorf("failed to check message existence: %w", err)
+		return fmt.Errorf("failed to update message: %w", err)
 	}
-	if exists == 0 {
+	switch updated {
+	case 0:
 		return ErrMessageNotFound
+	case -1:
+		return entity.ErrMessageDeleted
+	}
+
+	return nil
+}
+
+// maxUpdateRetries bounds how often a message update is retried when a concurrent write wins the race
+const maxUpdateRetries = 10
+
+// EditMessage edits a message in a transaction, so it can't land on a message deleted meanwhile
+func (r *RedisChatRepository) EditMessage(roomID, messageID, userID, content string, window time.Duration, now time.Time) (*entity.ChatMessage, error) {
+	return r.updateMessage(roomID, messageID, func(msg *entity.ChatMessage) error {
+		if err := msg.CheckEdit(userID, window, now); err != nil {
+			return err
+		}
+		msg.Edit(content, now)
+		return nil
+	})
+}
+
+// DeleteMessage turns a message into a tombstone in a transaction, dropping its reactions
+func (r *RedisChatRepository) DeleteMessage(roomID, messageID, userID string, moderator bool, now time.Time) (*entity.ChatMessage, error) {
+	var original *entity.ChatMessage
+	_, err := r.updateMessage(roomID, messageID, func(msg *entity.ChatMessage) error {
+		if err := msg.CheckDelete(userID, moderator); err != nil {
+			return err
+		}
+		original = msg.Clone()
+		msg.Delete(userID, now)
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
+	return original, nil
+}
+
+// AddReaction adds a user's reaction atomically in Redis
+func (r *RedisChatRepository) AddReaction(roomID, messageID, emoji, userID string, maxEmojis int) ([]string, error) {
+	return r.react(roomID, messageID, emoji, userID, maxEmojis, "add")
+}
+
+// RemoveReaction removes a user's reaction atomically in Redis
+func (r *RedisChatRepository) RemoveReaction(roomID, messageID, emoji, userID string) ([]string, error) {
+	return r.react(roomID, messageID, emoji, userID, 0, "remove")
+}
+
+// react runs the reaction script and interprets its reply
+func (r *RedisChatRepository) react(roomID, messageID, emoji, userID string, maxEmojis int, op string) ([]string, error) {
+	ctx := context.Background()
 
-	// Serialize and update
-	data, err := json.Marshal(message)
+	keys := []string{r.bodiesKey(roomID), r.reactionsKey(roomID)}
+	reply, err := reactionScript.Run(ctx, r.client, keys, messageID, emoji, userID, maxEmojis, op).StringSlice()
 	if err != nil {
-		return fmt.Errorf("failed to marshal message: %w", err)
+		return nil, fmt.Errorf("failed to update reaction: %w", err)
+	}
+	if len(reply) == 0 {
+		return nil, fmt.Errorf("failed to update reaction: empty reply")
 	}
 
-	// Keep existing TTL
-	ttl, err := r.client.TTL(ctx, msgKey).Result()
-	if err != nil || ttl < 0 {
-		ttl = r.ttl
+	switch reply[0] {
+	case "ok":
+		return reply[1:], nil
+	case "not_found":
+		return nil, ErrMessageNotFound
+	case "deleted":
+		return nil, entity.ErrReactionOnDeleted
+	case "too_many":
+		return nil, entity.ErrTooManyReactions
+	default:
+		return nil, fmt.Errorf("failed to update reaction: unexpected reply %q", reply[0])
 	}
+}
 
-	err = r.client.Set(ctx, msgKey, data, ttl).Err()
+// VotePoll records a user's choices on a poll
+func (r *RedisChatRepository) VotePoll(roomID, messageID, userID string, choices []int, now time.Time) (*entity.Poll, error) {
+	msg, err := r.updateMessage(roomID, messageID, func(msg *entity.ChatMessage) error {
+		if msg.Poll == nil || msg.IsDeleted() {
+			return entity.ErrNotAPoll
+		}
+		return msg.Poll.Vote(userID, choices, now)
+	})
 	if err != nil {
-		return fmt.Errorf("failed to update message: %w", err)
+		return nil, err
 	}
+	return msg.Poll, nil
+}
 
-	return nil
+// ClosePoll closes a poll
+func (r *RedisChatRepository) ClosePoll(roomID, messageID string, now time.Time) (*entity.Poll, bool, error) {
+	closed := false
+	msg, err := r.updateMessage(roomID, messageID, func(msg *entity.ChatMessage) error {
+		if msg.Poll == nil || msg.IsDeleted() {
+			return entity.ErrNotAPoll
+		}
+		if !msg.Poll.Close(now) {
+			return errUnchanged
+		}
+		closed = true
+		return nil
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	return msg.Poll, closed, nil
 }
 
-// DeleteRoomMessages deletes all messages for a room
-func (r *RedisChatRepository) DeleteRoomMessages(roomID string) error {
+// errUnchanged tells updateMessage that fn left the message as it was, so nothing is written
+var errUnchanged = errors.New("message unchanged")
+
+// updateMessage applies fn to a message in a WATCH/MULTI transaction, retrying when another
+// write to the room's messages got in between so no change is lost and none lands on a message
+// deleted meanwhile. It returns the message as fn left it; a deleted message's reactions are
+// dropped with it.
+func (r *RedisChatRepository) updateMessage(roomID, messageID string, fn func(msg *entity.ChatMessage) error) (*entity.ChatMessage, error) {
 	ctx := context.Background()
+	bodiesKey := r.bodiesKey(roomID)
 
-	roomKey := r.roomKey(roomID)
+	var result *entity.ChatMessage
+	txf := func(tx *redis.Tx) error {
+		msg, err := r.getMessage(ctx, tx, roomID, messageID)
+		if err != nil {
+			return err
+		}
+
+		err = fn(msg)
+		result = msg
+		if err == errUnchanged {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		data, err := encodeBody(msg)
+		if err != nil {
+			return fmt.Errorf("failed to marshal message: %w", err)
+		}
+		_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			pipe.HSet(ctx, bodiesKey, messageID, data)
+			if msg.IsDeleted() {
+				pipe.HDel(ctx, r.reactionsKey(roomID), messageID)
+			}
+			return nil
+		})
+		return err
+	}
 
-	// Get all message IDs
-	messageIDs, err := r.client.ZRange(ctx, roomKey, 0, -1).Result()
+	for i := 0; i < maxUpdateRetries; i++ {
+		err := r.client.Watch(ctx, txf, bodiesKey)
+		if errors.Is(err, redis.TxFailedErr) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		return result, nil
+	}
+	return nil, fmt.Errorf("failed to update message: too much contention")
+}
+
+// SetRoomLimits overrides a room's message cap and TTL, trimming the room and moving its
+// stored chat to the new TTL right away
+func (r *RedisChatRepository) SetRoomLimits(roomID string, maxMessages int, ttl time.Duration) error {
+	r.limitsMu.Lock()
+	if maxMessages <= 0 && ttl <= 0 {
+		delete(r.limits, roomID)
+	} else {
+		if maxMessages <= 0 {
+			maxMessages = r.maxMessages
+		}
+		if ttl <= 0 {
+			ttl = r.ttl
+		}
+		r.limits[roomID] = roomLimits{maxMessages: maxMessages, ttl: ttl}
+	}
+	r.limitsMu.Unlock()
+
+	maxMessages, ttl = r.roomLimits(roomID)
+	ctx := context.Background()
+
+	trimmed, err := r.client.ZRange(ctx, r.roomKey(roomID), 0, int64(-maxMessages-1)).Result()
 	if err != nil {
 		return fmt.Errorf("failed to get message IDs: %w", err)
 	}
+	if err := r.removeMessages(ctx, roomID, trimmed); err != nil {
+		return err
+	}
+
+	pipe := r.client.Pipeline()
+	for _, key := range r.roomKeys(roomID) {
+		pipe.Expire(ctx, key, ttl)
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to set room TTL: %w", err)
+	}
+	return nil
+}
+
+// PruneMessagesBefore deletes a room's messages sent before the cutoff
+func (r *RedisChatRepository) PruneMessagesBefore(roomID string, before time.Time) (int, error) {
+	ctx := context.Background()
+
+	// Scores are UnixNano timestamps; the cutoff is exclusive
+	messageIDs, err := r.client.ZRangeByScore(ctx, r.roomKey(roomID), &redis.ZRangeBy{
+		Min: "-inf",
+		Max: "(" + strconv.FormatInt(before.UnixNano(), 10),
+	}).Result()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get message IDs: %w", err)
+	}
+	if err := r.removeMessages(ctx, roomID, messageIDs); err != nil {
+		return 0, err
+	}
+	return len(messageIDs), nil
+}
 
+// removeMessages deletes messages, their threads and their place in the room's list and in
+// their parents' threads, in one script so readers never see half of it
+func (r *RedisChatRepository) removeMessages(ctx context.Context, roomID string, messageIDs []string) error {
 	if len(messageIDs) == 0 {
 		return nil
 	}
 
-	// Delete all message keys and the room key
-	keys := make([]string, 0, len(messageIDs)+1)
-	keys = append(keys, roomKey)
-	for _, msgID := range messageIDs {
-		keys = append(keys, r.messageKey(roomID, msgID))
+	args := make([]interface{}, len(messageIDs))
+	for i, msgID := range messageIDs {
+		args[i] = msgID
 	}
 
-	err = r.client.Del(ctx, keys...).Err()
-	if err != nil {
+	if err := removeMessagesScript.Run(ctx, r.client, r.roomKeys(roomID), args...).Err(); err != nil {
+		return fmt.Errorf("failed to remove messages: %w", err)
+	}
+	return nil
+}
+
+// DeleteRoomMessages deletes all messages for a room
+func (r *RedisChatRepository) DeleteRoomMessages(roomID string) error {
+	ctx := context.Background()
+
+	r.limitsMu.Lock()
+	delete(r.limits, roomID)
+	r.limitsMu.Unlock()
+
+	if err := r.client.Del(ctx, r.roomKeys(roomID)...).Err(); err != nil {
 		return fmt.Errorf("failed to delete room messages: %w", err)
 	}
 
 	return nil
 }
+
+// legacyScanBatch is how many keys each SCAN step asks for while migrating
+const legacyScanBatch = 500
+
+// MigrateLegacyMessages moves messages stored in the old per-message layout into the room
+// hashes and deletes the old keys, reporting how many messages were moved. Messages already
+// trimmed from their room's list are dropped, and threads are rebuilt from the replies. It is
+// a no-op once nothing is left to move, so it can run on every start.
+func (r *RedisChatRepository) MigrateLegacyMessages() (int, error) {
+	ctx := context.Background()
+
+	migrated := 0
+	rooms := make(map[string]bool)
+	iter := r.client.Scan(ctx, 0, "chat:room:*:msg:*", legacyScanBatch).Iterator()
+	for iter.Next(ctx) {
+		roomID, err := r.migrateLegacyMessage(ctx, iter.Val())
+		if err != nil {
+			return migrated, err
+		}
+		if roomID != "" {
+			rooms[roomID] = true
+			migrated++
+		}
+	}
+	if err := iter.Err(); err != nil {
+		return migrated, fmt.Errorf("failed to scan legacy messages: %w", err)
+	}
+
+	iter = r.client.Scan(ctx, 0, "chat:room:*:thread:*", legacyScanBatch).Iterator()
+	for iter.Next(ctx) {
+		if err := r.client.Del(ctx, iter.Val()).Err(); err != nil {
+			return migrated, fmt.Errorf("failed to delete legacy thread: %w", err)
+		}
+	}
+	if err := iter.Err(); err != nil {
+		return migrated, fmt.Errorf("failed to scan legacy threads: %w", err)
+	}
+
+	// The room's list kept its TTL; the new keys expire along with it
+	for roomID := range rooms {
+		ttl, err := r.client.PTTL(ctx, r.roomKey(roomID)).Result()
+		if err != nil {
+			return migrated, fmt.Errorf("failed to get room TTL: %w", err)
+		}
+		if ttl <= 0 {
+			continue
+		}
+		pipe := r.client.Pipeline()
+		for _, key := range r.roomKeys(roomID) {
+			pipe.PExpire(ctx, key, ttl)
+		}
+		if _, err := pipe.Exec(ctx); err != nil {
+			return migrated, fmt.Errorf("failed to set room TTL: %w", err)
+		}
+	}
+
+	return migrated, nil
+}
+
+// migrateLegacyMessage moves one chat:room:<id>:msg:<messageID> key into the room hashes and
+// deletes it, returning the message's room ID, or "" when the message was dropped
+func (r *RedisChatRepository) migrateLegacyMessage(ctx context.Context, key string) (string, error) {
+	data, err := r.client.Get(ctx, key).Bytes()
+	if err == redis.Nil {
+		return "", nil // Expired since the scan
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get legacy message: %w", err)
+	}
+
+	var msg entity.ChatMessage
+	if err := json.Unmarshal(data, &msg); err != nil {
+		return "", r.client.Del(ctx, key).Err() // Corrupted messages were skipped on load anyway
+	}
+
+	if err := r.client.ZScore(ctx, r.roomKey(msg.RoomID), msg.ID).Err(); err == redis.Nil {
+		return "", r.client.Del(ctx, key).Err()
+	} else if err != nil {
+		return "", fmt.Errorf("failed to get legacy message score: %w", err)
+	}
+
+	body, err := encodeBody(&msg)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal message: %w", err)
+	}
+
+	// HSETNX leaves anything written in the new layout since the upgrade alone
+	pipe := r.client.TxPipeline()
+	pipe.HSetNX(ctx, r.bodiesKey(msg.RoomID), msg.ID, body)
+	if msg.ReplyCount > 0 {
+		pipe.HSetNX(ctx, r.replyCountsKey(msg.RoomID), msg.ID, msg.ReplyCount)
+	}
+	if len(msg.Reactions) > 0 {
+		reactions, err := json.Marshal(msg.Reactions)
+		if err != nil {
+			return "", fmt.Errorf("failed to marshal reactions: %w", err)
+		}
+		pipe.HSetNX(ctx, r.reactionsKey(msg.RoomID), msg.ID, reactions)
+	}
+	if msg.IsReply() {
+		pipe.ZAdd(ctx, r.repliesKey(msg.RoomID), redis.Z{Member: replyMember(msg.ReplyTo, msg.Timestamp, msg.ID)})
+	}
+	pipe.Del(ctx, key)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return "", fmt.Errorf("failed to migrate message: %w", err)
+	}
+	return msg.RoomID, nil
+}
@@ -0,0 +1,273 @@
+package persistence
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/stretchr/testify/assert"
+
+	"voice-chat/internal/domain/entity"
+)
+
+func newTestRedisChatRepository(t *testing.T) (*RedisChatRepository, *miniredis.Miniredis) {
+	server := miniredis.RunT(t)
+	repo, err := NewRedisChatRepository(server.Addr(), "", 0)
+	assert.NoError(t, err)
+	t.Cleanup(func() { repo.Close() })
+	return repo, server
+}
+
+func TestRedisChatRepository_AddAndGetMessages(t *testing.T) {
+	repo, _ := newTestRedisChatRepository(t)
+	t0 := time.Date(2026, 6, 1, 18, 0, 0, 0, time.UTC)
+	addTestMessages(t, repo, "room-1", t0, t0.Add(time.Second), t0.Add(2*time.Second))
+
+	messages, err := repo.GetMessages("room-1", 2)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"msg-2", "msg-3"}, idsOf(messages))
+	assert.Equal(t, "message 2", messages[0].Content)
+	assert.NotNil(t, messages[0].Reactions)
+
+	messages, _ = repo.GetMessages("room-2", 10)
+	assert.Empty(t, messages)
+
+	// The room keeps only its cap of messages
+	assert.NoError(t, repo.SetRoomLimits("room-1", 2, 0))
+	messages, _ = repo.GetMessages("room-1", 10)
+	assert.Equal(t, []string{"msg-2", "msg-3"}, idsOf(messages))
+	_, err = repo.GetMessage("room-1", "msg-1")
+	assert.ErrorIs(t, err, ErrMessageNotFound)
+}
+
+func TestRedisChatRepository_GetMessagesBefore(t *testing.T) {
+	repo, _ := newTestRedisChatRepository(t)
+	assertGetMessagesBefore(t, repo)
+}
+
+func TestRedisChatRepository_EditAndDelete(t *testing.T) {
+	repo, _ := newTestRedisChatRepository(t)
+	assertEditAndDelete(t, repo)
+}
+
+func TestRedisChatRepository_RepliesAndReactionsKeepTheBody(t *testing.T) {
+	repo, _ := newTestRedisChatRepository(t)
+
+	// Values cjson can't round-trip: an integer past 2^53 and an empty list of choices
+	poll, err := entity.NewPoll("Map?", []string{"Dust", "Mirage"}, true, false, 0, time.Now())
+	assert.NoError(t, err)
+	poll.Votes["user-2"] = []int{}
+	parent := entity.NewPollMessage("msg-1", "room-1", "user-1", "Alice", poll)
+	parent.Attachments = []*entity.Attachment{{ID: "file-1", RoomID: "room-1", FileName: "demo.dem", Size: 1<<53 + 1}}
+	assert.NoError(t, repo.AddMessage(parent))
+
+	for _, id := range []string{"reply-1", "reply-2"} {
+		reply := entity.NewChatMessage(id, "room-1", "user-2", "Bob", "Dust")
+		reply.ReplyTo = "msg-1"
+		assert.NoError(t, repo.AddMessage(reply))
+	}
+
+	users, err := repo.AddReaction("room-1", "msg-1", "👍", "user-2", 0)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"user-2"}, users)
+	users, _ = repo.AddReaction("room-1", "msg-1", "👍", "user-3", 0)
+	assert.Equal(t, []string{"user-2", "user-3"}, users)
+	_, err = repo.AddReaction("room-1", "msg-1", "🔥", "user-2", 1)
+	assert.ErrorIs(t, err, entity.ErrTooManyReactions)
+
+	stored, err := repo.GetMessage("room-1", "msg-1")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, stored.ReplyCount)
+	assert.Equal(t, map[string][]string{"👍": {"user-2", "user-3"}}, stored.Reactions)
+	assert.Equal(t, int64(1<<53+1), stored.Attachments[0].Size)
+	assert.Equal(t, []int{}, stored.Poll.Votes["user-2"])
+
+	data, _ := json.Marshal(stored.Poll)
+	assert.Contains(t, string(data), `"user-2":[]`)
+
+	replies, err := repo.GetReplies("room-1", "msg-1", 10)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"reply-1", "reply-2"}, idsOf(replies))
+
+	// History carries the merged fields too
+	messages, _ := repo.GetMessages("room-1", 10)
+	assert.Equal(t, 2, messages[0].ReplyCount)
+	assert.Len(t, messages[0].Reactions["👍"], 2)
+
+	// Edits don't overwrite reactions made meanwhile
+	stale, _ := repo.GetMessage("room-1", "msg-1")
+	repo.RemoveReaction("room-1", "msg-1", "👍", "user-3")
+	stale.Edit("Map? (final)", time.Now())
+	assert.NoError(t, repo.UpdateMessage(stale))
+	stored, _ = repo.GetMessage("room-1", "msg-1")
+	assert.Equal(t, map[string][]string{"👍": {"user-2"}}, stored.Reactions)
+	assert.Equal(t, 2, stored.ReplyCount)
+
+	// Deleting drops the reactions and refuses new ones
+	stored.Delete("user-1", time.Now())
+	assert.NoError(t, repo.UpdateMessage(stored))
+	stored, _ = repo.GetMessage("room-1", "msg-1")
+	assert.Empty(t, stored.Reactions)
+	_, err = repo.AddReaction("room-1", "msg-1", "👍", "user-2", 0)
+	assert.ErrorIs(t, err, entity.ErrReactionOnDeleted)
+
+	_, err = repo.AddReaction("room-1", "missing", "👍", "user-2", 0)
+	assert.ErrorIs(t, err, ErrMessageNotFound)
+	assert.ErrorIs(t, repo.UpdateMessage(entity.NewChatMessage("missing", "room-1", "user-1", "Alice", "hi")), ErrMessageNotFound)
+}
+
+func TestRedisChatRepository_PrunesDanglingIDs(t *testing.T) {
+	repo, server := newTestRedisChatRepository(t)
+	t0 := time.Date(2026, 6, 1, 18, 0, 0, 0, time.UTC)
+	addTestMessages(t, repo, "room-1", t0, t0.Add(time.Second), t0.Add(2*time.Second))
+	reply := entity.NewChatMessage("reply-1", "room-1", "user-2", "Bob", "hi")
+	reply.ReplyTo = "msg-1"
+	assert.NoError(t, repo.AddMessage(reply))
+
+	// Bodies lost outside the repository leave their IDs behind in the indexes
+	server.HDel(repo.bodiesKey("room-1"), "msg-2")
+	server.HDel(repo.bodiesKey("room-1"), "reply-1")
+
+	messages, err := repo.GetMessages("room-1", 10)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"msg-1", "msg-3"}, idsOf(messages))
+	members, _ := server.ZMembers(repo.roomKey("room-1"))
+	assert.NotContains(t, members, "msg-2")
+
+	replies, err := repo.GetReplies("room-1", "msg-1", 10)
+	assert.NoError(t, err)
+	assert.Empty(t, replies)
+	assert.False(t, server.Exists(repo.repliesKey("room-1")))
+
+	// Pruned and deleted rooms leave nothing behind
+	count, err := repo.PruneMessagesBefore("room-1", t0.Add(2*time.Second))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+	messages, _ = repo.GetMessages("room-1", 10)
+	assert.Equal(t, []string{"msg-3"}, idsOf(messages))
+
+	assert.NoError(t, repo.DeleteRoomMessages("room-1"))
+	assert.Empty(t, server.Keys())
+}
+
+func TestRedisChatRepository_RemovedRepliesLeaveTheirThread(t *testing.T) {
+	repo, server := newTestRedisChatRepository(t)
+	t0 := time.Date(2026, 6, 1, 18, 0, 0, 0, time.UTC)
+	addTestMessages(t, repo, "room-1", t0.Add(2*time.Second))
+
+	// Replies stamped before their parent (clock skew) go before it
+	for i, at := range []time.Time{t0, t0.Add(time.Second), t0.Add(3 * time.Second)} {
+		reply := entity.NewChatMessage(fmt.Sprintf("reply-%d", i+1), "room-1", "user-2", "Bob", "hi")
+		reply.ReplyTo = "msg-1"
+		reply.Timestamp = at
+		assert.NoError(t, repo.AddMessage(reply))
+	}
+	assert.Equal(t, "3", server.HGet(repo.replyCountsKey("room-1"), "msg-1"))
+
+	// Pruning a reply takes it out of the thread and the parent's count right away
+	count, err := repo.PruneMessagesBefore("room-1", t0.Add(time.Second/2))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+	assert.Equal(t, "2", server.HGet(repo.replyCountsKey("room-1"), "msg-1"))
+	assert.Len(t, mustMembers(t, server, repo.repliesKey("room-1")), 2)
+
+	// So does trimming to the cap
+	assert.NoError(t, repo.SetRoomLimits("room-1", 2, 0))
+	assert.Equal(t, "1", server.HGet(repo.replyCountsKey("room-1"), "msg-1"))
+	members := mustMembers(t, server, repo.repliesKey("room-1"))
+	assert.Len(t, members, 1)
+	assert.True(t, strings.HasSuffix(members[0], ":reply-3"))
+
+	parent, err := repo.GetMessage("room-1", "msg-1")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, parent.ReplyCount)
+}
+
+func TestRedisChatRepository_TTLAlignment(t *testing.T) {
+	repo, server := newTestRedisChatRepository(t)
+
+	parent := entity.NewChatMessage("msg-1", "room-1", "user-1", "Alice", "hi")
+	assert.NoError(t, repo.AddMessage(parent))
+	server.FastForward(time.Hour)
+
+	reply := entity.NewChatMessage("reply-1", "room-1", "user-2", "Bob", "hello")
+	reply.ReplyTo = "msg-1"
+	assert.NoError(t, repo.AddMessage(reply))
+	server.FastForward(time.Hour)
+	_, err := repo.AddReaction("room-1", "msg-1", "👍", "user-2", 0)
+	assert.NoError(t, err)
+
+	// Every key of the room expires together, including those created after the last message
+	keys := repo.roomKeys("room-1")
+	for _, key := range keys {
+		assert.True(t, server.Exists(key), key)
+		assert.Equal(t, 23*time.Hour, server.TTL(key), key)
+	}
+
+	assert.NoError(t, repo.SetRoomLimits("room-1", 0, 2*time.Hour))
+	for _, key := range keys {
+		assert.Equal(t, 2*time.Hour, server.TTL(key), key)
+	}
+
+	server.FastForward(2 * time.Hour)
+	assert.Empty(t, server.Keys())
+	messages, _ := repo.GetMessages("room-1", 10)
+	assert.Empty(t, messages)
+}
+
+func TestRedisChatRepository_MigrateLegacyMessages(t *testing.T) {
+	repo, server := newTestRedisChatRepository(t)
+	t0 := time.Date(2026, 6, 1, 18, 0, 0, 0, time.UTC)
+
+	legacy := func(msg *entity.ChatMessage) {
+		data, _ := json.Marshal(msg)
+		server.Set("chat:room:room-1:msg:"+msg.ID, string(data))
+		server.SetTTL("chat:room:room-1:msg:"+msg.ID, time.Hour)
+		server.ZAdd(repo.roomKey("room-1"), float64(msg.Timestamp.UnixNano()), msg.ID)
+	}
+
+	parent := entity.NewChatMessage("msg-1", "room-1", "user-1", "Alice", "hi")
+	parent.Timestamp = t0
+	parent.ReplyCount = 1
+	parent.AddReaction("👍", "user-2")
+	legacy(parent)
+
+	reply := entity.NewChatMessage("reply-1", "room-1", "user-2", "Bob", "hello")
+	reply.Timestamp = t0.Add(time.Second)
+	reply.ReplyTo = "msg-1"
+	legacy(reply)
+	server.ZAdd("chat:room:room-1:thread:msg-1", float64(reply.Timestamp.UnixNano()), "reply-1")
+
+	// Already trimmed from the room's list
+	trimmed := entity.NewChatMessage("msg-0", "room-1", "user-1", "Alice", "old")
+	data, _ := json.Marshal(trimmed)
+	server.Set("chat:room:room-1:msg:msg-0", string(data))
+	server.SetTTL(repo.roomKey("room-1"), 3*time.Hour)
+
+	migrated, err := repo.MigrateLegacyMessages()
+	assert.NoError(t, err)
+	assert.Equal(t, 2, migrated)
+
+	for _, key := range server.Keys() {
+		assert.NotContains(t, key, ":msg:")
+		assert.NotContains(t, key, ":thread:")
+		assert.Equal(t, 3*time.Hour, server.TTL(key), key)
+	}
+
+	messages, err := repo.GetMessages("room-1", 10)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"msg-1", "reply-1"}, idsOf(messages))
+	assert.Equal(t, 1, messages[0].ReplyCount)
+	assert.Equal(t, map[string][]string{"👍": {"user-2"}}, messages[0].Reactions)
+
+	replies, _ := repo.GetReplies("room-1", "msg-1", 10)
+	assert.Equal(t, []string{"reply-1"}, idsOf(replies))
+
+	// Nothing left to move on the next start
+	migrated, err = repo.MigrateLegacyMessages()
+	assert.NoError(t, err)
+	assert.Zero(t, migrated)
+}
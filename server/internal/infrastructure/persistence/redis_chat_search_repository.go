@@ -0,0 +1,276 @@
+package persistence
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+
+	"voice-chat/internal/domain/entity"
+	"voice-chat/internal/domain/repository"
+)
+
+const (
+	// searchScanBatch is how many candidate IDs are loaded per round trip while filtering
+	searchScanBatch = 200
+	// searchScanLimit is how many candidates a search looks at before it settles for what it has
+	searchScanLimit = 5000
+)
+
+// redisSearchDoc is the stored copy of an indexed message
+type redisSearchDoc struct {
+	Message  *entity.ChatMessage `json:"message"`
+	RoomName string              `json:"room_name"`
+	Terms    []string            `json:"terms"`
+}
+
+// RedisChatSearchRepository is a Redis implementation of ChatSearchRepository. Each term is a
+// sorted set of document keys scored by message time, so multi-term queries are a ZINTERSTORE.
+// Every set is kept once across rooms and once per room, so room searches only see their room.
+type RedisChatSearchRepository struct {
+	client *redis.Client
+}
+
+// NewRedisChatSearchRepository creates a new RedisChatSearchRepository
+func NewRedisChatSearchRepository(addr, password string, db int) (*RedisChatSearchRepository, error) {
+	client := redis.NewClient(&redis.Options{
+		Addr:     addr,
+		Password: password,
+		DB:       db,
+	})
+
+	// Test connection
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := client.Ping(ctx).Err(); err != nil {
+		return nil, fmt.Errorf("failed to connect to Redis: %w", err)
+	}
+
+	return &RedisChatSearchRepository{client: client}, nil
+}
+
+// Close closes the Redis connection
+func (r *RedisChatSearchRepository) Close() error {
+	return r.client.Close()
+}
+
+// termKey returns the Redis key for a term's postings, across rooms when roomID is empty
+func (r *RedisChatSearchRepository) termKey(roomID, term string) string {
+	if roomID == "" {
+		return fmt.Sprintf("chat:search:term:%s", term)
+	}
+	return fmt.Sprintf("chat:search:room:%s:term:%s", roomID, term)
+}
+
+// allKey returns the Redis key listing every indexed document, across rooms when roomID is empty
+func (r *RedisChatSearchRepository) allKey(roomID string) string {
+	if roomID == "" {
+		return "chat:search:all"
+	}
+	return fmt.Sprintf("chat:search:room:%s:all", roomID)
+}
+
+// docKey returns the Redis key for an indexed message
+func (r *RedisChatSearchRepository) docKey(member string) string {
+	return fmt.Sprintf("chat:search:doc:%s", member)
+}
+
+// Index adds or replaces a message in the index; an empty roomName keeps the stored one
+func (r *RedisChatSearchRepository) Index(message *entity.ChatMessage, roomName string) error {
+	ctx := context.Background()
+	member := searchDocKey(message.RoomID, message.ID)
+
+	// Drop postings for terms the previous version had
+	previous, err := r.remove(ctx, member)
+	if err != nil {
+		return err
+	}
+	if previous != nil && roomName == "" {
+		roomName = previous.RoomName
+	}
+
+	doc := redisSearchDoc{
+		Message:  message,
+		RoomName: roomName,
+		Terms:    repository.SearchTerms(message.Content),
+	}
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return fmt.Errorf("failed to marshal search document: %w", err)
+	}
+
+	z := redis.Z{Score: float64(message.Timestamp.UnixNano()), Member: member}
+	pipe := r.client.Pipeline()
+	pipe.Set(ctx, r.docKey(member), data, 0)
+	for _, roomID := range []string{"", message.RoomID} {
+		pipe.ZAdd(ctx, r.allKey(roomID), z)
+		for _, term := range doc.Terms {
+			pipe.ZAdd(ctx, r.termKey(roomID, term), z)
+		}
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to index message: %w", err)
+	}
+	return nil
+}
+
+// Remove drops a message from the index
+func (r *RedisChatSearchRepository) Remove(roomID, messageID string) error {
+	_, err := r.remove(context.Background(), searchDocKey(roomID, messageID))
+	return err
+}
+
+// remove drops a document and its postings, returning the removed document if there was one
+func (r *RedisChatSearchRepository) remove(ctx context.Context, member string) (*redisSearchDoc, error) {
+	data, err := r.client.Get(ctx, r.docKey(member)).Bytes()
+	if err == redis.Nil {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get search document: %w", err)
+	}
+
+	var doc redisSearchDoc
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal search document: %w", err)
+	}
+
+	pipe := r.client.Pipeline()
+	for _, roomID := range []string{"", doc.Message.RoomID} {
+		for _, term := range doc.Terms {
+			pipe.ZRem(ctx, r.termKey(roomID, term), member)
+		}
+		pipe.ZRem(ctx, r.allKey(roomID), member)
+	}
+	pipe.Del(ctx, r.docKey(member))
+	if _, err := pipe.Exec(ctx); err != nil {
+		return nil, fmt.Errorf("failed to remove search document: %w", err)
+	}
+	return &doc, nil
+}
+
+// Search retrieves matching messages (newest first, up to query.Limit)
+func (r *RedisChatSearchRepository) Search(query repository.ChatSearchQuery) ([]*repository.ChatSearchResult, error) {
+	ctx := context.Background()
+	terms := repository.SearchTerms(query.Text)
+
+	source := r.allKey(query.RoomID)
+	switch len(terms) {
+	case 0:
+	case 1:
+		source = r.termKey(query.RoomID, terms[0])
+	default:
+		// Intersect the term sets into a short-lived key; MAX keeps the message time as score
+		keys := make([]string, len(terms))
+		for i, term := range terms {
+			keys[i] = r.termKey(query.RoomID, term)
+		}
+		source = fmt.Sprintf("chat:search:tmp:%s", uuid.New().String())
+		pipe := r.client.Pipeline()
+		pipe.ZInterStore(ctx, source, &redis.ZStore{Keys: keys, Aggregate: "MAX"})
+		pipe.Expire(ctx, source, 30*time.Second)
+		if _, err := pipe.Exec(ctx); err != nil {
+			return nil, fmt.Errorf("failed to intersect search terms: %w", err)
+		}
+		defer r.client.Del(ctx, source)
+	}
+
+	lower, upper := "-inf", "+inf"
+	if !query.Since.IsZero() {
+		lower = strconv.FormatInt(query.Since.UnixNano(), 10)
+	}
+	if !query.Until.IsZero() {
+		upper = strconv.FormatInt(query.Until.UnixNano(), 10)
+	}
+
+	// Page through candidates newest first, applying the remaining filters to the stored copies.
+	// Filters the sets can't answer (sender, room name) stop after searchScanLimit candidates.
+	results := make([]*repository.ChatSearchResult, 0)
+	for offset := int64(0); offset < searchScanLimit && (query.Limit <= 0 || len(results) < query.Limit); offset += searchScanBatch {
+		members, err := r.client.ZRevRangeByScore(ctx, source, &redis.ZRangeBy{
+			Min:    lower,
+			Max:    upper,
+			Offset: offset,
+			Count:  searchScanBatch,
+		}).Result()
+		if err != nil {
+			return nil, fmt.Errorf("failed to search messages: %w", err)
+		}
+		if len(members) == 0 {
+			break
+		}
+
+		docKeys := make([]string, len(members))
+		for i, member := range members {
+			docKeys[i] = r.docKey(member)
+		}
+		values, err := r.client.MGet(ctx, docKeys...).Result()
+		if err != nil {
+			return nil, fmt.Errorf("failed to load search documents: %w", err)
+		}
+
+		for _, value := range values {
+			data, ok := value.(string)
+			if !ok {
+				continue // Removed between the range and the load
+			}
+			var doc redisSearchDoc
+			if err := json.Unmarshal([]byte(data), &doc); err != nil {
+				continue
+			}
+			result := &repository.ChatSearchResult{Message: doc.Message, RoomName: doc.RoomName}
+			if !query.Matches(result) {
+				continue
+			}
+			results = append(results, result)
+			if query.Limit > 0 && len(results) == query.Limit {
+				break
+			}
+		}
+
+		if len(members) < searchScanBatch {
+			break
+		}
+	}
+
+	return results, nil
+}
+
+// Prune drops messages sent before the cutoff and returns how many were removed
+func (r *RedisChatSearchRepository) Prune(before time.Time) (int, error) {
+	return r.pruneSet(context.Background(), r.allKey(""), "("+strconv.FormatInt(before.UnixNano(), 10))
+}
+
+// PruneRoom drops a room's messages sent before the cutoff and returns how many were removed
+func (r *RedisChatSearchRepository) PruneRoom(roomID string, before time.Time) (int, error) {
+	return r.pruneSet(context.Background(), r.allKey(roomID), "("+strconv.FormatInt(before.UnixNano(), 10))
+}
+
+// RemoveRoom drops every message of a room
+func (r *RedisChatSearchRepository) RemoveRoom(roomID string) error {
+	_, err := r.pruneSet(context.Background(), r.allKey(roomID), "+inf")
+	return err
+}
+
+// pruneSet removes the documents of a set scored up to max
+func (r *RedisChatSearchRepository) pruneSet(ctx context.Context, key, max string) (int, error) {
+	members, err := r.client.ZRangeByScore(ctx, key, &redis.ZRangeBy{
+		Min: "-inf",
+		Max: max,
+	}).Result()
+	if err != nil {
+		return 0, fmt.Errorf("failed to find expired search documents: %w", err)
+	}
+
+	for _, member := range members {
+		if _, err := r.remove(ctx, member); err != nil {
+			return 0, err
+		}
+	}
+	return len(members), nil
+}
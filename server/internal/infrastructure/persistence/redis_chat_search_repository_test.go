@@ -0,0 +1,69 @@
+package persistence
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/stretchr/testify/assert"
+
+	"voice-chat/internal/domain/entity"
+	"voice-chat/internal/domain/repository"
+)
+
+func newTestRedisChatSearchRepository(t *testing.T) (*RedisChatSearchRepository, *miniredis.Miniredis) {
+	server := miniredis.RunT(t)
+	repo, err := NewRedisChatSearchRepository(server.Addr(), "", 0)
+	assert.NoError(t, err)
+	t.Cleanup(func() { repo.Close() })
+	return repo, server
+}
+
+func TestRedisChatSearchRepository_RoomSearch(t *testing.T) {
+	repo, server := newTestRedisChatSearchRepository(t)
+	t0 := time.Date(2026, 6, 1, 18, 0, 0, 0, time.UTC)
+
+	pizza := entity.NewChatMessage("msg-1", "room-1", "user-1", "Alice", "pizza tonight?")
+	pizza.Timestamp = t0
+	other := entity.NewChatMessage("msg-2", "room-2", "user-2", "Bob", "pizza over here")
+	other.Timestamp = t0.Add(time.Second)
+	assert.NoError(t, repo.Index(pizza, "Lobby"))
+	assert.NoError(t, repo.Index(other, "Gaming"))
+
+	// Room searches read the room's own sets, so the other room is never scanned
+	assert.Equal(t, []string{"room-1:msg-1"}, mustMembers(t, server, "chat:search:room:room-1:term:pizza"))
+
+	results, err := repo.Search(repository.ChatSearchQuery{Text: "pizza", RoomID: "room-1"})
+	assert.NoError(t, err)
+	assert.Len(t, results, 1)
+	assert.Equal(t, "Lobby", results[0].RoomName)
+
+	results, _ = repo.Search(repository.ChatSearchQuery{Text: "pizza"})
+	assert.Len(t, results, 2)
+
+	// Removing a message clears both the shared and the room sets
+	assert.NoError(t, repo.Remove("room-1", "msg-1"))
+	assert.False(t, server.Exists("chat:search:room:room-1:term:pizza"))
+	assert.False(t, server.Exists("chat:search:room:room-1:all"))
+	assert.Equal(t, []string{"room-2:msg-2"}, mustMembers(t, server, "chat:search:term:pizza"))
+
+	// Room pruning leaves other rooms alone
+	assert.NoError(t, repo.Index(pizza, "Lobby"))
+	count, err := repo.PruneRoom("room-1", t0.Add(time.Hour))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+	assert.NoError(t, repo.RemoveRoom("room-1"))
+	results, _ = repo.Search(repository.ChatSearchQuery{Text: "pizza"})
+	assert.Len(t, results, 1)
+
+	assert.NoError(t, repo.RemoveRoom("room-2"))
+	assert.False(t, server.Exists("chat:search:all"))
+}
+
+func mustMembers(t *testing.T, server *miniredis.Miniredis, key string) []string {
+	t.Helper()
+
+	members, err := server.ZMembers(key)
+	assert.NoError(t, err)
+	return members
+}
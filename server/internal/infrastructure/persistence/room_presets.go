@@ -0,0 +1,50 @@
+package persistence
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"voice-chat/internal/domain/entity"
+)
+
+// roomsFile is the on-disk layout of the rooms file
+type roomsFile struct {
+	Rooms []*entity.RoomPreset `json:"rooms" yaml:"rooms"`
+}
+
+// LoadRoomPresets reads and validates room presets from a YAML or JSON file (chosen by extension)
+func LoadRoomPresets(path string) ([]*entity.RoomPreset, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read rooms file: %w", err)
+	}
+
+	var file roomsFile
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		err = json.Unmarshal(data, &file)
+	default:
+		err = yaml.Unmarshal(data, &file)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse rooms file: %w", err)
+	}
+
+	names := make(map[string]bool, len(file.Rooms))
+	for _, preset := range file.Rooms {
+		if err := preset.Normalize(); err != nil {
+			return nil, err
+		}
+		if names[preset.Name] {
+			return nil, fmt.Errorf("%w: duplicate room %q", entity.ErrInvalidRoomPreset, preset.Name)
+		}
+		names[preset.Name] = true
+	}
+
+	return file.Rooms, nil
+}
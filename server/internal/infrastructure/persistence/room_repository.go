@@ -134,7 +134,7 @@ func (r *InMemoryRoomRepository) GetEmptyRoomsSince(minutes int) ([]*entity.Room
 	emptyRooms := make([]*entity.Room, 0)
 
 	for _, room := range r.rooms {
-		if room.IsEmpty() && room.TimeSinceLastActivity() > threshold && !room.IsAdminCreated() {
+		if room.IsEmpty() && room.TimeSinceLastActivity() > threshold && !room.IsPersistent() {
 			emptyRooms = append(emptyRooms, room)
 		}
 	}
@@ -0,0 +1,156 @@
+package dto
+
+// Translator renders a message catalog entry in the recipient's locale
+type Translator func(key string, params map[string]string) (string, bool)
+
+// Localizable is a payload carrying text from the message catalog. Payloads are shared between
+// recipients, so Localize returns a translated copy and leaves the payload as it was.
+type Localizable interface {
+	Localize(t Translator) interface{}
+}
+
+// Localize replaces the message with the catalog's text for the code, keeping the handler's own,
+// usually more specific, message as the detail
+func (e ErrorResponse) Localize(t Translator) interface{} {
+	if message, ok := t("errors."+e.Code, nil); ok && message != e.Message {
+		e.Detail = e.Message
+		e.Message = message
+	}
+	return e
+}
+
+// Localize renders the message if it's a structured system message
+func (e ChatMessageEvent) Localize(t Translator) interface{} {
+	e.Message = localizeMessage(t, e.Message)
+	return e
+}
+
+// Localize renders the structured system messages of the page
+func (r ChatHistoryResponse) Localize(t Translator) interface{} {
+	r.Messages = localizeMessages(t, r.Messages)
+	return r
+}
+
+// Localize renders the structured system messages of the thread
+func (r ChatThreadResponse) Localize(t Translator) interface{} {
+	r.Parent = localizeMessage(t, r.Parent)
+	r.Replies = localizeMessages(t, r.Replies)
+	return r
+}
+
+// Localize renders the message if it's a structured system message
+func (e MentionEvent) Localize(t Translator) interface{} {
+	e.Message = localizeMessage(t, e.Message)
+	return e
+}
+
+// Localize renders the message if it's a structured system message
+func (e ChatFlaggedEvent) Localize(t Translator) interface{} {
+	e.Message = localizeMessage(t, e.Message)
+	return e
+}
+
+// Localize renders the structured system messages among the results
+func (r ChatSearchResponse) Localize(t Translator) interface{} {
+	r.Results = localizeSearchResults(t, r.Results)
+	return r
+}
+
+// Localize renders the structured system messages of the lobby chat history
+func (r LobbyChatJoinedResponse) Localize(t Translator) interface{} {
+	r.Messages = localizeMessages(t, r.Messages)
+	return r
+}
+
+// Localize renders the structured system messages among the room's pins
+func (r JoinRoomResponse) Localize(t Translator) interface{} {
+	r.Pinned = localizePinned(t, r.Pinned)
+	return r
+}
+
+// Localize renders the structured system messages among the room's pins
+func (r RoomInfoResponse) Localize(t Translator) interface{} {
+	r.Pinned = localizePinned(t, r.Pinned)
+	return r
+}
+
+// Localize renders the structured system messages among the room's pins
+func (e RoomUpdatedEvent) Localize(t Translator) interface{} {
+	e.Pinned = localizePinned(t, e.Pinned)
+	return e
+}
+
+// localizeMessage renders a structured system message, copying it rather than changing it in place
+func localizeMessage(t Translator, msg *ChatMessageDTO) *ChatMessageDTO {
+	if msg == nil || msg.Code == "" {
+		return msg
+	}
+	content, ok := t("system."+msg.Code, msg.Params)
+	if !ok || content == msg.Content {
+		return msg
+	}
+	localized := *msg
+	localized.Content = content
+	return &localized
+}
+
+// localizeMessages renders the structured system messages of a list, copying the list if any changed
+func localizeMessages(t Translator, messages []*ChatMessageDTO) []*ChatMessageDTO {
+	var localized []*ChatMessageDTO
+	for i, msg := range messages {
+		if l := localizeMessage(t, msg); l != msg {
+			if localized == nil {
+				localized = append([]*ChatMessageDTO(nil), messages...)
+			}
+			localized[i] = l
+		}
+	}
+	if localized == nil {
+		return messages
+	}
+	return localized
+}
+
+// localizePinned renders the structured system messages among a room's pins
+func localizePinned(t Translator, pinned []*PinnedMessageDTO) []*PinnedMessageDTO {
+	var localized []*PinnedMessageDTO
+	for i, pin := range pinned {
+		if pin == nil {
+			continue
+		}
+		if l := localizeMessage(t, pin.Message); l != pin.Message {
+			if localized == nil {
+				localized = append([]*PinnedMessageDTO(nil), pinned...)
+			}
+			copied := *pin
+			copied.Message = l
+			localized[i] = &copied
+		}
+	}
+	if localized == nil {
+		return pinned
+	}
+	return localized
+}
+
+// localizeSearchResults renders the structured system messages among search results
+func localizeSearchResults(t Translator, results []*ChatSearchResultDTO) []*ChatSearchResultDTO {
+	var localized []*ChatSearchResultDTO
+	for i, result := range results {
+		if result == nil {
+			continue
+		}
+		if l := localizeMessage(t, result.Message); l != result.Message {
+			if localized == nil {
+				localized = append([]*ChatSearchResultDTO(nil), results...)
+			}
+			copied := *result
+			copied.Message = l
+			localized[i] = &copied
+		}
+	}
+	if localized == nil {
+		return results
+	}
+	return localized
+}
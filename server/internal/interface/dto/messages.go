@@ -5,6 +5,8 @@ import (
 	"time"
 
 	"voice-chat/internal/domain/entity"
+	"voice-chat/internal/domain/repository"
+	"voice-chat/internal/usecase/moderation"
 	"voice-chat/internal/usecase/room"
 )
 
@@ -23,22 +25,32 @@ type JoinRoomRequest struct {
 
 // JoinRoomResponse represents the response after joining a room
 type JoinRoomResponse struct {
-	RoomID       string             `json:"room_id"`
-	RoomName     string             `json:"room_name"`
-	UserID       string             `json:"user_id"`
-	UserName     string             `json:"user_name"`
-	LiveKitToken string             `json:"livekit_token"`
-	LiveKitURL   string             `json:"livekit_url"`
-	Participants []*ParticipantDTO  `json:"participants"`
-	IsNewRoom    bool               `json:"is_new_room"`
+	RoomID       string              `json:"room_id"`
+	RoomName     string              `json:"room_name"`
+	UserID       string              `json:"user_id"`
+	UserName     string              `json:"user_name"`
+	LiveKitToken string              `json:"livekit_token"`
+	LiveKitURL   string              `json:"livekit_url"`
+	Participants []*ParticipantDTO   `json:"participants"`
+	IsNewRoom    bool                `json:"is_new_room"`
+	VoiceMode    string              `json:"voice_mode"` // Effective mode after the room's voice policy
+	LastReadID   string              `json:"last_read_id,omitempty"`
+	UnreadCount  int                 `json:"unread_count"` // Messages newer than last_read_id
+	SlowMode     int                 `json:"slow_mode"`    // Seconds between a member's chat messages, 0 when off
+	Description  string              `json:"description,omitempty"`
+	Topic        string              `json:"topic,omitempty"`
+	Pinned       []*PinnedMessageDTO `json:"pinned"`
 }
 
 // ParticipantDTO represents a participant in a room
 type ParticipantDTO struct {
-	ID       string `json:"id"`
-	Name     string `json:"name"`
-	IsMuted  bool   `json:"is_muted"`
-	IsAdmin  bool   `json:"is_admin"`
+	ID         string `json:"id"`
+	Name       string `json:"name"`
+	IsMuted    bool   `json:"is_muted"`
+	IsAdmin    bool   `json:"is_admin"`
+	IsListener bool   `json:"is_listener"`
+	IsAFK      bool   `json:"is_afk"`
+	AFKMessage string `json:"afk_message,omitempty"`
 }
 
 // LeaveRoomRequest represents a request to leave a room
@@ -54,13 +66,19 @@ type GetRoomRequest struct {
 
 // RoomInfoResponse represents room information
 type RoomInfoResponse struct {
-	ID           string            `json:"id"`
-	Name         string            `json:"name"`
-	Type         string            `json:"type"`
-	Capacity     int               `json:"capacity"`
-	Participants []*ParticipantDTO `json:"participants"`
-	CanJoin      bool              `json:"can_join"`
-	IsClosed     bool              `json:"is_closed"`
+	ID           string              `json:"id"`
+	Name         string              `json:"name"`
+	Type         string              `json:"type"`
+	Capacity     int                 `json:"capacity"`
+	Participants []*ParticipantDTO   `json:"participants"`
+	CanJoin      bool                `json:"can_join"`
+	IsClosed     bool                `json:"is_closed"`
+	VoicePolicy  string              `json:"voice_policy"`
+	Description  string              `json:"description,omitempty"`
+	Category     string              `json:"category,omitempty"`
+	SlowMode     int                 `json:"slow_mode"`
+	Topic        string              `json:"topic,omitempty"`
+	Pinned       []*PinnedMessageDTO `json:"pinned"`
 }
 
 // RoomListResponse represents a list of rooms
@@ -76,6 +94,10 @@ type RoomSummaryDTO struct {
 	ParticipantCount int    `json:"participant_count"`
 	Capacity         int    `json:"capacity"`
 	IsFull           bool   `json:"is_full"`
+	VoicePolicy      string `json:"voice_policy"`
+	Description      string `json:"description,omitempty"`
+	Category         string `json:"category,omitempty"`
+	SortOrder        int    `json:"sort_order"`
 }
 
 // CreateRoomRequest represents a request to create a room (admin)
@@ -111,6 +133,40 @@ type MuteSelfRequest struct {
 	Muted bool `json:"muted"`
 }
 
+// ListenRoomRequest represents a request to listen to an additional room
+type ListenRoomRequest struct {
+	RoomName string `json:"room_name"`
+	UserName string `json:"user_name,omitempty"` // Used when not in a room yet
+	Stealth  bool   `json:"stealth,omitempty"`   // Admin only
+}
+
+// ListenRoomResponse represents the response after starting to listen to a room
+type ListenRoomResponse struct {
+	RoomID       string            `json:"room_id"`
+	RoomName     string            `json:"room_name"`
+	ListenerName string            `json:"listener_name"`
+	LiveKitToken string            `json:"livekit_token"`
+	LiveKitURL   string            `json:"livekit_url"`
+	Participants []*ParticipantDTO `json:"participants"`
+}
+
+// UnlistenRoomRequest represents a request to stop listening to a room
+type UnlistenRoomRequest struct {
+	RoomID string `json:"room_id"`
+}
+
+// UnlistenRoomResponse confirms that the user stopped listening to a room
+type UnlistenRoomResponse struct {
+	RoomID string `json:"room_id"`
+}
+
+// ListenerEvent represents a listener joining or leaving a room
+type ListenerEvent struct {
+	UserID   string `json:"user_id"`
+	UserName string `json:"user_name"`
+	RoomID   string `json:"room_id"`
+}
+
 // AdminAuthRequest represents an admin authentication request
 type AdminAuthRequest struct {
 	Password string `json:"password"`
@@ -122,6 +178,117 @@ type AdminAuthResponse struct {
 	Message string `json:"message"`
 }
 
+// AdminSetListenRolesRequest updates which roles may listen to additional rooms
+type AdminSetListenRolesRequest struct {
+	Roles []string `json:"roles"`
+}
+
+// ListenRolesResponse reports the roles allowed to listen to additional rooms
+type ListenRolesResponse struct {
+	Roles []string `json:"roles"`
+}
+
+// BroadcastAnnouncementRequest represents an admin announcement request
+type BroadcastAnnouncementRequest struct {
+	Content   string   `json:"content,omitempty"`    // Text announcement (optional when voice is set)
+	Target    string   `json:"target,omitempty"`     // "all" (default), "rooms" or "lobby"
+	RoomNames []string `json:"room_names,omitempty"` // Required for target "rooms"
+	Voice     bool     `json:"voice,omitempty"`      // Start a voice PA to all active rooms
+}
+
+// AnnouncementEvent represents a café-wide announcement delivered to every client
+type AnnouncementEvent struct {
+	ID         string `json:"id"`
+	Content    string `json:"content"`
+	SenderName string `json:"sender_name"`
+	Timestamp  int64  `json:"timestamp"` // Unix milliseconds
+}
+
+// ScheduleAnnouncementRequest represents a request to create a recurring announcement
+type ScheduleAnnouncementRequest struct {
+	Content   string   `json:"content"`
+	Cron      string   `json:"cron"`                 // Five-field cron expression, e.g. "0 18 * * *"
+	Target    string   `json:"target,omitempty"`     // "all" (default), "rooms" or "lobby"
+	RoomNames []string `json:"room_names,omitempty"` // Required for target "rooms"
+}
+
+// ScheduledAnnouncementIDRequest identifies a scheduled announcement
+type ScheduledAnnouncementIDRequest struct {
+	ID      string `json:"id"`
+	Enabled bool   `json:"enabled,omitempty"` // Used by admin_set_scheduled_announcement_enabled
+}
+
+// ScheduledAnnouncementDTO represents a scheduled announcement for admins
+type ScheduledAnnouncementDTO struct {
+	ID        string   `json:"id"`
+	Content   string   `json:"content"`
+	Cron      string   `json:"cron"`
+	Target    string   `json:"target"`
+	RoomNames []string `json:"room_names,omitempty"`
+	Enabled   bool     `json:"enabled"`
+	LastRunAt int64    `json:"last_run_at,omitempty"` // Unix milliseconds
+}
+
+// ScheduledAnnouncementsResponse lists all scheduled announcements
+type ScheduledAnnouncementsResponse struct {
+	Schedules []*ScheduledAnnouncementDTO `json:"schedules"`
+}
+
+// SetMessageOfTheDayRequest represents a request to change the message of the day
+type SetMessageOfTheDayRequest struct {
+	MessageOfTheDay string `json:"motd"`
+}
+
+// MessageOfTheDayResponse reports the current message of the day
+type MessageOfTheDayResponse struct {
+	MessageOfTheDay string `json:"motd"`
+}
+
+// AdminScheduleMaintenanceRequest represents an admin request to schedule maintenance
+type AdminScheduleMaintenanceRequest struct {
+	Minutes int    `json:"minutes"` // Countdown length; 0 starts maintenance immediately
+	Message string `json:"message,omitempty"`
+}
+
+// MaintenanceEvent represents the maintenance countdown state
+type MaintenanceEvent struct {
+	Message          string    `json:"message"`
+	StartsAt         time.Time `json:"starts_at"`
+	SecondsRemaining int       `json:"seconds_remaining"`
+	Active           bool      `json:"active"`
+}
+
+// ServerShutdownEvent is sent to every client right before the server closes its socket
+type ServerShutdownEvent struct {
+	Message        string `json:"message"`
+	ReconnectAfter int    `json:"reconnect_after"` // Seconds the client should wait before reconnecting
+}
+
+// VoicePARoomToken represents a publish token for one room of a voice PA
+type VoicePARoomToken struct {
+	RoomID       string `json:"room_id"`
+	RoomName     string `json:"room_name"`
+	LiveKitToken string `json:"livekit_token"`
+}
+
+// VoicePATokensResponse delivers the voice PA publish tokens to the announcing admin
+type VoicePATokensResponse struct {
+	LiveKitURL string              `json:"livekit_url"`
+	Rooms      []*VoicePARoomToken `json:"rooms"`
+}
+
+// VoicePAStartedEvent tells room clients to duck other speakers for the announcer
+type VoicePAStartedEvent struct {
+	SpeakerIdentity string  `json:"speaker_identity"`
+	DuckOthers      bool    `json:"duck_others"`
+	DuckVolume      float64 `json:"duck_volume"` // Volume multiplier for other speakers (0-1)
+}
+
+// VoicePAEndedEvent tells room clients to restore normal volume
+type VoicePAEndedEvent struct {
+	SpeakerIdentity string `json:"speaker_identity"`
+}
+
 // AdminMuteRequest represents an admin mute request
 type AdminMuteRequest struct {
 	UserID string `json:"user_id"`
@@ -154,13 +321,13 @@ type AdminJoinStealthRequest struct {
 
 // StatsResponse represents admin statistics
 type StatsResponse struct {
-	ActiveRooms     int                   `json:"active_rooms"`
-	TotalUsers      int                   `json:"total_users"`
-	PeakUsersToday  int                   `json:"peak_users_today"`
-	TotalJoinsToday int                   `json:"total_joins_today"`
-	Rooms           []*RoomStatsDTO       `json:"rooms"`
-	RecentActivity  []*ActivityLogDTO     `json:"recent_activity"`
-	ActiveBans      int                   `json:"active_bans"`
+	ActiveRooms     int               `json:"active_rooms"`
+	TotalUsers      int               `json:"total_users"`
+	PeakUsersToday  int               `json:"peak_users_today"`
+	TotalJoinsToday int               `json:"total_joins_today"`
+	Rooms           []*RoomStatsDTO   `json:"rooms"`
+	RecentActivity  []*ActivityLogDTO `json:"recent_activity"`
+	ActiveBans      int               `json:"active_bans"`
 }
 
 // RoomStatsDTO represents room statistics
@@ -184,14 +351,32 @@ type ActivityLogDTO struct {
 
 // ErrorResponse represents an error message
 type ErrorResponse struct {
-	Code    string `json:"code"`
-	Message string `json:"message"`
+	Code         string `json:"code"`
+	Message      string `json:"message"`
+	Detail       string `json:"detail,omitempty"`         // The server's own English text, when the message was translated
+	RetryAfterMs int64  `json:"retry_after_ms,omitempty"` // For rate limits, slow mode and mutes
 }
 
 // ConnectedResponse represents the initial connection response
 type ConnectedResponse struct {
-	Message string `json:"message"`
-	UserID  string `json:"user_id"`
+	Message         string            `json:"message"`
+	UserID          string            `json:"user_id"`
+	MessageOfTheDay string            `json:"motd,omitempty"`
+	Maintenance     *MaintenanceEvent `json:"maintenance,omitempty"`
+	SessionToken    string            `json:"session_token"` // Pass as ?resume= when reconnecting to keep read markers
+	DMID            string            `json:"dm_id"`         // Identity in direct messages, kept with the session
+	Locale          string            `json:"locale"`        // Negotiated from ?locale= or Accept-Language
+	Locales         []string          `json:"locales"`       // Locales the server has messages for
+}
+
+// SetLocaleRequest changes the locale of server messages for the connection
+type SetLocaleRequest struct {
+	Locale string `json:"locale"`
+}
+
+// LocaleResponse confirms the locale used for server messages
+type LocaleResponse struct {
+	Locale string `json:"locale"`
 }
 
 // UserKickedEvent represents a kick notification
@@ -213,23 +398,418 @@ type RoomClosedEvent struct {
 
 // ChatMessageRequest represents a chat message from client
 type ChatMessageRequest struct {
-	Content string `json:"content"`
+	Content       string   `json:"content"`
+	ReplyTo       string   `json:"reply_to,omitempty"`       // Parent message ID
+	AttachmentIDs []string `json:"attachment_ids,omitempty"` // IDs returned by POST /api/uploads
 }
 
 // ChatMessageDTO represents a chat message for client
 type ChatMessageDTO struct {
-	ID         string              `json:"id"`
-	Type       string              `json:"type"` // "chat" or "system"
-	SenderID   string              `json:"sender_id"`
-	SenderName string              `json:"sender_name"`
-	Content    string              `json:"content"`
-	Timestamp  int64               `json:"timestamp"` // Unix milliseconds
-	Reactions  map[string][]string `json:"reactions"` // emoji -> []userID
+	ID          string              `json:"id"`
+	Type        string              `json:"type"` // "chat" or "system"
+	SenderID    string              `json:"sender_id"`
+	SenderName  string              `json:"sender_name"`
+	Content     string              `json:"content"`
+	Timestamp   int64               `json:"timestamp"`           // Unix milliseconds
+	Reactions   map[string][]string `json:"reactions"`           // emoji -> []userID
+	EditedAt    int64               `json:"edited_at,omitempty"` // Unix milliseconds
+	Deleted     bool                `json:"deleted,omitempty"`
+	DeletedBy   string              `json:"deleted_by,omitempty"`
+	ReplyTo     string              `json:"reply_to,omitempty"`
+	ReplyCount  int                 `json:"reply_count,omitempty"`
+	Quote       *ChatQuoteDTO       `json:"quote,omitempty"`
+	Mentions    []string            `json:"mentions,omitempty"` // Mentioned user IDs
+	AtHere      bool                `json:"at_here,omitempty"`
+	Attachments []*AttachmentDTO    `json:"attachments,omitempty"`
+	Poll        *PollDTO            `json:"poll,omitempty"`
+	Code        string              `json:"code,omitempty"`   // Structured system messages, e.g. "user_joined"
+	Params      map[string]string   `json:"params,omitempty"` // Parameters for the code
+}
+
+// AttachmentDTO represents a file attached to a chat message
+type AttachmentDTO struct {
+	ID           string `json:"id"`
+	FileName     string `json:"file_name"`
+	ContentType  string `json:"content_type"`
+	Size         int64  `json:"size"` // Bytes
+	Width        int    `json:"width,omitempty"`
+	Height       int    `json:"height,omitempty"`
+	URL          string `json:"url"`
+	ThumbnailURL string `json:"thumbnail_url,omitempty"`
+}
+
+// PollDTO represents a poll with its current results
+type PollDTO struct {
+	Question    string           `json:"question"`
+	Options     []*PollOptionDTO `json:"options"`
+	MultiChoice bool             `json:"multi_choice"`
+	Anonymous   bool             `json:"anonymous"`
+	ClosesAt    int64            `json:"closes_at,omitempty"` // Unix milliseconds
+	Closed      bool             `json:"closed"`
+	VoterCount  int              `json:"voter_count"`
+}
+
+// PollOptionDTO represents one poll option and its votes
+type PollOptionDTO struct {
+	Text   string   `json:"text"`
+	Votes  int      `json:"votes"`
+	Voters []string `json:"voters,omitempty"` // User IDs, never set for anonymous polls
+}
+
+// ChatQuoteDTO represents the quoted parent shown above a reply
+type ChatQuoteDTO struct {
+	MessageID  string `json:"message_id"`
+	SenderName string `json:"sender_name"`
+	Snippet    string `json:"snippet"`
+	Deleted    bool   `json:"deleted,omitempty"`
+}
+
+// ChatEditRequest represents a request to edit one of the user's own messages
+type ChatEditRequest struct {
+	MessageID string `json:"message_id"`
+	Content   string `json:"content"`
+}
+
+// ChatDeleteRequest represents a request to delete a message
+type ChatDeleteRequest struct {
+	MessageID string `json:"message_id"`
+}
+
+// GetThreadRequest requests the replies to a message
+type GetThreadRequest struct {
+	MessageID string `json:"message_id"`
+	Limit     int    `json:"limit,omitempty"`
+}
+
+// ChatThreadResponse represents a thread root and its replies
+type ChatThreadResponse struct {
+	Parent  *ChatMessageDTO   `json:"parent"`
+	Replies []*ChatMessageDTO `json:"replies"`
+}
+
+// MentionEvent notifies a user that they were mentioned
+type MentionEvent struct {
+	RoomID  string          `json:"room_id"`
+	Message *ChatMessageDTO `json:"message"`
+	AtHere  bool            `json:"at_here"` // Mentioned through @here rather than by name
+}
+
+// ChatThreadUpdatedEvent reports a new reply count on a thread root
+type ChatThreadUpdatedEvent struct {
+	MessageID  string `json:"message_id"`
+	ReplyCount int    `json:"reply_count"`
+}
+
+// ModerationViolationDTO represents a chat filter a message tripped
+type ModerationViolationDTO struct {
+	Filter string `json:"filter"`
+	Action string `json:"action"`
+	Reason string `json:"reason"`
+}
+
+// ChatWarningEvent tells a sender their message was sent but broke a chat rule
+type ChatWarningEvent struct {
+	RoomID     string                    `json:"room_id"`
+	MessageID  string                    `json:"message_id"`
+	Violations []*ModerationViolationDTO `json:"violations"`
+}
+
+// ChatFlaggedEvent tells admins a message was flagged for review
+type ChatFlaggedEvent struct {
+	RoomID     string                    `json:"room_id"`
+	Message    *ChatMessageDTO           `json:"message"`
+	Violations []*ModerationViolationDTO `json:"violations"`
+}
+
+// CreatePollRequest represents a poll posted to the current room
+type CreatePollRequest struct {
+	Question        string   `json:"question"`
+	Options         []string `json:"options"`
+	MultiChoice     bool     `json:"multi_choice"`
+	Anonymous       bool     `json:"anonymous"`
+	DurationSeconds int      `json:"duration_seconds,omitempty"` // 0 keeps the poll open until closed
+}
+
+// PollVoteRequest represents a vote; empty choices withdraw it
+type PollVoteRequest struct {
+	MessageID string `json:"message_id"`
+	Choices   []int  `json:"choices"` // Option indexes
+}
+
+// ClosePollRequest represents the poll's author or a moderator closing it
+type ClosePollRequest struct {
+	MessageID string `json:"message_id"`
+}
+
+// PollUpdatedEvent carries a poll's results after a vote or when it closes
+type PollUpdatedEvent struct {
+	RoomID    string   `json:"room_id"`
+	MessageID string   `json:"message_id"`
+	Poll      *PollDTO `json:"poll"`
+}
+
+// SetTopicRequest represents a room owner or admin changing the topic of their current room
+type SetTopicRequest struct {
+	Topic string `json:"topic"` // Empty clears the topic
+}
+
+// PinMessageRequest represents a room owner or admin pinning or unpinning a message
+type PinMessageRequest struct {
+	MessageID string `json:"message_id"`
+}
+
+// PinnedMessageDTO represents a message pinned to the top of a room
+type PinnedMessageDTO struct {
+	Message  *ChatMessageDTO `json:"message"`
+	PinnedBy string          `json:"pinned_by"`
+	PinnedAt int64           `json:"pinned_at"` // Unix milliseconds
+}
+
+// RoomUpdatedEvent carries a room's current topic and pins after either changed
+type RoomUpdatedEvent struct {
+	RoomID    string              `json:"room_id"`
+	Topic     string              `json:"topic"`
+	Pinned    []*PinnedMessageDTO `json:"pinned"`
+	UpdatedBy string              `json:"updated_by,omitempty"`
+}
+
+// SetSlowModeRequest represents a room owner or admin changing slow mode in their current room
+type SetSlowModeRequest struct {
+	Seconds int `json:"seconds"` // 0 turns slow mode off
+}
+
+// SlowModeEvent announces a room's new slow mode
+type SlowModeEvent struct {
+	RoomID  string `json:"room_id"`
+	Seconds int    `json:"seconds"`
+	SetBy   string `json:"set_by"`
+}
+
+// CommandReplyEvent is a slash command's answer shown only to the caller
+type CommandReplyEvent struct {
+	Command string `json:"command"`
+	Content string `json:"content"`
+}
+
+// ChatMutedEvent tells a client it was muted for flooding
+type ChatMutedEvent struct {
+	DurationMs int64  `json:"duration_ms"`
+	Reason     string `json:"reason"`
+}
+
+// TypingRequest represents a client starting or stopping typing
+type TypingRequest struct {
+	Typing bool `json:"typing"`
+}
+
+// TypingEvent tells a room that a user started or stopped typing
+type TypingEvent struct {
+	RoomID    string `json:"room_id"`
+	UserID    string `json:"user_id"`
+	UserName  string `json:"user_name"`
+	Typing    bool   `json:"typing"`
+	ExpiresIn int    `json:"expires_in,omitempty"` // Seconds until clients should drop the indicator
+}
+
+// MarkReadRequest moves the read marker in the current room
+type MarkReadRequest struct {
+	MessageID string `json:"message_id"`
+}
+
+// ReadMarkerEvent reports the read marker and unread count for a room
+type ReadMarkerEvent struct {
+	RoomID      string `json:"room_id"`
+	LastReadID  string `json:"last_read_id,omitempty"`
+	UnreadCount int    `json:"unread_count"`
+}
+
+// SearchChatRequest represents a search of the current room's chat
+type SearchChatRequest struct {
+	Query string `json:"query"`
+	Limit int    `json:"limit,omitempty"`
+}
+
+// AdminSearchChatRequest represents an admin search across every room
+type AdminSearchChatRequest struct {
+	Query      string    `json:"query,omitempty"`
+	RoomID     string    `json:"room_id,omitempty"`
+	RoomName   string    `json:"room_name,omitempty"`
+	SenderID   string    `json:"sender_id,omitempty"`
+	SenderName string    `json:"sender_name,omitempty"`
+	Since      time.Time `json:"since,omitempty"` // RFC 3339
+	Until      time.Time `json:"until,omitempty"` // RFC 3339
+	Limit      int       `json:"limit,omitempty"`
+}
+
+// ChatSearchResultDTO represents a matching message and the room it was sent in
+type ChatSearchResultDTO struct {
+	RoomName string          `json:"room_name"`
+	Message  *ChatMessageDTO `json:"message"`
+}
+
+// ChatSearchResponse represents search results (newest first)
+type ChatSearchResponse struct {
+	Query   string                 `json:"query"`
+	Results []*ChatSearchResultDTO `json:"results"`
+}
+
+// AdminSetRetentionRequest represents an admin changing a room's chat retention
+type AdminSetRetentionRequest struct {
+	RoomID   string `json:"room_id"`
+	Mode     string `json:"mode"`               // ephemeral, messages, days or archive
+	Messages int    `json:"messages,omitempty"` // Messages kept in "messages" mode
+	Days     int    `json:"days,omitempty"`     // Days kept in "days" mode
+}
+
+// RoomRetentionResponse reports a room's chat retention policy
+type RoomRetentionResponse struct {
+	RoomID   string `json:"room_id"`
+	Mode     string `json:"mode"`
+	Messages int    `json:"messages,omitempty"`
+	Days     int    `json:"days,omitempty"`
+}
+
+// ChatArchiveDTO represents an archived transcript of a closed room
+type ChatArchiveDTO struct {
+	ID             string `json:"id"`
+	RoomID         string `json:"room_id"`
+	RoomName       string `json:"room_name"`
+	MessageCount   int    `json:"message_count"`
+	FirstMessageAt int64  `json:"first_message_at"` // Unix milliseconds
+	LastMessageAt  int64  `json:"last_message_at"`  // Unix milliseconds
+	ArchivedAt     int64  `json:"archived_at"`      // Unix milliseconds
+	Size           int64  `json:"size"`             // Compressed bytes
+	URL            string `json:"url"`              // Download with ?format=jsonl|txt|html
+}
+
+// ChatArchivesResponse lists archived transcripts (newest first)
+type ChatArchivesResponse struct {
+	Archives []*ChatArchiveDTO `json:"archives"`
+}
+
+// LobbyChatJoinRequest opts a client into the café-wide lobby chat
+type LobbyChatJoinRequest struct {
+	UserName string `json:"user_name,omitempty"` // Defaults to the name used in the current room
+}
+
+// LobbyChatJoinedResponse confirms a lobby chat subscription with the latest messages
+type LobbyChatJoinedResponse struct {
+	UserName        string            `json:"user_name"`
+	CooldownSeconds int               `json:"cooldown_seconds"` // Minimum time between messages
+	Messages        []*ChatMessageDTO `json:"messages"`
+}
+
+// LobbyChatMessageRequest represents a message sent to the lobby chat
+type LobbyChatMessageRequest struct {
+	Content string `json:"content"`
+}
+
+// LobbyChatMuteRequest represents an admin muting someone in the lobby chat
+type LobbyChatMuteRequest struct {
+	UserID  string `json:"user_id"`
+	Minutes int    `json:"minutes"` // 0 lifts the mute
+}
+
+// LobbyChatMutedResponse confirms a lobby chat mute to the admin
+type LobbyChatMutedResponse struct {
+	UserID  string `json:"user_id"`
+	Minutes int    `json:"minutes"`
+}
+
+// DMSendRequest represents a request to send a direct message
+type DMSendRequest struct {
+	ToUserID string `json:"to_user_id"` // Online user ID or DM identity
+	Content  string `json:"content"`
+}
+
+// DirectMessageDTO represents a direct message for transfer
+type DirectMessageDTO struct {
+	ID            string    `json:"id"`
+	SenderID      string    `json:"sender_id"`
+	SenderName    string    `json:"sender_name"`
+	RecipientID   string    `json:"recipient_id"`
+	RecipientName string    `json:"recipient_name"`
+	Content       string    `json:"content"`
+	Timestamp     time.Time `json:"timestamp"`
+}
+
+// DMMessageEvent delivers a direct message to its sender and recipient
+type DMMessageEvent struct {
+	Message *DirectMessageDTO `json:"message"`
+}
+
+// DMFlaggedEvent tells admins a direct message was flagged for review
+type DMFlaggedEvent struct {
+	Message    *DirectMessageDTO         `json:"message"`
+	Violations []*ModerationViolationDTO `json:"violations"`
+}
+
+// GetDMHistoryRequest represents a request for a page of a direct message conversation
+type GetDMHistoryRequest struct {
+	UserID string `json:"user_id"`          // The other participant, by user ID or DM identity
+	Before string `json:"before,omitempty"` // Message ID to page back from
+	Limit  int    `json:"limit,omitempty"`
+}
+
+// DMHistoryResponse represents a page of a direct message conversation
+type DMHistoryResponse struct {
+	UserID   string              `json:"user_id"` // DM identity of the other participant
+	Messages []*DirectMessageDTO `json:"messages"`
+}
+
+// DMBlockRequest represents a request to block or unblock a user
+type DMBlockRequest struct {
+	UserID string `json:"user_id"` // Online user ID or DM identity
+}
+
+// DMBlockListResponse lists the DM identities a client has blocked
+type DMBlockListResponse struct {
+	UserIDs []string `json:"user_ids"`
+}
+
+// DMReportRequest represents a request to report a received direct message
+type DMReportRequest struct {
+	MessageID string `json:"message_id"`
+	Reason    string `json:"reason"`
+}
+
+// DMReportResponse confirms a report was filed
+type DMReportResponse struct {
+	ReportID string `json:"report_id"`
+}
+
+// AdminListDMReportsRequest represents an admin request for reported direct messages
+type AdminListDMReportsRequest struct {
+	Limit int `json:"limit,omitempty"`
+}
+
+// DMReportDTO represents a reported direct message for admins
+type DMReportDTO struct {
+	ID           string              `json:"id"`
+	ReporterID   string              `json:"reporter_id"`
+	ReporterName string              `json:"reporter_name"`
+	Reason       string              `json:"reason"`
+	Message      *DirectMessageDTO   `json:"message"`
+	Context      []*DirectMessageDTO `json:"context"` // Earlier messages, oldest first
+	CreatedAt    time.Time           `json:"created_at"`
+}
+
+// DMReportsResponse lists reported direct messages (newest first)
+type DMReportsResponse struct {
+	Reports []*DMReportDTO `json:"reports"`
+}
+
+// GetChatHistoryRequest requests a page of older chat messages in the current room
+type GetChatHistoryRequest struct {
+	Before string `json:"before,omitempty"` // Message ID or RFC 3339 timestamp; empty for the newest page
+	Limit  int    `json:"limit,omitempty"`
 }
 
 // ChatHistoryResponse represents chat history sent to client
 type ChatHistoryResponse struct {
 	Messages []*ChatMessageDTO `json:"messages"`
+	HasMore  bool              `json:"has_more"`
+	Before   string            `json:"before,omitempty"` // Echoes the request cursor for paged responses
 }
 
 // ChatMessageEvent represents a new chat message broadcast
@@ -253,7 +833,7 @@ type ChatReactionEvent struct {
 
 // ToChatMessageDTO converts a ChatMessage entity to DTO
 func ToChatMessageDTO(msg *entity.ChatMessage) *ChatMessageDTO {
-	return &ChatMessageDTO{
+	result := &ChatMessageDTO{
 		ID:         msg.ID,
 		Type:       string(msg.Type),
 		SenderID:   msg.SenderID,
@@ -261,7 +841,93 @@ func ToChatMessageDTO(msg *entity.ChatMessage) *ChatMessageDTO {
 		Content:    msg.Content,
 		Timestamp:  msg.Timestamp.UnixMilli(),
 		Reactions:  msg.Reactions,
+		ReplyTo:    msg.ReplyTo,
+		ReplyCount: msg.ReplyCount,
+		Mentions:   msg.Mentions,
+		AtHere:     msg.AtHere,
+		Code:       msg.Code,
+		Params:     msg.Params,
+	}
+	if msg.Quote != nil {
+		result.Quote = &ChatQuoteDTO{
+			MessageID:  msg.Quote.MessageID,
+			SenderName: msg.Quote.SenderName,
+			Snippet:    msg.Quote.Snippet,
+			Deleted:    msg.Quote.Deleted,
+		}
+	}
+	if len(msg.Attachments) > 0 {
+		result.Attachments = ToAttachmentDTOs(msg.Attachments)
+	}
+	if msg.Poll != nil {
+		result.Poll = ToPollDTO(msg.Poll, time.Now())
+	}
+	if msg.IsEdited() {
+		result.EditedAt = msg.EditedAt.UnixMilli()
+	}
+	if msg.IsDeleted() {
+		result.Deleted = true
+		result.DeletedBy = msg.DeletedBy
 	}
+	return result
+}
+
+// ToPollDTO converts a Poll entity to DTO, leaving out voters of anonymous polls
+func ToPollDTO(poll *entity.Poll, now time.Time) *PollDTO {
+	tally := poll.Tally()
+	voters := poll.Voters()
+
+	options := make([]*PollOptionDTO, len(poll.Options))
+	for i, text := range poll.Options {
+		options[i] = &PollOptionDTO{Text: text, Votes: tally[i]}
+		if !poll.Anonymous {
+			options[i].Voters = voters[i]
+		}
+	}
+
+	result := &PollDTO{
+		Question:    poll.Question,
+		Options:     options,
+		MultiChoice: poll.MultiChoice,
+		Anonymous:   poll.Anonymous,
+		Closed:      poll.IsClosed(now),
+		VoterCount:  len(poll.Votes),
+	}
+	if poll.ClosesAt != nil {
+		result.ClosesAt = poll.ClosesAt.UnixMilli()
+	}
+	return result
+}
+
+// AttachmentURL is where an attachment's file is served
+func AttachmentURL(att *entity.Attachment) string {
+	return "/api/uploads/" + att.RoomID + "/" + att.ID
+}
+
+// ToAttachmentDTO converts an Attachment entity to DTO
+func ToAttachmentDTO(att *entity.Attachment) *AttachmentDTO {
+	result := &AttachmentDTO{
+		ID:          att.ID,
+		FileName:    att.FileName,
+		ContentType: att.ContentType,
+		Size:        att.Size,
+		Width:       att.Width,
+		Height:      att.Height,
+		URL:         AttachmentURL(att),
+	}
+	if att.HasThumbnail {
+		result.ThumbnailURL = AttachmentURL(att) + "/thumbnail"
+	}
+	return result
+}
+
+// ToAttachmentDTOs converts a slice of Attachment entities to DTOs
+func ToAttachmentDTOs(attachments []*entity.Attachment) []*AttachmentDTO {
+	dtos := make([]*AttachmentDTO, len(attachments))
+	for i, att := range attachments {
+		dtos[i] = ToAttachmentDTO(att)
+	}
+	return dtos
 }
 
 // ToChatMessageDTOs converts a slice of ChatMessage entities to DTOs
@@ -273,13 +939,119 @@ func ToChatMessageDTOs(messages []*entity.ChatMessage) []*ChatMessageDTO {
 	return dtos
 }
 
+// ToChatSearchResultDTOs converts search results to DTOs
+func ToChatSearchResultDTOs(results []*repository.ChatSearchResult) []*ChatSearchResultDTO {
+	dtos := make([]*ChatSearchResultDTO, len(results))
+	for i, r := range results {
+		dtos[i] = &ChatSearchResultDTO{
+			RoomName: r.RoomName,
+			Message:  ToChatMessageDTO(r.Message),
+		}
+	}
+	return dtos
+}
+
+// ToModerationViolationDTOs converts moderation violations to DTOs
+func ToModerationViolationDTOs(violations []moderation.Violation) []*ModerationViolationDTO {
+	result := make([]*ModerationViolationDTO, len(violations))
+	for i, v := range violations {
+		result[i] = &ModerationViolationDTO{
+			Filter: v.Filter,
+			Action: string(v.Action),
+			Reason: v.Reason,
+		}
+	}
+	return result
+}
+
+// ToDirectMessageDTO converts a direct message entity to DTO
+func ToDirectMessageDTO(msg *entity.DirectMessage) *DirectMessageDTO {
+	return &DirectMessageDTO{
+		ID:            msg.ID,
+		SenderID:      msg.SenderID,
+		SenderName:    msg.SenderName,
+		RecipientID:   msg.RecipientID,
+		RecipientName: msg.RecipientName,
+		Content:       msg.Content,
+		Timestamp:     msg.Timestamp,
+	}
+}
+
+// ToDirectMessageDTOs converts a slice of direct messages to DTOs
+func ToDirectMessageDTOs(messages []*entity.DirectMessage) []*DirectMessageDTO {
+	result := make([]*DirectMessageDTO, len(messages))
+	for i, msg := range messages {
+		result[i] = ToDirectMessageDTO(msg)
+	}
+	return result
+}
+
+// ToDMReportDTOs converts reported direct messages to DTOs
+func ToDMReportDTOs(reports []*entity.DMReport) []*DMReportDTO {
+	result := make([]*DMReportDTO, len(reports))
+	for i, r := range reports {
+		result[i] = &DMReportDTO{
+			ID:           r.ID,
+			ReporterID:   r.ReporterID,
+			ReporterName: r.ReporterName,
+			Reason:       r.Reason,
+			Message:      ToDirectMessageDTO(r.Message),
+			Context:      ToDirectMessageDTOs(r.Context),
+			CreatedAt:    r.CreatedAt,
+		}
+	}
+	return result
+}
+
+// ToAnnouncementEvent converts an Announcement entity to an AnnouncementEvent
+func ToAnnouncementEvent(a *entity.Announcement) *AnnouncementEvent {
+	return &AnnouncementEvent{
+		ID:         a.ID,
+		Content:    a.Content,
+		SenderName: entity.AnnouncementSenderName,
+		Timestamp:  a.CreatedAt.UnixMilli(),
+	}
+}
+
+// ToScheduledAnnouncementDTOs converts scheduled announcement entities to DTOs
+func ToScheduledAnnouncementDTOs(schedules []*entity.ScheduledAnnouncement) []*ScheduledAnnouncementDTO {
+	dtos := make([]*ScheduledAnnouncementDTO, len(schedules))
+	for i, s := range schedules {
+		dtos[i] = &ScheduledAnnouncementDTO{
+			ID:        s.ID,
+			Content:   s.Content,
+			Cron:      s.Cron,
+			Target:    string(s.Target),
+			RoomNames: s.RoomNames,
+			Enabled:   s.Enabled,
+		}
+		if !s.LastRunAt.IsZero() {
+			dtos[i].LastRunAt = s.LastRunAt.UnixMilli()
+		}
+	}
+	return dtos
+}
+
+// ToMaintenanceEvent converts a maintenance window to a MaintenanceEvent
+func ToMaintenanceEvent(m *entity.MaintenanceWindow, now time.Time) *MaintenanceEvent {
+	return &MaintenanceEvent{
+		Message:          m.Message,
+		StartsAt:         m.StartsAt,
+		SecondsRemaining: int(m.TimeRemaining(now).Round(time.Second) / time.Second),
+		Active:           m.IsActive(now),
+	}
+}
+
 // ToParticipantDTO converts a User entity to ParticipantDTO
 func ToParticipantDTO(user *entity.User) *ParticipantDTO {
 	return &ParticipantDTO{
-		ID:      user.ID,
-		Name:    user.Name,
-		IsMuted: user.IsMuted,
-		IsAdmin: user.IsAdmin,
+		ID:         user.ID,
+		Name:       user.Name,
+		IsMuted:    user.IsMuted,
+		IsAdmin:    user.IsAdmin,
+		IsListener: user.IsListener,
+		IsAFK:      user.IsAFK,
+		AFKMessage: user.AFKMessage,
 	}
 }
 
@@ -301,6 +1073,10 @@ func ToRoomSummaryDTO(summary *room.RoomSummary) *RoomSummaryDTO {
 		ParticipantCount: summary.ParticipantCount,
 		Capacity:         summary.Capacity,
 		IsFull:           summary.IsFull,
+		VoicePolicy:      summary.VoicePolicy,
+		Description:      summary.Description,
+		Category:         summary.Category,
+		SortOrder:        summary.SortOrder,
 	}
 }
 
@@ -318,10 +1094,12 @@ func ToRoomInfoDTO(output *room.GetRoomOutput) *RoomInfoResponse {
 	participants := make([]*ParticipantDTO, len(output.Participants))
 	for i, p := range output.Participants {
 		participants[i] = &ParticipantDTO{
-			ID:      p.ID,
-			Name:    p.Name,
-			IsMuted: p.IsMuted,
-			IsAdmin: p.IsAdmin,
+			ID:         p.ID,
+			Name:       p.Name,
+			IsMuted:    p.IsMuted,
+			IsAdmin:    p.IsAdmin,
+			IsListener: p.IsListener,
+			IsAFK:      p.IsAFK,
 		}
 	}
 	return &RoomInfoResponse{
@@ -332,5 +1110,64 @@ func ToRoomInfoDTO(output *room.GetRoomOutput) *RoomInfoResponse {
 		Participants: participants,
 		CanJoin:      output.CanJoin,
 		IsClosed:     output.IsClosed,
+		VoicePolicy:  string(output.VoicePolicy),
+		Description:  output.Description,
+		Category:     output.Category,
+		SlowMode:     int(output.SlowMode / time.Second),
+		Topic:        output.Topic,
+		Pinned:       ToPinnedMessageDTOs(output.Pinned),
 	}
 }
+
+// ToPinnedMessageDTOs converts a room's pinned messages to DTOs
+func ToPinnedMessageDTOs(pinned []*entity.PinnedMessage) []*PinnedMessageDTO {
+	dtos := make([]*PinnedMessageDTO, len(pinned))
+	for i, p := range pinned {
+		dtos[i] = &PinnedMessageDTO{
+			Message:  ToChatMessageDTO(p.Message),
+			PinnedBy: p.PinnedBy,
+			PinnedAt: p.PinnedAt.UnixMilli(),
+		}
+	}
+	return dtos
+}
+
+// ToRoomUpdatedEvent builds a room_updated event from a room's current state
+func ToRoomUpdatedEvent(room *entity.Room, updatedBy string) RoomUpdatedEvent {
+	return RoomUpdatedEvent{
+		RoomID:    room.ID,
+		Topic:     room.GetTopic(),
+		Pinned:    ToPinnedMessageDTOs(room.GetPinned()),
+		UpdatedBy: updatedBy,
+	}
+}
+
+// ToRoomRetentionResponse converts a room's retention policy to DTO
+func ToRoomRetentionResponse(room *entity.Room) RoomRetentionResponse {
+	policy := room.GetRetention()
+	return RoomRetentionResponse{
+		RoomID:   room.ID,
+		Mode:     string(policy.Mode),
+		Messages: policy.Messages,
+		Days:     policy.Days,
+	}
+}
+
+// ToChatArchiveDTOs converts archive metadata to DTOs
+func ToChatArchiveDTOs(archives []*entity.ChatArchive) []*ChatArchiveDTO {
+	dtos := make([]*ChatArchiveDTO, len(archives))
+	for i, a := range archives {
+		dtos[i] = &ChatArchiveDTO{
+			ID:             a.ID,
+			RoomID:         a.RoomID,
+			RoomName:       a.RoomName,
+			MessageCount:   a.MessageCount,
+			FirstMessageAt: a.FirstMessageAt.UnixMilli(),
+			LastMessageAt:  a.LastMessageAt.UnixMilli(),
+			ArchivedAt:     a.ArchivedAt.UnixMilli(),
+			Size:           a.Size,
+			URL:            "/api/archives/" + a.ID,
+		}
+	}
+	return dtos
+}
@@ -0,0 +1,66 @@
+package handler
+
+import (
+	"encoding/json"
+	"log"
+
+	"voice-chat/internal/interface/dto"
+	"voice-chat/internal/usecase/admin"
+)
+
+func (h *WebSocketHandler) handleAdminAuth(client *Client, payload json.RawMessage) {
+	var req dto.AdminAuthRequest
+	if err := json.Unmarshal(payload, &req); err != nil {
+		h.sendError(client, "INVALID_PAYLOAD", "Invalid admin auth request")
+		return
+	}
+
+	result, err := h.adminAuthUC.Execute(admin.AdminAuthInput{
+		Password: req.Password,
+		IP:       client.IP,
+	})
+	if err != nil {
+		log.Printf("Admin auth failed for UserID=%s from %s: %v", client.UserID, client.IP, err)
+		h.sendToClient(client, "admin_auth_result", dto.AdminAuthResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	client.IsAdmin = true
+	log.Printf("Admin authenticated: UserID=%s from %s", client.UserID, client.IP)
+
+	h.sendToClient(client, "admin_auth_result", dto.AdminAuthResponse{
+		Success: result.IsAuthenticated,
+		Message: result.Message,
+	})
+}
+
+// requireAdmin sends an error and returns false if the client is not an authenticated admin
+func (h *WebSocketHandler) requireAdmin(client *Client) bool {
+	if !client.IsAdmin {
+		h.sendError(client, "NOT_AUTHORIZED", admin.ErrNotAuthorized.Error())
+		return false
+	}
+	return true
+}
+
+func (h *WebSocketHandler) handleAdminSetListenRoles(client *Client, payload json.RawMessage) {
+	if !h.requireAdmin(client) {
+		return
+	}
+
+	var req dto.AdminSetListenRolesRequest
+	if err := json.Unmarshal(payload, &req); err != nil {
+		h.sendError(client, "INVALID_PAYLOAD", "Invalid listen roles request")
+		return
+	}
+
+	h.listenRoomUC.SetAllowedRoles(req.Roles)
+	log.Printf("Admin %s set listen roles: %v", client.UserID, req.Roles)
+
+	h.sendToClient(client, "listen_roles", dto.ListenRolesResponse{
+		Roles: h.listenRoomUC.AllowedRoles(),
+	})
+}
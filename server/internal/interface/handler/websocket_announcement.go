@@ -0,0 +1,274 @@
+package handler
+
+import (
+	"encoding/json"
+	"log"
+	"sort"
+	"strings"
+
+	"voice-chat/internal/domain/entity"
+	"voice-chat/internal/interface/dto"
+	"voice-chat/internal/usecase/admin"
+)
+
+// paDuckVolume is the volume hint clients apply to other speakers during a voice PA
+const paDuckVolume = 0.2
+
+// paIdentity returns the LiveKit identity used by an admin during a voice PA
+func paIdentity(adminID string) string {
+	return "pa-" + adminID
+}
+
+func (h *WebSocketHandler) handleBroadcastAnnouncement(client *Client, payload json.RawMessage) {
+	if !h.requireAdmin(client) {
+		return
+	}
+
+	var req dto.BroadcastAnnouncementRequest
+	if err := json.Unmarshal(payload, &req); err != nil {
+		h.sendError(client, "INVALID_PAYLOAD", "Invalid announcement request")
+		return
+	}
+
+	if req.Content == "" && !req.Voice {
+		h.sendError(client, "INVALID_PAYLOAD", admin.ErrEmptyAnnouncement.Error())
+		return
+	}
+
+	if req.Content != "" {
+		result, err := h.announcementUC.Broadcast(admin.BroadcastAnnouncementInput{
+			AdminID:   client.UserID,
+			Content:   req.Content,
+			Target:    entity.AnnouncementTarget(req.Target),
+			RoomNames: req.RoomNames,
+		})
+		if err != nil {
+			h.sendError(client, "ANNOUNCEMENT_FAILED", err.Error())
+			return
+		}
+		h.DeliverAnnouncement(result)
+		if len(result.FailedRooms) > 0 {
+			h.sendError(client, "ANNOUNCEMENT_FAILED", "Announced, but not saved to the chat of: "+strings.Join(sortedNames(result.FailedRooms), ", "))
+		}
+	}
+
+	if req.Voice {
+		h.startVoicePA(client)
+	}
+}
+
+// DeliverAnnouncement sends an announcement to its target clients and its system message to each room
+func (h *WebSocketHandler) DeliverAnnouncement(result *admin.BroadcastAnnouncementOutput) {
+	event := dto.ToAnnouncementEvent(result.Announcement)
+
+	switch result.Announcement.Target {
+	case entity.AnnouncementTargetLobby:
+		h.broadcastToLobby("announcement", event)
+	case entity.AnnouncementTargetRooms:
+		for roomID := range result.SystemMessages {
+			h.broadcastToRoomAll(roomID, "announcement", event)
+		}
+		for roomID := range result.FailedRooms {
+			h.broadcastToRoomAll(roomID, "announcement", event)
+		}
+	default:
+		h.broadcastToAll("announcement", event)
+	}
+
+	for roomID, msg := range result.SystemMessages {
+		h.broadcastToRoomAll(roomID, "chat_message", dto.ChatMessageEvent{
+			Message: dto.ToChatMessageDTO(msg),
+		})
+	}
+
+	log.Printf("Announcement %s delivered to %d rooms", result.Announcement.ID, len(result.SystemMessages))
+}
+
+// startVoicePA mints publish tokens for every active room and tells rooms to duck other speakers
+func (h *WebSocketHandler) startVoicePA(client *Client) {
+	rooms, err := h.announcementUC.StartVoicePA(client.UserID)
+	if err != nil {
+		h.sendError(client, "VOICE_PA_FAILED", err.Error())
+		return
+	}
+
+	identity := paIdentity(client.UserID)
+	tokens := make([]*dto.VoicePARoomToken, 0, len(rooms))
+	for _, r := range rooms {
+		token, err := h.tokenService.GenerateAnnouncerToken(r.Name, identity)
+		if err != nil {
+			log.Printf("ERROR: Failed to generate PA token for room %s: %v", r.Name, err)
+			continue
+		}
+		tokens = append(tokens, &dto.VoicePARoomToken{
+			RoomID:       r.ID,
+			RoomName:     r.Name,
+			LiveKitToken: token,
+		})
+	}
+
+	if len(tokens) == 0 {
+		h.announcementUC.StopVoicePA(client.UserID)
+		h.sendError(client, "TOKEN_FAILED", "Failed to generate voice tokens")
+		return
+	}
+
+	h.sendToClient(client, "voice_pa_tokens", dto.VoicePATokensResponse{
+		LiveKitURL: h.config.LiveKitPublicURL,
+		Rooms:      tokens,
+	})
+
+	started := dto.VoicePAStartedEvent{
+		SpeakerIdentity: identity,
+		DuckOthers:      true,
+		DuckVolume:      paDuckVolume,
+	}
+	for _, t := range tokens {
+		h.broadcastToRoomAll(t.RoomID, "voice_pa_started", started)
+	}
+
+	log.Printf("Voice PA started by admin %s in %d rooms", client.UserID, len(tokens))
+}
+
+func (h *WebSocketHandler) handleStopVoicePA(client *Client) {
+	if !h.requireAdmin(client) {
+		return
+	}
+
+	h.stopVoicePA(client)
+}
+
+// stopVoicePA ends the admin's voice PA and restores normal volume in the affected rooms
+func (h *WebSocketHandler) stopVoicePA(client *Client) {
+	rooms, err := h.announcementUC.StopVoicePA(client.UserID)
+	if err != nil {
+		h.sendError(client, "VOICE_PA_FAILED", err.Error())
+		return
+	}
+
+	ended := dto.VoicePAEndedEvent{SpeakerIdentity: paIdentity(client.UserID)}
+	for _, r := range rooms {
+		h.broadcastToRoomAll(r.ID, "voice_pa_ended", ended)
+	}
+	h.sendToClient(client, "voice_pa_ended", ended)
+
+	log.Printf("Voice PA stopped by admin %s", client.UserID)
+}
+
+func (h *WebSocketHandler) handleAdminScheduleAnnouncement(client *Client, payload json.RawMessage) {
+	if !h.requireAdmin(client) {
+		return
+	}
+
+	var req dto.ScheduleAnnouncementRequest
+	if err := json.Unmarshal(payload, &req); err != nil {
+		h.sendError(client, "INVALID_PAYLOAD", "Invalid scheduled announcement request")
+		return
+	}
+
+	scheduled, err := h.scheduleUC.Create(admin.ScheduleAnnouncementInput{
+		AdminID:   client.UserID,
+		Content:   req.Content,
+		Cron:      req.Cron,
+		Target:    entity.AnnouncementTarget(req.Target),
+		RoomNames: req.RoomNames,
+	})
+	if err != nil {
+		h.sendError(client, "SCHEDULE_FAILED", err.Error())
+		return
+	}
+
+	log.Printf("Admin %s scheduled announcement %s (%s)", client.UserID, scheduled.ID, scheduled.Cron)
+	h.sendScheduledAnnouncements(client)
+}
+
+func (h *WebSocketHandler) handleAdminSetScheduledAnnouncementEnabled(client *Client, payload json.RawMessage) {
+	if !h.requireAdmin(client) {
+		return
+	}
+
+	var req dto.ScheduledAnnouncementIDRequest
+	if err := json.Unmarshal(payload, &req); err != nil {
+		h.sendError(client, "INVALID_PAYLOAD", "Invalid scheduled announcement request")
+		return
+	}
+
+	if _, err := h.scheduleUC.SetEnabled(req.ID, req.Enabled); err != nil {
+		h.sendError(client, "SCHEDULE_NOT_FOUND", err.Error())
+		return
+	}
+
+	h.sendScheduledAnnouncements(client)
+}
+
+func (h *WebSocketHandler) handleAdminDeleteScheduledAnnouncement(client *Client, payload json.RawMessage) {
+	if !h.requireAdmin(client) {
+		return
+	}
+
+	var req dto.ScheduledAnnouncementIDRequest
+	if err := json.Unmarshal(payload, &req); err != nil {
+		h.sendError(client, "INVALID_PAYLOAD", "Invalid scheduled announcement request")
+		return
+	}
+
+	if err := h.scheduleUC.Delete(req.ID); err != nil {
+		h.sendError(client, "SCHEDULE_NOT_FOUND", err.Error())
+		return
+	}
+
+	h.sendScheduledAnnouncements(client)
+}
+
+func (h *WebSocketHandler) handleAdminListScheduledAnnouncements(client *Client) {
+	if !h.requireAdmin(client) {
+		return
+	}
+
+	h.sendScheduledAnnouncements(client)
+}
+
+// sendScheduledAnnouncements sends the current list of scheduled announcements to an admin
+func (h *WebSocketHandler) sendScheduledAnnouncements(client *Client) {
+	schedules, err := h.scheduleUC.List()
+	if err != nil {
+		h.sendError(client, "SCHEDULE_FAILED", err.Error())
+		return
+	}
+
+	h.sendToClient(client, "scheduled_announcements", dto.ScheduledAnnouncementsResponse{
+		Schedules: dto.ToScheduledAnnouncementDTOs(schedules),
+	})
+}
+
+func (h *WebSocketHandler) handleAdminSetMessageOfTheDay(client *Client, payload json.RawMessage) {
+	if !h.requireAdmin(client) {
+		return
+	}
+
+	var req dto.SetMessageOfTheDayRequest
+	if err := json.Unmarshal(payload, &req); err != nil {
+		h.sendError(client, "INVALID_PAYLOAD", "Invalid message of the day request")
+		return
+	}
+
+	if err := h.scheduleUC.SetMessageOfTheDay(req.MessageOfTheDay); err != nil {
+		h.sendError(client, "MOTD_FAILED", err.Error())
+		return
+	}
+
+	log.Printf("Admin %s updated the message of the day", client.UserID)
+	h.sendToClient(client, "motd", dto.MessageOfTheDayResponse{
+		MessageOfTheDay: h.scheduleUC.MessageOfTheDay(),
+	})
+}
+
+// sortedNames returns the values of an ID -> name map in alphabetical order
+func sortedNames(names map[string]string) []string {
+	sorted := make([]string, 0, len(names))
+	for _, name := range names {
+		sorted = append(sorted, name)
+	}
+	sort.Strings(sorted)
+	return sorted
+}
@@ -0,0 +1,100 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"voice-chat/internal/interface/dto"
+	"voice-chat/internal/usecase/chat"
+)
+
+func (h *WebSocketHandler) handleTyping(client *Client, payload json.RawMessage) {
+	if client.RoomID == "" {
+		return // Typing outside a room is meaningless, not worth an error
+	}
+
+	var req dto.TypingRequest
+	if err := json.Unmarshal(payload, &req); err != nil {
+		h.sendError(client, "INVALID_PAYLOAD", "Invalid typing request")
+		return
+	}
+
+	if !req.Typing {
+		h.stopTyping(client)
+		return
+	}
+
+	if !h.typingUC.Start(client.RoomID, client.UserID, client.UserName, time.Now()) {
+		return
+	}
+
+	h.broadcastToRoom(client.RoomID, client.UserID, "typing", dto.TypingEvent{
+		RoomID:    client.RoomID,
+		UserID:    client.UserID,
+		UserName:  client.UserName,
+		Typing:    true,
+		ExpiresIn: int(chat.TypingTimeout / time.Second),
+	})
+}
+
+// stopTyping clears the client's typing state and tells the room if it was set
+func (h *WebSocketHandler) stopTyping(client *Client) {
+	if client.RoomID == "" || !h.typingUC.Stop(client.RoomID, client.UserID) {
+		return
+	}
+
+	h.broadcastToRoom(client.RoomID, client.UserID, "typing", dto.TypingEvent{
+		RoomID:   client.RoomID,
+		UserID:   client.UserID,
+		UserName: client.UserName,
+		Typing:   false,
+	})
+}
+
+// RunTypingExpiry clears typing indicators that clients stopped refreshing until ctx is cancelled
+func (h *WebSocketHandler) RunTypingExpiry(ctx context.Context) {
+	ticker := time.NewTicker(1 * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			for _, change := range h.typingUC.Expire(now) {
+				h.broadcastToRoom(change.RoomID, change.UserID, "typing", dto.TypingEvent{
+					RoomID:   change.RoomID,
+					UserID:   change.UserID,
+					UserName: change.UserName,
+					Typing:   false,
+				})
+			}
+		}
+	}
+}
+
+func (h *WebSocketHandler) handleMarkRead(client *Client, payload json.RawMessage) {
+	if client.RoomID == "" {
+		h.sendError(client, "NOT_IN_ROOM", "You must join a room first")
+		return
+	}
+
+	var req dto.MarkReadRequest
+	if err := json.Unmarshal(payload, &req); err != nil || req.MessageID == "" {
+		h.sendError(client, "INVALID_PAYLOAD", "Invalid mark read request")
+		return
+	}
+
+	state, err := h.readStateUC.MarkRead(client.SessionID, client.RoomID, req.MessageID)
+	if err != nil {
+		h.sendError(client, "MESSAGE_NOT_FOUND", err.Error())
+		return
+	}
+
+	h.sendToClient(client, "read_marker", dto.ReadMarkerEvent{
+		RoomID:      state.RoomID,
+		LastReadID:  state.LastReadID,
+		UnreadCount: state.UnreadCount,
+	})
+}
@@ -0,0 +1,50 @@
+package handler
+
+import (
+	"errors"
+	"log"
+
+	"voice-chat/internal/interface/dto"
+	"voice-chat/internal/usecase/command"
+)
+
+// handleCommand runs a slash command typed into room chat
+func (h *WebSocketHandler) handleCommand(client *Client, content string) {
+	result, err := h.commandUC.Execute(command.ExecuteInput{
+		RoomID:   client.RoomID,
+		UserID:   client.UserID,
+		UserName: client.UserName,
+		IsAdmin:  client.IsAdmin,
+		Content:  content,
+	})
+	if errors.Is(err, command.ErrUnknownCommand) {
+		h.sendError(client, "UNKNOWN_COMMAND", err.Error())
+		return
+	}
+	if errors.Is(err, command.ErrCommandNotAllowed) {
+		h.sendError(client, "NOT_AUTHORIZED", err.Error())
+		return
+	}
+	if err != nil {
+		h.sendError(client, "COMMAND_FAILED", err.Error())
+		return
+	}
+
+	if result.Reply != "" {
+		h.sendToClient(client, "command_reply", dto.CommandReplyEvent{
+			Command: result.Command,
+			Content: result.Reply,
+		})
+	}
+
+	if result.Message != nil {
+		log.Printf("UserID=%s ran /%s in room %s", client.UserID, result.Command, client.RoomID)
+		h.broadcastToRoomAll(client.RoomID, "chat_message", dto.ChatMessageEvent{
+			Message: dto.ToChatMessageDTO(result.Message),
+		})
+	}
+
+	if result.RoomUpdated != nil {
+		h.broadcastToRoomAll(client.RoomID, "room_updated", dto.ToRoomUpdatedEvent(result.RoomUpdated, client.UserName))
+	}
+}
@@ -0,0 +1,205 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+
+	"voice-chat/internal/domain/entity"
+	"voice-chat/internal/interface/dto"
+	"voice-chat/internal/usecase/dm"
+	"voice-chat/internal/usecase/moderation"
+)
+
+func (h *WebSocketHandler) handleDMSend(client *Client, payload json.RawMessage) {
+	var req dto.DMSendRequest
+	if err := json.Unmarshal(payload, &req); err != nil || req.ToUserID == "" {
+		h.sendError(client, "INVALID_PAYLOAD", "Invalid direct message request")
+		return
+	}
+
+	// Names are chosen when joining a room
+	if client.UserName == "" {
+		h.sendError(client, "NOT_IN_ROOM", "Join a room before sending direct messages")
+		return
+	}
+
+	recipientID, recipients := h.dmRecipients(req.ToUserID)
+	if len(recipients) == 0 {
+		h.sendError(client, "USER_NOT_FOUND", "User is not online")
+		return
+	}
+
+	msg, review, err := h.dmUC.Send(dm.SendMessageInput{
+		SenderID:      client.DMID,
+		SenderName:    client.UserName,
+		RecipientID:   recipientID,
+		RecipientName: recipients[0].UserName,
+		Content:       req.Content,
+		IP:            client.IP,
+	})
+	if errors.Is(err, dm.ErrRecipientBlocked) || errors.Is(err, dm.ErrSenderBlocked) {
+		h.sendError(client, "DM_BLOCKED", err.Error())
+		return
+	}
+	if errors.Is(err, moderation.ErrMessageBlocked) {
+		h.sendError(client, "MESSAGE_BLOCKED", err.Error())
+		return
+	}
+	if err != nil {
+		h.sendError(client, "DM_FAILED", err.Error())
+		return
+	}
+
+	// Echo to every connection of the sender as confirmation, like room chat
+	msgDTO := dto.ToDirectMessageDTO(msg)
+	event := dto.DMMessageEvent{Message: msgDTO}
+	_, senders := h.dmRecipients(client.DMID)
+	for _, c := range append(senders, recipients...) {
+		h.sendToClient(c, "dm_message", event)
+	}
+
+	if review != nil {
+		h.notifyDMModeration(client, msgDTO, review)
+	}
+}
+
+// dmRecipients resolves an online user ID or a DM identity to the DM identity and the
+// connections currently using it. Unknown IDs are taken to be the identity of someone offline.
+func (h *WebSocketHandler) dmRecipients(id string) (string, []*Client) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	dmID := id
+	if c, online := h.clients[id]; online {
+		dmID = c.DMID
+	}
+
+	clients := make([]*Client, 0, 1)
+	for _, c := range h.clients {
+		if c.DMID == dmID {
+			clients = append(clients, c)
+		}
+	}
+	return dmID, clients
+}
+
+func (h *WebSocketHandler) handleGetDMHistory(client *Client, payload json.RawMessage) {
+	var req dto.GetDMHistoryRequest
+	if err := json.Unmarshal(payload, &req); err != nil || req.UserID == "" {
+		h.sendError(client, "INVALID_PAYLOAD", "Invalid direct message history request")
+		return
+	}
+
+	otherID, _ := h.dmRecipients(req.UserID)
+	messages, err := h.dmUC.History(client.DMID, otherID, req.Before, req.Limit)
+	if err != nil {
+		h.sendError(client, "MESSAGE_NOT_FOUND", "Message not found")
+		return
+	}
+
+	h.sendToClient(client, "dm_history", dto.DMHistoryResponse{
+		UserID:   otherID,
+		Messages: dto.ToDirectMessageDTOs(messages),
+	})
+}
+
+func (h *WebSocketHandler) handleDMBlock(client *Client, payload json.RawMessage, block bool) {
+	var req dto.DMBlockRequest
+	if err := json.Unmarshal(payload, &req); err != nil || req.UserID == "" {
+		h.sendError(client, "INVALID_PAYLOAD", "Invalid block request")
+		return
+	}
+
+	otherID, _ := h.dmRecipients(req.UserID)
+
+	var blocked []string
+	var err error
+	if block {
+		blocked, err = h.dmBlockUC.Block(client.DMID, otherID)
+	} else {
+		blocked, err = h.dmBlockUC.Unblock(client.DMID, otherID)
+	}
+	if err != nil {
+		h.sendError(client, "BLOCK_FAILED", err.Error())
+		return
+	}
+
+	h.sendToClient(client, "dm_block_list", dto.DMBlockListResponse{UserIDs: blocked})
+}
+
+func (h *WebSocketHandler) handleGetDMBlocks(client *Client) {
+	blocked, err := h.dmBlockUC.Blocked(client.DMID)
+	if err != nil {
+		h.sendError(client, "BLOCK_FAILED", err.Error())
+		return
+	}
+
+	h.sendToClient(client, "dm_block_list", dto.DMBlockListResponse{UserIDs: blocked})
+}
+
+func (h *WebSocketHandler) handleDMReport(client *Client, payload json.RawMessage) {
+	var req dto.DMReportRequest
+	if err := json.Unmarshal(payload, &req); err != nil {
+		h.sendError(client, "INVALID_PAYLOAD", "Invalid report request")
+		return
+	}
+
+	report, err := h.dmReportUC.Report(dm.ReportInput{
+		ReporterID:   client.DMID,
+		ReporterName: client.UserName,
+		MessageID:    req.MessageID,
+		Reason:       req.Reason,
+		IP:           client.IP,
+	})
+	if err != nil {
+		h.sendError(client, "REPORT_FAILED", err.Error())
+		return
+	}
+
+	log.Printf("UserID=%s reported direct message %s from %s", client.UserID, report.Message.ID, report.Message.SenderID)
+	h.sendToClient(client, "dm_report_result", dto.DMReportResponse{ReportID: report.ID})
+
+	// Let online admins know there is something to review
+	h.broadcastToAdmins("dm_reported", dto.ToDMReportDTOs([]*entity.DMReport{report})[0])
+}
+
+// broadcastToAdmins sends a message to every authenticated admin
+func (h *WebSocketHandler) broadcastToAdmins(msgType string, payload interface{}) {
+	h.mu.RLock()
+	admins := make([]*Client, 0)
+	for _, client := range h.clients {
+		if client.IsAdmin {
+			admins = append(admins, client)
+		}
+	}
+	h.mu.RUnlock()
+
+	for _, client := range admins {
+		h.sendToClient(client, msgType, payload)
+	}
+}
+
+func (h *WebSocketHandler) handleAdminListDMReports(client *Client, payload json.RawMessage) {
+	if !h.requireAdmin(client) {
+		return
+	}
+
+	var req dto.AdminListDMReportsRequest
+	if len(payload) > 0 {
+		if err := json.Unmarshal(payload, &req); err != nil {
+			h.sendError(client, "INVALID_PAYLOAD", "Invalid reports request")
+			return
+		}
+	}
+
+	reports, err := h.dmReportUC.Reports(req.Limit)
+	if err != nil {
+		h.sendError(client, "REPORTS_FAILED", err.Error())
+		return
+	}
+
+	h.sendToClient(client, "dm_reports", dto.DMReportsResponse{
+		Reports: dto.ToDMReportDTOs(reports),
+	})
+}
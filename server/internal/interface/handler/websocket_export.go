@@ -0,0 +1,122 @@
+package handler
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"voice-chat/internal/usecase/chat"
+)
+
+// HandleChatExport downloads a room's chat transcript:
+//
+//	GET /api/export/<roomID>?format=jsonl|txt|html&from=<RFC 3339>&to=<RFC 3339>
+//	Authorization: Bearer <session token>
+//
+// The session must belong to an authenticated admin, or to the room's owner when owner exports
+// are enabled.
+func (h *WebSocketHandler) HandleChatExport(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		h.writeAPIError(w, r, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", 0)
+		return
+	}
+
+	client := h.exportClient(w, r)
+	if client == nil {
+		return
+	}
+
+	roomID := strings.Trim(strings.TrimPrefix(r.URL.Path, "/api/export/"), "/")
+	if roomID == "" || strings.Contains(roomID, "/") {
+		http.NotFound(w, r)
+		return
+	}
+
+	query := r.URL.Query()
+	format, err := chat.ParseExportFormat(query.Get("format"))
+	if err != nil {
+		h.writeAPIError(w, r, http.StatusBadRequest, "INVALID_FORMAT", err.Error(), 0)
+		return
+	}
+	from, fromErr := parseExportTime(query.Get("from"))
+	to, toErr := parseExportTime(query.Get("to"))
+	if fromErr != nil || toErr != nil {
+		h.writeAPIError(w, r, http.StatusBadRequest, "INVALID_RANGE", "from and to must be RFC 3339 timestamps", 0)
+		return
+	}
+
+	export, err := h.exportUC.Export(chat.ExportInput{
+		RoomID:  roomID,
+		UserID:  client.UserID,
+		IsAdmin: client.IsAdmin,
+		Format:  format,
+		From:    from,
+		To:      to,
+	})
+	switch {
+	case errors.Is(err, chat.ErrNotAllowedToExport):
+		h.writeAPIError(w, r, http.StatusForbidden, "NOT_AUTHORIZED", err.Error(), 0)
+		return
+	case errors.Is(err, chat.ErrRoomNotFound):
+		h.writeAPIError(w, r, http.StatusNotFound, "ROOM_NOT_FOUND", err.Error(), 0)
+		return
+	case errors.Is(err, chat.ErrInvalidExportRange):
+		h.writeAPIError(w, r, http.StatusBadRequest, "INVALID_RANGE", err.Error(), 0)
+		return
+	case err != nil:
+		log.Printf("Error exporting chat of room %s for UserID=%s: %v", roomID, client.UserID, err)
+		h.writeAPIError(w, r, http.StatusInternalServerError, "EXPORT_FAILED", "Failed to export the chat", 0)
+		return
+	}
+
+	client.mu.Lock()
+	locale := client.Locale
+	client.mu.Unlock()
+	h.localizeExport(locale, export)
+
+	log.Printf("UserID=%s exported %d messages from room %s as %s", client.UserID, len(export.Messages), roomID, format)
+	writeChatExport(w, export)
+}
+
+// exportClient authenticates a transcript download by session token and rate limits it,
+// writing the error response and returning nil when the request can't go ahead
+func (h *WebSocketHandler) exportClient(w http.ResponseWriter, r *http.Request) *Client {
+	client := h.clientBySession(strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer "))
+	if client == nil {
+		h.writeAPIError(w, r, http.StatusUnauthorized, "NOT_AUTHORIZED", "A valid session token is required", 0)
+		return nil
+	}
+
+	decision := h.floodUC.Check(chat.FloodCheckInput{
+		ConnectionID: client.ID,
+		SessionID:    client.SessionID,
+		IP:           client.IP,
+		MessageType:  "export",
+	}, time.Now())
+	if !decision.Allowed {
+		h.writeAPIError(w, r, http.StatusTooManyRequests, "RATE_LIMITED", "You are exporting too often, slow down", decision.RetryAfter)
+		return nil
+	}
+	return client
+}
+
+// writeChatExport sends a transcript as a file download
+func writeChatExport(w http.ResponseWriter, export *chat.ChatExport) {
+	w.Header().Set("Content-Type", export.Format.ContentType())
+	w.Header().Set("Content-Disposition", `attachment; filename="`+export.FileName()+`"`)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.Header().Set("Cache-Control", "no-store")
+	if err := export.Write(w); err != nil {
+		log.Printf("Error writing chat export of room %s: %v", export.RoomID, err)
+	}
+}
+
+// parseExportTime reads an optional RFC 3339 timestamp
+func parseExportTime(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	return time.Parse(time.RFC3339Nano, value)
+}
@@ -0,0 +1,98 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"time"
+
+	"voice-chat/internal/interface/dto"
+	"voice-chat/internal/usecase/chat"
+)
+
+// allowMessage applies flood control to an incoming message, telling the client when it is
+// warned or muted. Dropped messages get no reply so a flood isn't answered with one.
+func (h *WebSocketHandler) allowMessage(client *Client, msgType string) bool {
+	decision := h.floodUC.Check(chat.FloodCheckInput{
+		ConnectionID: client.ID,
+		SessionID:    client.SessionID,
+		IP:           client.IP,
+		MessageType:  msgType,
+	}, time.Now())
+	if decision.Allowed {
+		return true
+	}
+
+	switch decision.Action {
+	case chat.FloodActionWarn:
+		h.sendRetryError(client, "RATE_LIMITED", "You are sending messages too fast, slow down", decision.RetryAfter)
+	case chat.FloodActionMute:
+		if decision.NewlyMuted {
+			log.Printf("UserID=%s from %s muted for flooding (%s)", client.UserID, client.IP, msgType)
+			h.sendToClient(client, "chat_muted", dto.ChatMutedEvent{
+				DurationMs: decision.RetryAfter.Milliseconds(),
+				Reason:     "Sending messages too fast",
+			})
+			return false
+		}
+		h.sendRetryError(client, "CHAT_MUTED", "You are temporarily muted for flooding", decision.RetryAfter)
+	}
+	return false
+}
+
+func (h *WebSocketHandler) handleSetSlowMode(client *Client, payload json.RawMessage) {
+	if client.RoomID == "" {
+		h.sendError(client, "NOT_IN_ROOM", "You must join a room first")
+		return
+	}
+
+	var req dto.SetSlowModeRequest
+	if err := json.Unmarshal(payload, &req); err != nil {
+		h.sendError(client, "INVALID_PAYLOAD", "Invalid slow mode request")
+		return
+	}
+
+	room, err := h.slowModeUC.Set(chat.SetSlowModeInput{
+		RoomID:   client.RoomID,
+		UserID:   client.UserID,
+		IsAdmin:  client.IsAdmin,
+		Cooldown: time.Duration(req.Seconds) * time.Second,
+	})
+	if errors.Is(err, chat.ErrNotAllowedToSetSlowMode) {
+		h.sendError(client, "NOT_AUTHORIZED", err.Error())
+		return
+	}
+	if err != nil {
+		h.sendError(client, "SLOW_MODE_FAILED", err.Error())
+		return
+	}
+
+	log.Printf("UserID=%s set slow mode in room %s to %ds", client.UserID, room.ID, req.Seconds)
+	h.broadcastToRoomAll(room.ID, "slow_mode_changed", dto.SlowModeEvent{
+		RoomID:  room.ID,
+		Seconds: int(room.GetSlowMode() / time.Second),
+		SetBy:   client.UserName,
+	})
+}
+
+// RunFloodCleanup forgets idle rate limit state and unused uploads until ctx is cancelled
+func (h *WebSocketHandler) RunFloodCleanup(ctx context.Context) {
+	ticker := time.NewTicker(1 * time.Minute)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			h.floodUC.Cleanup(now)
+			h.slowModeUC.Cleanup(now)
+			h.roomMuteUC.Cleanup(now)
+			h.lobbyChatUC.Cleanup(now)
+			if n := h.attachUC.Cleanup(now); n > 0 {
+				log.Printf("Deleted %d unused uploads", n)
+			}
+		}
+	}
+}
@@ -2,6 +2,7 @@ package handler
 
 import (
 	"encoding/json"
+	"errors"
 	"log"
 	"net/http"
 	"sync"
@@ -15,8 +16,14 @@ import (
 	"voice-chat/internal/infrastructure/livekit"
 	"voice-chat/internal/interface/dto"
 	"voice-chat/internal/usecase/admin"
+	"voice-chat/internal/usecase/attachment"
+	"voice-chat/internal/usecase/chat"
+	"voice-chat/internal/usecase/command"
+	"voice-chat/internal/usecase/dm"
+	"voice-chat/internal/usecase/moderation"
 	"voice-chat/internal/usecase/room"
 	"voice-chat/pkg/config"
+	"voice-chat/pkg/i18n"
 )
 
 var upgrader = websocket.Upgrader{
@@ -30,25 +37,64 @@ type Client struct {
 	Conn            *websocket.Conn
 	Handler         *WebSocketHandler
 	UserID          string
+	SessionID       string // Outlives the connection so read markers survive reconnects
+	DMID            string // Public identity in direct messages, derived from SessionID
 	UserName        string
 	RoomID          string
 	IP              string
 	IsAdmin         bool
 	BackgroundAudio bool
+	ListeningRooms  map[string]string // roomID -> room name for listen-only rooms, guarded by mu
+	LobbyChatName   string            // Set while subscribed to the lobby chat
+	Locale          string            // Locale of server messages, guarded by mu
 	mu              sync.Mutex
 }
 
+// Role returns the permission role of the connected client
+func (c *Client) Role() entity.UserRole {
+	if c.IsAdmin {
+		return entity.UserRoleAdmin
+	}
+	return entity.UserRoleUser
+}
+
 type WebSocketHandler struct {
 	createRoomUC   *room.CreateRoomUseCase
 	joinRoomUC     *room.JoinRoomUseCase
 	leaveRoomUC    *room.LeaveRoomUseCase
 	listRoomsUC    *room.ListRoomsUseCase
 	getRoomUC      *room.GetRoomUseCase
+	listenRoomUC   *room.ListenRoomUseCase
+	chatSendUC     *chat.SendMessageUseCase
+	chatEditUC     *chat.EditMessageUseCase
+	chatDeleteUC   *chat.DeleteMessageUseCase
+	reactionUC     *chat.ReactionUseCase
+	typingUC       *chat.TypingUseCase
+	readStateUC    *chat.ReadStateUseCase
+	searchUC       *chat.SearchUseCase
+	floodUC        *chat.FloodControlUseCase
+	slowModeUC     *chat.SlowModeUseCase
+	roomMuteUC     *chat.RoomMuteUseCase
+	commandUC      *command.CommandUseCase
+	attachUC       *attachment.AttachmentUseCase
+	pinUC          *chat.PinUseCase
+	topicUC        *chat.TopicUseCase
+	pollUC         *chat.PollUseCase
+	exportUC       *chat.ExportUseCase
+	retentionUC    *chat.RetentionUseCase
+	lobbyChatUC    *chat.LobbyChatUseCase
+	dmUC           *dm.DirectMessageUseCase
+	dmBlockUC      *dm.BlockUseCase
+	dmReportUC     *dm.ReportUseCase
 	adminAuthUC    *admin.AdminAuthUseCase
 	adminActionsUC *admin.AdminActionsUseCase
+	announcementUC *admin.AnnouncementUseCase
+	scheduleUC     *admin.ScheduledAnnouncementsUseCase
+	maintenanceUC  *admin.MaintenanceUseCase
 	getStatsUC     *admin.GetStatsUseCase
 	tokenService   *livekit.TokenService
 	chatRepo       repository.ChatRepository
+	catalog        *i18n.Catalog
 	config         *config.Config
 	clients        map[string]*Client
 	roomClients    map[string]map[string]*Client
@@ -61,11 +107,37 @@ func NewWebSocketHandler(
 	leaveRoomUC *room.LeaveRoomUseCase,
 	listRoomsUC *room.ListRoomsUseCase,
 	getRoomUC *room.GetRoomUseCase,
+	listenRoomUC *room.ListenRoomUseCase,
+	chatSendUC *chat.SendMessageUseCase,
+	chatEditUC *chat.EditMessageUseCase,
+	chatDeleteUC *chat.DeleteMessageUseCase,
+	reactionUC *chat.ReactionUseCase,
+	typingUC *chat.TypingUseCase,
+	readStateUC *chat.ReadStateUseCase,
+	searchUC *chat.SearchUseCase,
+	floodUC *chat.FloodControlUseCase,
+	slowModeUC *chat.SlowModeUseCase,
+	roomMuteUC *chat.RoomMuteUseCase,
+	commandUC *command.CommandUseCase,
+	attachUC *attachment.AttachmentUseCase,
+	pinUC *chat.PinUseCase,
+	topicUC *chat.TopicUseCase,
+	pollUC *chat.PollUseCase,
+	exportUC *chat.ExportUseCase,
+	retentionUC *chat.RetentionUseCase,
+	lobbyChatUC *chat.LobbyChatUseCase,
+	dmUC *dm.DirectMessageUseCase,
+	dmBlockUC *dm.BlockUseCase,
+	dmReportUC *dm.ReportUseCase,
 	adminAuthUC *admin.AdminAuthUseCase,
 	adminActionsUC *admin.AdminActionsUseCase,
+	announcementUC *admin.AnnouncementUseCase,
+	scheduleUC *admin.ScheduledAnnouncementsUseCase,
+	maintenanceUC *admin.MaintenanceUseCase,
 	getStatsUC *admin.GetStatsUseCase,
 	tokenService *livekit.TokenService,
 	chatRepo repository.ChatRepository,
+	catalog *i18n.Catalog,
 	cfg *config.Config,
 ) *WebSocketHandler {
 	return &WebSocketHandler{
@@ -74,11 +146,37 @@ func NewWebSocketHandler(
 		leaveRoomUC:    leaveRoomUC,
 		listRoomsUC:    listRoomsUC,
 		getRoomUC:      getRoomUC,
+		listenRoomUC:   listenRoomUC,
+		chatSendUC:     chatSendUC,
+		chatEditUC:     chatEditUC,
+		chatDeleteUC:   chatDeleteUC,
+		reactionUC:     reactionUC,
+		typingUC:       typingUC,
+		readStateUC:    readStateUC,
+		searchUC:       searchUC,
+		floodUC:        floodUC,
+		slowModeUC:     slowModeUC,
+		roomMuteUC:     roomMuteUC,
+		commandUC:      commandUC,
+		attachUC:       attachUC,
+		pinUC:          pinUC,
+		topicUC:        topicUC,
+		pollUC:         pollUC,
+		exportUC:       exportUC,
+		retentionUC:    retentionUC,
+		lobbyChatUC:    lobbyChatUC,
+		dmUC:           dmUC,
+		dmBlockUC:      dmBlockUC,
+		dmReportUC:     dmReportUC,
 		adminAuthUC:    adminAuthUC,
 		adminActionsUC: adminActionsUC,
+		announcementUC: announcementUC,
+		scheduleUC:     scheduleUC,
+		maintenanceUC:  maintenanceUC,
 		getStatsUC:     getStatsUC,
 		tokenService:   tokenService,
 		chatRepo:       chatRepo,
+		catalog:        catalog,
 		config:         cfg,
 		clients:        make(map[string]*Client),
 		roomClients:    make(map[string]map[string]*Client),
@@ -97,22 +195,36 @@ func (h *WebSocketHandler) HandleConnection(w http.ResponseWriter, r *http.Reque
 	log.Printf("WebSocket connection upgraded successfully")
 
 	userID := uuid.New().String()
+	sessionID := h.readStateUC.ResumeSession(r.URL.Query().Get("resume"))
 	client := &Client{
-		ID:      uuid.New().String(),
-		Conn:    conn,
-		Handler: h,
-		UserID:  userID,
-		IP:      getClientIP(r),
+		ID:             uuid.New().String(),
+		Conn:           conn,
+		Handler:        h,
+		UserID:         userID,
+		SessionID:      sessionID,
+		DMID:           dm.Identity(sessionID),
+		IP:             getClientIP(r),
+		ListeningRooms: make(map[string]string),
+		Locale:         h.catalog.Negotiate(r.URL.Query().Get("locale"), r.Header.Get("Accept-Language")),
 	}
 
 	h.registerClient(client)
 	log.Printf("Client registered: UserID=%s, Total clients=%d", userID, len(h.clients))
 
 	// Send connected message
-	h.sendToClient(client, "connected", dto.ConnectedResponse{
-		Message: "Connected to voice chat server",
-		UserID:  userID,
-	})
+	connected := dto.ConnectedResponse{
+		Message:         "Connected to voice chat server",
+		UserID:          userID,
+		MessageOfTheDay: h.scheduleUC.MessageOfTheDay(),
+		SessionToken:    client.SessionID,
+		DMID:            client.DMID,
+		Locale:          client.Locale,
+		Locales:         h.catalog.Locales(),
+	}
+	if window := h.maintenanceUC.Current(); window != nil {
+		connected.Maintenance = dto.ToMaintenanceEvent(window, time.Now())
+	}
+	h.sendToClient(client, "connected", connected)
 
 	// Start ping keepalive
 	go h.startPingKeepalive(client)
@@ -167,6 +279,10 @@ func (h *WebSocketHandler) readMessages(client *Client) {
 }
 
 func (h *WebSocketHandler) handleMessage(client *Client, msg dto.Message) {
+	if !h.allowMessage(client, msg.Type) {
+		return
+	}
+
 	switch msg.Type {
 	case "join_room":
 		h.handleJoinRoom(client, msg.Payload)
@@ -181,10 +297,96 @@ func (h *WebSocketHandler) handleMessage(client *Client, msg dto.Message) {
 		h.handleGetRoom(client, msg.Payload)
 	case "chat_message":
 		h.handleChatMessage(client, msg.Payload)
+	case "chat_edit":
+		h.handleChatEdit(client, msg.Payload)
+	case "chat_delete":
+		h.handleChatDelete(client, msg.Payload)
+	case "get_thread":
+		h.handleGetThread(client, msg.Payload)
+	case "typing":
+		h.handleTyping(client, msg.Payload)
+	case "mark_read":
+		h.handleMarkRead(client, msg.Payload)
+	case "set_slow_mode":
+		h.handleSetSlowMode(client, msg.Payload)
+	case "set_topic":
+		h.handleSetTopic(client, msg.Payload)
+	case "pin_message":
+		h.handlePinMessage(client, msg.Payload, true)
+	case "unpin_message":
+		h.handlePinMessage(client, msg.Payload, false)
+	case "create_poll":
+		h.handleCreatePoll(client, msg.Payload)
+	case "poll_vote":
+		h.handlePollVote(client, msg.Payload)
+	case "close_poll":
+		h.handleClosePoll(client, msg.Payload)
+	case "search_chat":
+		h.handleSearchChat(client, msg.Payload)
+	case "get_chat_history":
+		h.handleGetChatHistory(client, msg.Payload)
 	case "chat_reaction_add":
 		h.handleChatReactionAdd(client, msg.Payload)
 	case "chat_reaction_remove":
 		h.handleChatReactionRemove(client, msg.Payload)
+	case "lobby_chat_join":
+		h.handleLobbyChatJoin(client, msg.Payload)
+	case "lobby_chat_leave":
+		h.handleLobbyChatLeave(client)
+	case "lobby_chat_message":
+		h.handleLobbyChatMessage(client, msg.Payload)
+	case "lobby_chat_delete":
+		h.handleLobbyChatDelete(client, msg.Payload)
+	case "lobby_chat_mute":
+		h.handleLobbyChatMute(client, msg.Payload)
+	case "dm_send":
+		h.handleDMSend(client, msg.Payload)
+	case "get_dm_history":
+		h.handleGetDMHistory(client, msg.Payload)
+	case "dm_block":
+		h.handleDMBlock(client, msg.Payload, true)
+	case "dm_unblock":
+		h.handleDMBlock(client, msg.Payload, false)
+	case "get_dm_blocks":
+		h.handleGetDMBlocks(client)
+	case "dm_report":
+		h.handleDMReport(client, msg.Payload)
+	case "listen_room":
+		h.handleListenRoom(client, msg.Payload)
+	case "unlisten_room":
+		h.handleUnlistenRoom(client, msg.Payload)
+	case "admin_auth":
+		h.handleAdminAuth(client, msg.Payload)
+	case "admin_set_listen_roles":
+		h.handleAdminSetListenRoles(client, msg.Payload)
+	case "broadcast_announcement":
+		h.handleBroadcastAnnouncement(client, msg.Payload)
+	case "stop_voice_pa":
+		h.handleStopVoicePA(client)
+	case "admin_schedule_announcement":
+		h.handleAdminScheduleAnnouncement(client, msg.Payload)
+	case "admin_list_scheduled_announcements":
+		h.handleAdminListScheduledAnnouncements(client)
+	case "admin_set_scheduled_announcement_enabled":
+		h.handleAdminSetScheduledAnnouncementEnabled(client, msg.Payload)
+	case "admin_delete_scheduled_announcement":
+		h.handleAdminDeleteScheduledAnnouncement(client, msg.Payload)
+	case "admin_set_motd":
+		h.handleAdminSetMessageOfTheDay(client, msg.Payload)
+	case "admin_search_chat":
+		h.handleAdminSearchChat(client, msg.Payload)
+	case "admin_list_dm_reports":
+		h.handleAdminListDMReports(client, msg.Payload)
+	case "admin_schedule_maintenance":
+		h.handleAdminScheduleMaintenance(client, msg.Payload)
+	case "admin_cancel_maintenance":
+		h.handleAdminCancelMaintenance(client)
+	case "admin_set_retention":
+		h.handleAdminSetRetention(client, msg.Payload)
+	case "admin_list_archives":
+		h.handleAdminListArchives(client)
+	case "set_locale":
+		h.handleSetLocale(client, msg.Payload)
 	case "ping":
 		// Respond to ping with pong
 		h.sendToClient(client, "pong", map[string]interface{}{})
@@ -204,6 +406,12 @@ func (h *WebSocketHandler) handleJoinRoom(client *Client, payload json.RawMessag
 		return
 	}
 
+	// New joins are refused while the server drains for maintenance
+	if err := h.maintenanceUC.CheckJoinAllowed(time.Now()); err != nil {
+		h.sendError(client, "MAINTENANCE_MODE", err.Error())
+		return
+	}
+
 	voiceMode := entity.VoiceModePTT
 	if req.VoiceMode == "vad" {
 		voiceMode = entity.VoiceModeVAD
@@ -222,6 +430,11 @@ func (h *WebSocketHandler) handleJoinRoom(client *Client, payload json.RawMessag
 		return
 	}
 
+	// Joining a room as participant replaces listening to it; a failed join keeps listening
+	if _, listening := client.listeningRooms()[result.Room.ID]; listening {
+		h.stopListening(client, result.Room.ID)
+	}
+
 	// Generate LiveKit token
 	token, err := h.tokenService.GenerateToken(result.Room.Name, client.UserID, result.User.Name, true)
 	if err != nil {
@@ -265,6 +478,16 @@ func (h *WebSocketHandler) handleJoinRoom(client *Client, payload json.RawMessag
 	// Start test tone sender to monitor for changes
 	go h.startTestToneSender(client)
 
+	// Unread counts come from the session's read marker, which survives reconnects
+	var lastReadID string
+	var unreadCount int
+	if unread, err := h.readStateUC.Unread(client.SessionID, result.Room.ID); err != nil {
+		log.Printf("Error fetching unread count: %v", err)
+	} else {
+		lastReadID = unread.LastReadID
+		unreadCount = unread.UnreadCount
+	}
+
 	// Send join response (use public URL for clients)
 	h.sendToClient(client, "room_joined", dto.JoinRoomResponse{
 		RoomID:       result.Room.ID,
@@ -275,29 +498,33 @@ func (h *WebSocketHandler) handleJoinRoom(client *Client, payload json.RawMessag
 		LiveKitURL:   h.config.LiveKitPublicURL,
 		Participants: dto.ToParticipantDTOs(result.Participants),
 		IsNewRoom:    result.IsNewRoom,
+		VoiceMode:    string(result.User.VoiceMode),
+		LastReadID:   lastReadID,
+		UnreadCount:  unreadCount,
+		SlowMode:     int(result.Room.GetSlowMode() / time.Second),
+		Description:  result.Room.Description,
+		Topic:        result.Room.GetTopic(),
+		Pinned:       dto.ToPinnedMessageDTOs(result.Room.GetPinned()),
 	})
 
 	log.Printf("Sent room_joined response: UserID=%s, RoomID=%s, LiveKitURL=%s",
 		client.UserID, result.Room.ID, h.config.LiveKitPublicURL)
 
 	// Send chat history to the joining user
-	chatHistory, err := h.chatRepo.GetMessages(result.Room.ID, repository.DefaultChatHistoryLimit)
+	chatHistory, hasMore, err := h.chatRepo.GetMessagesBefore(result.Room.ID, repository.ChatHistoryCursor{}, repository.DefaultChatHistoryLimit)
 	if err != nil {
 		log.Printf("Error fetching chat history: %v", err)
 	} else {
 		h.sendToClient(client, "chat_history", dto.ChatHistoryResponse{
 			Messages: dto.ToChatMessageDTOs(chatHistory),
+			HasMore:  hasMore,
 		})
 	}
 
 	// Create and broadcast system message about user joining
-	systemMsg := entity.NewSystemMessage(
-		uuid.New().String(),
-		result.Room.ID,
-		result.User.ID,
-		result.User.Name,
-		"joined the room",
-	)
+	systemMsg := h.newSystemEvent(result.Room.ID, result.User.ID, result.User.Name, entity.SystemCodeUserJoined, map[string]string{
+		"name": result.User.Name,
+	})
 	if err := h.chatRepo.AddMessage(systemMsg); err != nil {
 		log.Printf("Error saving system message: %v", err)
 	}
@@ -327,6 +554,8 @@ func (h *WebSocketHandler) handleLeaveRoom(client *Client, payload json.RawMessa
 	userID := client.UserID
 	userName := client.UserName
 
+	h.stopTyping(client)
+
 	_, err := h.leaveRoomUC.Execute(room.LeaveRoomInput{
 		RoomID: roomID,
 		UserID: userID,
@@ -338,13 +567,9 @@ func (h *WebSocketHandler) handleLeaveRoom(client *Client, payload json.RawMessa
 	}
 
 	// Create system message about user leaving (before removing from room)
-	systemMsg := entity.NewSystemMessage(
-		uuid.New().String(),
-		roomID,
-		userID,
-		userName,
-		"left the room",
-	)
+	systemMsg := h.newSystemEvent(roomID, userID, userName, entity.SystemCodeUserLeft, map[string]string{
+		"name": userName,
+	})
 	if err := h.chatRepo.AddMessage(systemMsg); err != nil {
 		log.Printf("Error saving system message: %v", err)
 	}
@@ -417,6 +642,10 @@ func (h *WebSocketHandler) handleGetRoom(client *Client, payload json.RawMessage
 }
 
 func (h *WebSocketHandler) handleDisconnect(client *Client) {
+	if client.IsAdmin && h.announcementUC.IsVoicePAActive(client.UserID) {
+		h.stopVoicePA(client)
+	}
+	h.stopListeningAll(client)
 	if client.RoomID != "" {
 		h.handleLeaveRoom(client, nil)
 	}
@@ -499,7 +728,7 @@ func (h *WebSocketHandler) sendToClient(client *Client, msgType string, payload
 
 	msg := map[string]interface{}{
 		"type":      msgType,
-		"payload":   payload,
+		"payload":   h.localize(client.Locale, payload),
 		"timestamp": time.Now(),
 	}
 
@@ -513,6 +742,15 @@ func (h *WebSocketHandler) sendError(client *Client, code, message string) {
 	})
 }
 
+// sendRetryError sends an error telling the client how long to wait before trying again
+func (h *WebSocketHandler) sendRetryError(client *Client, code, message string, retryAfter time.Duration) {
+	h.sendToClient(client, "error", dto.ErrorResponse{
+		Code:         code,
+		Message:      message,
+		RetryAfterMs: retryAfter.Milliseconds(),
+	})
+}
+
 func (h *WebSocketHandler) sendToUser(userID string, msgType string, payload interface{}) {
 	h.mu.RLock()
 	client, ok := h.clients[userID]
@@ -552,21 +790,76 @@ func (h *WebSocketHandler) broadcastRoomListToLobby() {
 		return
 	}
 
-	payload := dto.RoomListResponse{
+	h.broadcastToLobby("room_list", dto.RoomListResponse{
 		Rooms: dto.ToRoomInfoDTOs(result.Rooms),
+	})
+}
+
+// NotifyPresetsSynced tells occupants of removed preset rooms and lobby clients about a rooms file reconcile
+func (h *WebSocketHandler) NotifyPresetsSynced(result *room.SyncPresetsOutput) {
+	for _, r := range result.Closed {
+		systemMsg := h.newSystemEvent(r.ID, entity.PresetRoomCreator, entity.AnnouncementSenderName, entity.SystemCodeRoomRemoved, nil)
+		if err := h.chatRepo.AddMessage(systemMsg); err != nil {
+			log.Printf("Error saving system message: %v", err)
+		}
+		h.broadcastToRoomAll(r.ID, "chat_message", dto.ChatMessageEvent{
+			Message: dto.ToChatMessageDTO(systemMsg),
+		})
+	}
+
+	for _, r := range result.Closed {
+		h.ReleaseListeners(r.ID)
+	}
+	for _, r := range result.Deleted {
+		h.ReleaseListeners(r.ID)
 	}
 
+	if result.HasChanges() {
+		h.broadcastRoomListToLobby()
+	}
+}
+
+// broadcastToLobby sends a message to all clients not in a room
+func (h *WebSocketHandler) broadcastToLobby(msgType string, payload interface{}) {
+	h.broadcastWhere(func(client *Client) bool {
+		return client.RoomID == ""
+	}, msgType, payload)
+}
+
+// broadcastToLobbyChat sends a message to all clients subscribed to the lobby chat, wherever they are
+func (h *WebSocketHandler) broadcastToLobbyChat(msgType string, payload interface{}) {
+	h.broadcastWhere(func(client *Client) bool {
+		return client.LobbyChatName != ""
+	}, msgType, payload)
+}
+
+// broadcastWhere sends a message to every connected client the filter matches
+func (h *WebSocketHandler) broadcastWhere(match func(*Client) bool, msgType string, payload interface{}) {
 	h.mu.RLock()
-	lobbyClients := make([]*Client, 0)
+	targets := make([]*Client, 0)
 	for _, client := range h.clients {
-		if client.RoomID == "" {
-			lobbyClients = append(lobbyClients, client)
+		if match(client) {
+			targets = append(targets, client)
 		}
 	}
 	h.mu.RUnlock()
 
-	for _, client := range lobbyClients {
-		h.sendToClient(client, "room_list", payload)
+	for _, client := range targets {
+		h.sendToClient(client, msgType, payload)
+	}
+}
+
+// broadcastToAll sends a message to every connected client, in the lobby or in a room
+func (h *WebSocketHandler) broadcastToAll(msgType string, payload interface{}) {
+	h.mu.RLock()
+	clients := make([]*Client, 0, len(h.clients))
+	for _, client := range h.clients {
+		clients = append(clients, client)
+	}
+	h.mu.RUnlock()
+
+	for _, client := range clients {
+		h.sendToClient(client, msgType, payload)
 	}
 }
 
@@ -602,96 +895,277 @@ func (h *WebSocketHandler) handleChatMessage(client *Client, payload json.RawMes
 		return
 	}
 
-	if req.Content == "" {
+	if req.Content == "" && len(req.AttachmentIDs) == 0 {
 		return // Ignore empty messages
 	}
 
-	// Create chat message
-	msg := entity.NewChatMessage(
-		uuid.New().String(),
-		client.RoomID,
-		client.UserID,
-		client.UserName,
-		req.Content,
-	)
+	now := time.Now()
+	if wait := h.roomMuteUC.Remaining(client.RoomID, client.UserID, now); wait > 0 {
+		h.sendRetryError(client, "CHAT_MUTED", "A moderator has muted you in this room", wait)
+		return
+	}
+
+	if command.IsCommand(req.Content) {
+		h.handleCommand(client, req.Content)
+		return
+	}
+
+	// Slow mode is checked before moderation so waiting users don't trip filters for nothing
+	if wait := h.slowModeUC.Remaining(client.RoomID, client.UserID, client.IsAdmin, now); wait > 0 {
+		h.sendRetryError(client, "SLOW_MODE", "Slow mode is on, wait before sending another message", wait)
+		return
+	}
+
+	attachments, err := h.attachUC.Claim(client.RoomID, client.UserID, req.AttachmentIDs)
+	if err != nil {
+		h.sendError(client, "INVALID_ATTACHMENT", err.Error())
+		return
+	}
 
-	// Save to repository
-	if err := h.chatRepo.AddMessage(msg); err != nil {
+	// Create and save chat message
+	result, err := h.chatSendUC.Execute(chat.SendMessageInput{
+		RoomID:      client.RoomID,
+		UserID:      client.UserID,
+		UserName:    client.UserName,
+		Content:     req.Content,
+		ReplyTo:     req.ReplyTo,
+		IsAdmin:     client.IsAdmin,
+		IP:          client.IP,
+		Attachments: attachments,
+	})
+	if err != nil {
+		// The uploads can go on another message
+		h.attachUC.Release(attachments)
+	}
+	if errors.Is(err, moderation.ErrMessageBlocked) {
+		h.sendError(client, "MESSAGE_BLOCKED", err.Error())
+		return
+	}
+	if errors.Is(err, chat.ErrParentNotFound) {
+		h.sendError(client, "MESSAGE_NOT_FOUND", err.Error())
+		return
+	}
+	if errors.Is(err, chat.ErrHereMentionRateLimited) {
+		h.sendError(client, "RATE_LIMITED", err.Error())
+		return
+	}
+	if err != nil {
 		log.Printf("Error saving chat message: %v", err)
 		h.sendError(client, "CHAT_ERROR", "Failed to save message")
 		return
 	}
 
+	h.slowModeUC.Record(client.RoomID, client.UserID, now)
+
+	// Sending a message ends typing and implies everything up to it has been read
+	h.stopTyping(client)
+	if _, err := h.readStateUC.MarkRead(client.SessionID, client.RoomID, result.Message.ID); err != nil {
+		log.Printf("Error advancing read marker: %v", err)
+	}
+
 	// Broadcast to all room participants (including sender for confirmation)
+	msgDTO := dto.ToChatMessageDTO(result.Message)
 	h.broadcastToRoomAll(client.RoomID, "chat_message", dto.ChatMessageEvent{
-		Message: dto.ToChatMessageDTO(msg),
+		Message: msgDTO,
 	})
+
+	// Ping mentioned users directly so clients can notify even when chat is hidden
+	for _, userID := range result.Notify {
+		h.sendToUser(userID, "mention", dto.MentionEvent{
+			RoomID:  client.RoomID,
+			Message: msgDTO,
+			AtHere:  result.Message.AtHere,
+		})
+	}
+
+	if result.Parent != nil {
+		h.broadcastToRoomAll(client.RoomID, "chat_thread_updated", dto.ChatThreadUpdatedEvent{
+			MessageID:  result.Parent.ID,
+			ReplyCount: result.Parent.ReplyCount,
+		})
+	}
+
+	if result.Review != nil {
+		h.notifyModeration(client, client.RoomID, msgDTO, result.Review)
+	}
 }
 
-func (h *WebSocketHandler) handleChatReactionAdd(client *Client, payload json.RawMessage) {
+func (h *WebSocketHandler) handleGetThread(client *Client, payload json.RawMessage) {
 	if client.RoomID == "" {
 		h.sendError(client, "NOT_IN_ROOM", "You must join a room first")
 		return
 	}
 
-	var req dto.ChatReactionRequest
+	var req dto.GetThreadRequest
 	if err := json.Unmarshal(payload, &req); err != nil {
-		h.sendError(client, "INVALID_PAYLOAD", "Invalid reaction request")
+		h.sendError(client, "INVALID_PAYLOAD", "Invalid thread request")
 		return
 	}
 
-	// Get the message
-	msg, err := h.chatRepo.GetMessage(client.RoomID, req.MessageID)
+	parent, err := h.chatRepo.GetMessage(client.RoomID, req.MessageID)
 	if err != nil {
 		h.sendError(client, "MESSAGE_NOT_FOUND", "Message not found")
 		return
 	}
 
-	// Add reaction
-	msg.AddReaction(req.Emoji, client.UserID)
+	limit := req.Limit
+	if limit <= 0 || limit > repository.MaxChatHistoryPageSize {
+		limit = repository.MaxChatHistoryPageSize
+	}
 
-	// Update in repository
-	if err := h.chatRepo.UpdateMessage(msg); err != nil {
-		log.Printf("Error updating message reaction: %v", err)
-		h.sendError(client, "CHAT_ERROR", "Failed to update reaction")
+	replies, err := h.chatRepo.GetReplies(client.RoomID, parent.ID, limit)
+	if err != nil {
+		h.sendError(client, "HISTORY_FAILED", err.Error())
 		return
 	}
 
-	// Broadcast reaction update to all room participants
-	h.broadcastToRoomAll(client.RoomID, "chat_reaction", dto.ChatReactionEvent{
+	h.sendToClient(client, "chat_thread", dto.ChatThreadResponse{
+		Parent:  dto.ToChatMessageDTO(parent),
+		Replies: dto.ToChatMessageDTOs(replies),
+	})
+}
+
+func (h *WebSocketHandler) handleChatEdit(client *Client, payload json.RawMessage) {
+	if client.RoomID == "" {
+		h.sendError(client, "NOT_IN_ROOM", "You must join a room first")
+		return
+	}
+
+	var req dto.ChatEditRequest
+	if err := json.Unmarshal(payload, &req); err != nil {
+		h.sendError(client, "INVALID_PAYLOAD", "Invalid chat edit request")
+		return
+	}
+
+	msg, err := h.chatEditUC.Execute(chat.EditMessageInput{
+		RoomID:    client.RoomID,
 		MessageID: req.MessageID,
-		Emoji:     req.Emoji,
 		UserID:    client.UserID,
-		UserIDs:   msg.Reactions[req.Emoji],
+		UserName:  client.UserName,
+		IP:        client.IP,
+		Content:   req.Content,
 	})
+	if errors.Is(err, moderation.ErrMessageBlocked) {
+		h.sendError(client, "MESSAGE_BLOCKED", err.Error())
+		return
+	}
+	if err != nil {
+		h.sendError(client, "EDIT_FAILED", err.Error())
+		return
+	}
+
+	h.broadcastToRoomAll(client.RoomID, "chat_message_edited", dto.ChatMessageEvent{
+		Message: dto.ToChatMessageDTO(msg),
+	})
+	h.refreshPin(msg)
 }
 
-func (h *WebSocketHandler) handleChatReactionRemove(client *Client, payload json.RawMessage) {
+func (h *WebSocketHandler) handleChatDelete(client *Client, payload json.RawMessage) {
 	if client.RoomID == "" {
 		h.sendError(client, "NOT_IN_ROOM", "You must join a room first")
 		return
 	}
 
-	var req dto.ChatReactionRequest
+	var req dto.ChatDeleteRequest
 	if err := json.Unmarshal(payload, &req); err != nil {
-		h.sendError(client, "INVALID_PAYLOAD", "Invalid reaction request")
+		h.sendError(client, "INVALID_PAYLOAD", "Invalid chat delete request")
 		return
 	}
 
-	// Get the message
-	msg, err := h.chatRepo.GetMessage(client.RoomID, req.MessageID)
+	msg, err := h.chatDeleteUC.Execute(chat.DeleteMessageInput{
+		RoomID:    client.RoomID,
+		MessageID: req.MessageID,
+		UserID:    client.UserID,
+		UserName:  client.UserName,
+		IP:        client.IP,
+		IsAdmin:   client.IsAdmin,
+	})
 	if err != nil {
-		h.sendError(client, "MESSAGE_NOT_FOUND", "Message not found")
+		h.sendError(client, "DELETE_FAILED", err.Error())
 		return
 	}
 
-	// Remove reaction
-	msg.RemoveReaction(req.Emoji, client.UserID)
+	h.broadcastToRoomAll(client.RoomID, "chat_message_deleted", dto.ChatMessageEvent{
+		Message: dto.ToChatMessageDTO(msg),
+	})
+	h.refreshPin(msg)
+}
 
-	// Update in repository
-	if err := h.chatRepo.UpdateMessage(msg); err != nil {
-		log.Printf("Error updating message reaction: %v", err)
-		h.sendError(client, "CHAT_ERROR", "Failed to update reaction")
+func (h *WebSocketHandler) handleGetChatHistory(client *Client, payload json.RawMessage) {
+	if client.RoomID == "" {
+		h.sendError(client, "NOT_IN_ROOM", "You must be in a room to load chat history")
+		return
+	}
+
+	var req dto.GetChatHistoryRequest
+	if err := json.Unmarshal(payload, &req); err != nil {
+		h.sendError(client, "INVALID_PAYLOAD", "Invalid chat history request")
+		return
+	}
+
+	limit := req.Limit
+	if limit <= 0 || limit > repository.MaxChatHistoryPageSize {
+		limit = repository.MaxChatHistoryPageSize
+	}
+
+	messages, hasMore, err := h.chatRepo.GetMessagesBefore(client.RoomID, repository.ParseChatHistoryCursor(req.Before), limit)
+	if err != nil {
+		h.sendError(client, "HISTORY_FAILED", err.Error())
+		return
+	}
+
+	h.sendToClient(client, "chat_history_page", dto.ChatHistoryResponse{
+		Messages: dto.ToChatMessageDTOs(messages),
+		HasMore:  hasMore,
+		Before:   req.Before,
+	})
+}
+
+func (h *WebSocketHandler) handleChatReactionAdd(client *Client, payload json.RawMessage) {
+	h.handleChatReaction(client, payload, true)
+}
+
+func (h *WebSocketHandler) handleChatReactionRemove(client *Client, payload json.RawMessage) {
+	h.handleChatReaction(client, payload, false)
+}
+
+func (h *WebSocketHandler) handleChatReaction(client *Client, payload json.RawMessage, add bool) {
+	if client.RoomID == "" {
+		h.sendError(client, "NOT_IN_ROOM", "You must join a room first")
+		return
+	}
+
+	var req dto.ChatReactionRequest
+	if err := json.Unmarshal(payload, &req); err != nil {
+		h.sendError(client, "INVALID_PAYLOAD", "Invalid reaction request")
+		return
+	}
+
+	input := chat.ReactionInput{
+		RoomID:    client.RoomID,
+		MessageID: req.MessageID,
+		UserID:    client.UserID,
+		Emoji:     req.Emoji,
+	}
+	var userIDs []string
+	var err error
+	if add {
+		userIDs, err = h.reactionUC.Add(input)
+	} else {
+		userIDs, err = h.reactionUC.Remove(input)
+	}
+	switch {
+	case errors.Is(err, chat.ErrReactionNotAllowed):
+		h.sendError(client, "REACTION_NOT_ALLOWED", err.Error())
+		return
+	case errors.Is(err, entity.ErrTooManyReactions):
+		h.sendError(client, "TOO_MANY_REACTIONS", err.Error())
+		return
+	case errors.Is(err, entity.ErrReactionOnDeleted):
+		h.sendError(client, "MESSAGE_DELETED", "Message has been deleted")
+		return
+	case err != nil:
+		h.sendError(client, "MESSAGE_NOT_FOUND", "Message not found")
 		return
 	}
 
@@ -700,7 +1174,7 @@ func (h *WebSocketHandler) handleChatReactionRemove(client *Client, payload json
 		MessageID: req.MessageID,
 		Emoji:     req.Emoji,
 		UserID:    client.UserID,
-		UserIDs:   msg.Reactions[req.Emoji],
+		UserIDs:   userIDs,
 	})
 }
 
@@ -0,0 +1,153 @@
+package handler
+
+import (
+	"encoding/json"
+	"log"
+
+	"voice-chat/internal/interface/dto"
+	"voice-chat/internal/usecase/room"
+)
+
+func (h *WebSocketHandler) handleListenRoom(client *Client, payload json.RawMessage) {
+	var req dto.ListenRoomRequest
+	if err := json.Unmarshal(payload, &req); err != nil {
+		h.sendError(client, "INVALID_PAYLOAD", "Invalid listen room request")
+		return
+	}
+
+	// Use the name from the primary room when there is one
+	userName := client.UserName
+	if userName == "" {
+		userName = req.UserName
+	}
+	if userName == "" {
+		h.sendError(client, "INVALID_PAYLOAD", "User name is required")
+		return
+	}
+
+	result, err := h.listenRoomUC.Listen(room.ListenRoomInput{
+		RoomName: req.RoomName,
+		UserID:   client.UserID,
+		UserName: userName,
+		IP:       client.IP,
+		Role:     client.Role(),
+		Stealth:  req.Stealth,
+	})
+	if err != nil {
+		h.sendError(client, "LISTEN_FAILED", err.Error())
+		return
+	}
+
+	// Subscribe-only token for the additional room
+	token, err := h.tokenService.GenerateListenerToken(result.Room.Name, client.UserID, result.Listener.Name, result.Listener.IsStealth)
+	if err != nil {
+		log.Printf("ERROR: Failed to generate listener token for user %s in room %s: %v", client.UserID, result.Room.Name, err)
+		h.listenRoomUC.Unlisten(room.UnlistenRoomInput{RoomID: result.Room.ID, UserID: client.UserID})
+		h.sendError(client, "TOKEN_FAILED", "Failed to generate voice token")
+		return
+	}
+
+	client.mu.Lock()
+	client.ListeningRooms[result.Room.ID] = result.Room.Name
+	client.mu.Unlock()
+
+	h.sendToClient(client, "room_listening", dto.ListenRoomResponse{
+		RoomID:       result.Room.ID,
+		RoomName:     result.Room.Name,
+		ListenerName: result.Listener.Name,
+		LiveKitToken: token,
+		LiveKitURL:   h.config.LiveKitPublicURL,
+		Participants: dto.ToParticipantDTOs(result.Participants),
+	})
+
+	log.Printf("User %s listening to room %s (stealth: %v)", client.UserID, result.Room.Name, result.Listener.IsStealth)
+
+	if !result.Listener.IsStealth {
+		h.broadcastToRoom(result.Room.ID, client.UserID, "listener_joined", dto.ListenerEvent{
+			UserID:   client.UserID,
+			UserName: result.Listener.Name,
+			RoomID:   result.Room.ID,
+		})
+	}
+}
+
+func (h *WebSocketHandler) handleUnlistenRoom(client *Client, payload json.RawMessage) {
+	var req dto.UnlistenRoomRequest
+	if err := json.Unmarshal(payload, &req); err != nil {
+		h.sendError(client, "INVALID_PAYLOAD", "Invalid unlisten room request")
+		return
+	}
+
+	if _, ok := client.listeningRooms()[req.RoomID]; !ok {
+		h.sendError(client, "NOT_LISTENING", "You are not listening to this room")
+		return
+	}
+
+	h.stopListening(client, req.RoomID)
+}
+
+// stopListening removes the client as listener from a room and notifies everyone involved
+func (h *WebSocketHandler) stopListening(client *Client, roomID string) {
+	client.mu.Lock()
+	delete(client.ListeningRooms, roomID)
+	client.mu.Unlock()
+
+	_, listener, err := h.listenRoomUC.Unlisten(room.UnlistenRoomInput{
+		RoomID: roomID,
+		UserID: client.UserID,
+	})
+	if err != nil {
+		// Room may already be gone, the client just needs to drop its connection
+		log.Printf("Error stopping listener %s in room %s: %v", client.UserID, roomID, err)
+	}
+
+	h.sendToClient(client, "room_unlistened", dto.UnlistenRoomResponse{RoomID: roomID})
+
+	if listener != nil && !listener.IsStealth {
+		h.broadcastToRoom(roomID, client.UserID, "listener_left", dto.ListenerEvent{
+			UserID:   client.UserID,
+			UserName: listener.Name,
+			RoomID:   roomID,
+		})
+	}
+}
+
+// stopListeningAll stops listening to every additional room (on disconnect)
+func (h *WebSocketHandler) stopListeningAll(client *Client) {
+	for roomID := range client.listeningRooms() {
+		h.stopListening(client, roomID)
+	}
+}
+
+// ReleaseListeners tells everyone listening to a room that was closed or deleted that their
+// access ended. The room's listener list is cleared by whoever closed it.
+func (h *WebSocketHandler) ReleaseListeners(roomID string) {
+	h.mu.RLock()
+	clients := make([]*Client, 0)
+	for _, client := range h.clients {
+		if _, ok := client.listeningRooms()[roomID]; ok {
+			clients = append(clients, client)
+		}
+	}
+	h.mu.RUnlock()
+
+	for _, client := range clients {
+		client.mu.Lock()
+		delete(client.ListeningRooms, roomID)
+		client.mu.Unlock()
+
+		h.sendToClient(client, "room_unlistened", dto.UnlistenRoomResponse{RoomID: roomID})
+	}
+}
+
+// listeningRooms returns a copy of the rooms the client listens to
+func (c *Client) listeningRooms() map[string]string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	rooms := make(map[string]string, len(c.ListeningRooms))
+	for roomID, name := range c.ListeningRooms {
+		rooms[roomID] = name
+	}
+	return rooms
+}
@@ -0,0 +1,246 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	"voice-chat/internal/domain/entity"
+	"voice-chat/internal/domain/repository"
+	"voice-chat/internal/interface/dto"
+	"voice-chat/internal/usecase/chat"
+	"voice-chat/internal/usecase/moderation"
+)
+
+// sessionClients returns every connection of a session
+func (h *WebSocketHandler) sessionClients(sessionID string) []*Client {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	var clients []*Client
+	for _, c := range h.clients {
+		if c.SessionID == sessionID {
+			clients = append(clients, c)
+		}
+	}
+	return clients
+}
+
+// lobbyChatName returns the name the client chats under in the lobby, or "" if not subscribed
+func (h *WebSocketHandler) lobbyChatName(client *Client) string {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return client.LobbyChatName
+}
+
+func (h *WebSocketHandler) handleLobbyChatJoin(client *Client, payload json.RawMessage) {
+	var req dto.LobbyChatJoinRequest
+	if len(payload) > 0 {
+		if err := json.Unmarshal(payload, &req); err != nil {
+			h.sendError(client, "INVALID_PAYLOAD", "Invalid lobby chat request")
+			return
+		}
+	}
+
+	name := req.UserName
+	if name == "" {
+		name = client.UserName
+	}
+
+	messages, err := h.lobbyChatUC.History(repository.DefaultChatHistoryLimit)
+	if errors.Is(err, chat.ErrLobbyChatDisabled) {
+		h.sendError(client, "LOBBY_CHAT_DISABLED", err.Error())
+		return
+	}
+	if err != nil {
+		h.sendError(client, "HISTORY_FAILED", err.Error())
+		return
+	}
+
+	// Names are checked and claimed under one lock so two people can't take the same one.
+	// Other tabs of the same session may share a name.
+	h.mu.Lock()
+	name, err = h.lobbyChatUC.DisplayName(name, client.IsAdmin, func(name string) bool {
+		for _, c := range h.clients {
+			if c.SessionID != client.SessionID && strings.EqualFold(c.LobbyChatName, name) {
+				return true
+			}
+		}
+		return false
+	})
+	if err == nil {
+		client.LobbyChatName = name
+	}
+	h.mu.Unlock()
+
+	if errors.Is(err, chat.ErrLobbyChatNameTaken) {
+		h.sendError(client, "NAME_TAKEN", err.Error())
+		return
+	}
+	if err != nil {
+		h.sendError(client, "INVALID_NAME", err.Error())
+		return
+	}
+
+	h.sendToClient(client, "lobby_chat_joined", dto.LobbyChatJoinedResponse{
+		UserName:        name,
+		CooldownSeconds: int(h.lobbyChatUC.Cooldown() / time.Second),
+		Messages:        dto.ToChatMessageDTOs(messages),
+	})
+}
+
+func (h *WebSocketHandler) handleLobbyChatLeave(client *Client) {
+	h.mu.Lock()
+	client.LobbyChatName = ""
+	h.mu.Unlock()
+
+	h.sendToClient(client, "lobby_chat_left", map[string]interface{}{})
+}
+
+func (h *WebSocketHandler) handleLobbyChatMessage(client *Client, payload json.RawMessage) {
+	name := h.lobbyChatName(client)
+	if name == "" {
+		h.sendError(client, "NOT_IN_LOBBY_CHAT", "Join the lobby chat first")
+		return
+	}
+
+	var req dto.LobbyChatMessageRequest
+	if err := json.Unmarshal(payload, &req); err != nil {
+		h.sendError(client, "INVALID_PAYLOAD", "Invalid lobby chat message")
+		return
+	}
+
+	now := time.Now()
+	if wait := h.lobbyChatUC.Muted(client.SessionID, now); wait > 0 {
+		h.sendRetryError(client, "CHAT_MUTED", "An admin has muted you in the lobby chat", wait)
+		return
+	}
+	if wait := h.lobbyChatUC.Remaining(client.SessionID, client.IsAdmin, now); wait > 0 {
+		h.sendRetryError(client, "SLOW_MODE", "Wait before sending another lobby message", wait)
+		return
+	}
+
+	msg, review, err := h.lobbyChatUC.Send(chat.LobbyChatInput{
+		UserID:    client.UserID,
+		SessionID: client.SessionID,
+		UserName:  name,
+		Content:   req.Content,
+		IsAdmin:   client.IsAdmin,
+		IP:        client.IP,
+	}, now)
+	if errors.Is(err, chat.ErrEmptyMessage) {
+		return // Ignore empty messages
+	}
+	if errors.Is(err, moderation.ErrMessageBlocked) {
+		h.sendError(client, "MESSAGE_BLOCKED", err.Error())
+		return
+	}
+	if errors.Is(err, chat.ErrLobbyChatDisabled) {
+		h.sendError(client, "LOBBY_CHAT_DISABLED", err.Error())
+		return
+	}
+	if err != nil {
+		log.Printf("Error saving lobby chat message: %v", err)
+		h.sendError(client, "LOBBY_CHAT_ERROR", "Failed to save message")
+		return
+	}
+
+	msgDTO := dto.ToChatMessageDTO(msg)
+	h.broadcastToLobbyChat("lobby_chat_message", dto.ChatMessageEvent{
+		Message: msgDTO,
+	})
+
+	if review != nil {
+		h.notifyModeration(client, entity.LobbyChatRoomID, msgDTO, review)
+	}
+}
+
+func (h *WebSocketHandler) handleLobbyChatDelete(client *Client, payload json.RawMessage) {
+	name := h.lobbyChatName(client)
+	if name == "" {
+		h.sendError(client, "NOT_IN_LOBBY_CHAT", "Join the lobby chat first")
+		return
+	}
+
+	var req dto.ChatDeleteRequest
+	if err := json.Unmarshal(payload, &req); err != nil {
+		h.sendError(client, "INVALID_PAYLOAD", "Invalid chat delete request")
+		return
+	}
+
+	// Authors can delete their own messages; only admins moderate the lobby chat
+	msg, err := h.chatDeleteUC.Execute(chat.DeleteMessageInput{
+		RoomID:    entity.LobbyChatRoomID,
+		MessageID: req.MessageID,
+		UserID:    client.UserID,
+		UserName:  name,
+		IP:        client.IP,
+		IsAdmin:   client.IsAdmin,
+	})
+	if err != nil {
+		h.sendError(client, "DELETE_FAILED", err.Error())
+		return
+	}
+
+	h.broadcastToLobbyChat("lobby_chat_message_deleted", dto.ChatMessageEvent{
+		Message: dto.ToChatMessageDTO(msg),
+	})
+}
+
+func (h *WebSocketHandler) handleLobbyChatMute(client *Client, payload json.RawMessage) {
+	var req dto.LobbyChatMuteRequest
+	if err := json.Unmarshal(payload, &req); err != nil {
+		h.sendError(client, "INVALID_PAYLOAD", "Invalid lobby chat mute request")
+		return
+	}
+
+	if !client.IsAdmin {
+		h.sendError(client, "NOT_AUTHORIZED", chat.ErrNotAllowedToModerateLobby.Error())
+		return
+	}
+
+	// Mutes follow the target's session, so reconnecting doesn't lift them
+	h.mu.RLock()
+	target, ok := h.clients[req.UserID]
+	var sessionID string
+	if ok {
+		sessionID = target.SessionID
+	}
+	h.mu.RUnlock()
+	if !ok {
+		h.sendError(client, "USER_NOT_FOUND", "User is not online")
+		return
+	}
+
+	duration := time.Duration(req.Minutes) * time.Minute
+	err := h.lobbyChatUC.Mute(chat.LobbyMuteInput{
+		SessionID: sessionID,
+		IsAdmin:   client.IsAdmin,
+		Duration:  duration,
+	}, time.Now())
+	if errors.Is(err, chat.ErrNotAllowedToModerateLobby) {
+		h.sendError(client, "NOT_AUTHORIZED", err.Error())
+		return
+	}
+	if err != nil {
+		h.sendError(client, "MUTE_FAILED", err.Error())
+		return
+	}
+
+	log.Printf("Admin UserID=%s muted UserID=%s in the lobby chat for %d minutes", client.UserID, req.UserID, req.Minutes)
+	h.sendToClient(client, "lobby_chat_muted", dto.LobbyChatMutedResponse{
+		UserID:  req.UserID,
+		Minutes: req.Minutes,
+	})
+
+	if duration > 0 {
+		for _, c := range h.sessionClients(sessionID) {
+			h.sendToClient(c, "chat_muted", dto.ChatMutedEvent{
+				DurationMs: duration.Milliseconds(),
+				Reason:     "Muted in the lobby chat by an admin",
+			})
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package handler
+
+import (
+	"encoding/json"
+	"reflect"
+
+	"github.com/google/uuid"
+
+	"voice-chat/internal/domain/entity"
+	"voice-chat/internal/interface/dto"
+	"voice-chat/internal/usecase/chat"
+)
+
+func (h *WebSocketHandler) handleSetLocale(client *Client, payload json.RawMessage) {
+	var req dto.SetLocaleRequest
+	if err := json.Unmarshal(payload, &req); err != nil {
+		h.sendError(client, "INVALID_PAYLOAD", "Invalid locale request")
+		return
+	}
+
+	locale, ok := h.catalog.Match(req.Locale)
+	if !ok {
+		h.sendError(client, "INVALID_LOCALE", "Unsupported locale")
+		return
+	}
+
+	client.mu.Lock()
+	client.Locale = locale
+	client.mu.Unlock()
+
+	h.sendToClient(client, "locale_set", dto.LocaleResponse{Locale: locale})
+}
+
+// newSystemEvent creates a structured system message, storing its text in the fallback locale
+func (h *WebSocketHandler) newSystemEvent(roomID, userID, userName, code string, params map[string]string) *entity.ChatMessage {
+	content, _ := h.catalog.Render(h.catalog.Fallback(), "system."+code, params)
+	return entity.NewSystemEvent(uuid.New().String(), roomID, userID, userName, code, params, content)
+}
+
+// localize translates the parts of a payload that come from the message catalog. Payloads,
+// whether sent as values or pointers, opt in by implementing dto.Localizable.
+func (h *WebSocketHandler) localize(locale string, payload interface{}) interface{} {
+	l, ok := payload.(dto.Localizable)
+	if !ok {
+		return payload
+	}
+	if v := reflect.ValueOf(payload); v.Kind() == reflect.Ptr && v.IsNil() {
+		return payload
+	}
+	return l.Localize(func(key string, params map[string]string) (string, bool) {
+		return h.catalog.Render(locale, key, params)
+	})
+}
+
+// localizeExport renders the structured system messages of a transcript in the locale; the
+// messages may be shared with the repository, so translated ones are copied
+func (h *WebSocketHandler) localizeExport(locale string, export *chat.ChatExport) {
+	var localized []*entity.ChatMessage
+	for i, msg := range export.Messages {
+		if msg.Code == "" {
+			continue
+		}
+		content, ok := h.catalog.Render(locale, "system."+msg.Code, msg.Params)
+		if !ok || content == msg.Content {
+			continue
+		}
+		if localized == nil {
+			localized = append([]*entity.ChatMessage(nil), export.Messages...)
+		}
+		copied := *msg
+		copied.Content = content
+		localized[i] = &copied
+	}
+	if localized != nil {
+		export.Messages = localized
+	}
+}
@@ -0,0 +1,233 @@
+package handler
+
+import (
+	"encoding/json"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"reflect"
+	"sort"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"voice-chat/internal/domain/entity"
+	"voice-chat/internal/interface/dto"
+	"voice-chat/internal/usecase/chat"
+	"voice-chat/pkg/i18n"
+)
+
+// errorCodeArgs maps the helpers that send errors to the position of their code argument
+var errorCodeArgs = map[string]int{
+	"sendError":      1,
+	"sendRetryError": 1,
+	"writeAPIError":  3,
+}
+
+// handlerErrorCodes collects the error codes the handlers send, reading the package's source
+func handlerErrorCodes(t *testing.T) []string {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, ".", nil, 0)
+	assert.NoError(t, err)
+
+	seen := make(map[string]bool)
+	for _, pkg := range pkgs {
+		for _, file := range pkg.Files {
+			ast.Inspect(file, func(n ast.Node) bool {
+				call, ok := n.(*ast.CallExpr)
+				if !ok {
+					return true
+				}
+				sel, ok := call.Fun.(*ast.SelectorExpr)
+				if !ok {
+					return true
+				}
+				index, ok := errorCodeArgs[sel.Sel.Name]
+				if !ok || len(call.Args) <= index {
+					return true
+				}
+				lit, ok := call.Args[index].(*ast.BasicLit)
+				if !assert.True(t, ok && lit.Kind == token.STRING, "%s: error codes must be string literals", fset.Position(call.Pos())) {
+					return true
+				}
+				code, _ := strconv.Unquote(lit.Value)
+				seen[code] = true
+				return true
+			})
+		}
+	}
+
+	codes := make([]string, 0, len(seen))
+	for code := range seen {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	return codes
+}
+
+func TestErrorCodesAreTranslated(t *testing.T) {
+	catalog, err := i18n.Load("en", i18n.Builtin())
+	assert.NoError(t, err)
+
+	codes := handlerErrorCodes(t)
+	assert.Contains(t, codes, "NOT_IN_ROOM")
+	assert.Contains(t, codes, "UPLOAD_FAILED")
+
+	for _, locale := range catalog.Locales() {
+		for _, code := range codes {
+			_, ok := catalog.Lookup(locale, "errors."+code, nil)
+			assert.True(t, ok, "%s has no message for %s", locale, code)
+		}
+	}
+}
+
+func TestLocalizeError(t *testing.T) {
+	catalog, err := i18n.Load("en", i18n.Builtin())
+	assert.NoError(t, err)
+	h := &WebSocketHandler{catalog: catalog}
+
+	payload := h.localize("de", dto.ErrorResponse{Code: "COMMAND_FAILED", Message: "usage: /roll [NdM]"})
+	assert.Equal(t, dto.ErrorResponse{
+		Code:    "COMMAND_FAILED",
+		Message: "Der Befehl ist fehlgeschlagen",
+		Detail:  "usage: /roll [NdM]",
+	}, payload)
+
+	// Nothing is repeated when the handler already used the catalog's text
+	payload = h.localize("en", dto.ErrorResponse{Code: "NOT_IN_ROOM", Message: "Join a room first"})
+	assert.Equal(t, dto.ErrorResponse{Code: "NOT_IN_ROOM", Message: "Join a room first"}, payload)
+
+	// Unknown codes keep the handler's message
+	payload = h.localize("de", dto.ErrorResponse{Code: "SOMETHING_NEW", Message: "Something new failed"})
+	assert.Equal(t, dto.ErrorResponse{Code: "SOMETHING_NEW", Message: "Something new failed"}, payload)
+
+	payload = h.localize("de", &dto.ErrorResponse{Code: "NOT_IN_ROOM", Message: "Join a room first"})
+	assert.Equal(t, dto.ErrorResponse{Code: "NOT_IN_ROOM", Message: "Tritt zuerst einem Raum bei", Detail: "Join a room first"}, payload)
+}
+
+// messageHolders are the DTOs that wrap a chat message without being sent on their own
+var messageHolders = map[string]bool{
+	"ChatMessageDTO":      true,
+	"PinnedMessageDTO":    true,
+	"ChatSearchResultDTO": true,
+}
+
+// payloadsWithMessages lists the DTOs that carry chat messages, reading the dto package's source
+func payloadsWithMessages(t *testing.T) []string {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, "../dto", nil, 0)
+	assert.NoError(t, err)
+
+	var names []string
+	for _, pkg := range pkgs {
+		for _, file := range pkg.Files {
+			ast.Inspect(file, func(n ast.Node) bool {
+				spec, ok := n.(*ast.TypeSpec)
+				if !ok || messageHolders[spec.Name.Name] {
+					return true
+				}
+				st, ok := spec.Type.(*ast.StructType)
+				if !ok {
+					return true
+				}
+				for _, field := range st.Fields.List {
+					carries := false
+					ast.Inspect(field.Type, func(n ast.Node) bool {
+						if ident, ok := n.(*ast.Ident); ok && messageHolders[ident.Name] {
+							carries = true
+						}
+						return !carries
+					})
+					if carries {
+						names = append(names, spec.Name.Name)
+						break
+					}
+				}
+				return true
+			})
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+func TestLocalizeChatMessages(t *testing.T) {
+	catalog, err := i18n.Load("en", i18n.Builtin())
+	assert.NoError(t, err)
+	h := &WebSocketHandler{catalog: catalog}
+
+	joined := func() *dto.ChatMessageDTO {
+		return &dto.ChatMessageDTO{
+			ID:      "msg-1",
+			Type:    string(entity.ChatMessageTypeSystem),
+			Content: "Alice joined the room",
+			Code:    entity.SystemCodeUserJoined,
+			Params:  map[string]string{"name": "Alice"},
+		}
+	}
+	pinned := func() []*dto.PinnedMessageDTO {
+		return []*dto.PinnedMessageDTO{{Message: joined(), PinnedBy: "Bob"}}
+	}
+	payloads := map[string]interface{}{
+		"ChatMessageEvent":        dto.ChatMessageEvent{Message: joined()},
+		"ChatHistoryResponse":     dto.ChatHistoryResponse{Messages: []*dto.ChatMessageDTO{joined()}},
+		"ChatThreadResponse":      dto.ChatThreadResponse{Parent: joined(), Replies: []*dto.ChatMessageDTO{joined()}},
+		"MentionEvent":            dto.MentionEvent{Message: joined()},
+		"ChatFlaggedEvent":        dto.ChatFlaggedEvent{Message: joined()},
+		"ChatSearchResponse":      dto.ChatSearchResponse{Results: []*dto.ChatSearchResultDTO{{RoomName: "Lobby", Message: joined()}}},
+		"LobbyChatJoinedResponse": dto.LobbyChatJoinedResponse{Messages: []*dto.ChatMessageDTO{joined()}},
+		"JoinRoomResponse":        dto.JoinRoomResponse{Pinned: pinned()},
+		"RoomInfoResponse":        dto.RoomInfoResponse{Pinned: pinned()},
+		"RoomUpdatedEvent":        dto.RoomUpdatedEvent{Pinned: pinned()},
+	}
+
+	names := payloadsWithMessages(t)
+	assert.Contains(t, names, "ChatSearchResponse")
+	assert.Contains(t, names, "RoomUpdatedEvent")
+
+	for _, name := range names {
+		payload, ok := payloads[name]
+		if !assert.True(t, ok, "no sample for %s, which carries chat messages", name) {
+			continue
+		}
+		assert.Implements(t, (*dto.Localizable)(nil), payload, name)
+
+		// Handlers send some payloads as values and some as pointers
+		pointer := reflect.New(reflect.TypeOf(payload))
+		pointer.Elem().Set(reflect.ValueOf(payload))
+		for _, sent := range []interface{}{payload, pointer.Interface()} {
+			before, _ := json.Marshal(sent)
+
+			localized, _ := json.Marshal(h.localize("de", sent))
+			assert.Contains(t, string(localized), "Alice hat den Raum betreten", name)
+			assert.NotContains(t, string(localized), "Alice joined the room", name)
+
+			// The shared payload is left as it was for other recipients
+			after, _ := json.Marshal(sent)
+			assert.JSONEq(t, string(before), string(after), name)
+		}
+	}
+
+	var missing *dto.RoomInfoResponse
+	assert.Nil(t, h.localize("de", missing))
+}
+
+func TestLocalizeExport(t *testing.T) {
+	catalog, err := i18n.Load("en", i18n.Builtin())
+	assert.NoError(t, err)
+	h := &WebSocketHandler{catalog: catalog}
+
+	event := entity.NewSystemEvent("msg-1", "room-1", "user-1", "Alice", entity.SystemCodeUserJoined,
+		map[string]string{"name": "Alice"}, "Alice joined the room")
+	legacy := entity.NewSystemMessage("msg-2", "room-1", "user-1", "Alice", "Alice left the room")
+	message := entity.NewChatMessage("msg-3", "room-1", "user-1", "Alice", "hi")
+	export := &chat.ChatExport{Messages: []*entity.ChatMessage{event, legacy, message}}
+
+	h.localizeExport("de", export)
+	assert.Equal(t, "Alice hat den Raum betreten", export.Messages[0].Content)
+	assert.Same(t, legacy, export.Messages[1])
+	assert.Same(t, message, export.Messages[2])
+	// Stored messages are copied, not translated in place
+	assert.Equal(t, "Alice joined the room", event.Content)
+}
@@ -0,0 +1,131 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"voice-chat/internal/interface/dto"
+	"voice-chat/internal/usecase/admin"
+)
+
+// shutdownWriteTimeout bounds how long a slow client can delay shutdown
+const shutdownWriteTimeout = 2 * time.Second
+
+func (h *WebSocketHandler) handleAdminScheduleMaintenance(client *Client, payload json.RawMessage) {
+	if !h.requireAdmin(client) {
+		return
+	}
+
+	var req dto.AdminScheduleMaintenanceRequest
+	if err := json.Unmarshal(payload, &req); err != nil {
+		h.sendError(client, "INVALID_PAYLOAD", "Invalid maintenance request")
+		return
+	}
+
+	window, err := h.maintenanceUC.Schedule(admin.ScheduleMaintenanceInput{
+		AdminID: client.UserID,
+		Delay:   time.Duration(req.Minutes) * time.Minute,
+		Message: req.Message,
+	})
+	if err != nil {
+		h.sendError(client, "MAINTENANCE_FAILED", err.Error())
+		return
+	}
+
+	log.Printf("Admin %s scheduled maintenance at %s", client.UserID, window.StartsAt.Format(time.RFC3339))
+	h.broadcastToAll("maintenance_scheduled", dto.ToMaintenanceEvent(window, time.Now()))
+}
+
+func (h *WebSocketHandler) handleAdminCancelMaintenance(client *Client) {
+	if !h.requireAdmin(client) {
+		return
+	}
+
+	if err := h.maintenanceUC.Cancel(client.UserID); err != nil {
+		h.sendError(client, "MAINTENANCE_FAILED", err.Error())
+		return
+	}
+
+	log.Printf("Admin %s cancelled maintenance", client.UserID)
+	h.broadcastToAll("maintenance_cancelled", map[string]interface{}{})
+}
+
+// RunMaintenanceCountdown re-broadcasts the maintenance countdown until ctx is cancelled
+func (h *WebSocketHandler) RunMaintenanceCountdown(ctx context.Context) {
+	ticker := time.NewTicker(1 * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			tick := h.maintenanceUC.Tick(now)
+			if tick == nil {
+				continue
+			}
+
+			event := dto.ToMaintenanceEvent(tick.Window, now)
+			if tick.Started {
+				log.Println("Maintenance mode active, new joins are disabled")
+				h.broadcastToAll("maintenance_started", event)
+			} else {
+				h.broadcastToAll("maintenance_scheduled", event)
+			}
+		}
+	}
+}
+
+// Shutdown tells every client the server is going away, closes their sockets with a
+// close frame and waits (until ctx expires) for their read loops to clean up
+func (h *WebSocketHandler) Shutdown(ctx context.Context, reconnectAfter time.Duration) {
+	h.mu.RLock()
+	clients := make([]*Client, 0, len(h.clients))
+	for _, client := range h.clients {
+		clients = append(clients, client)
+	}
+	h.mu.RUnlock()
+
+	event := dto.ServerShutdownEvent{
+		Message:        "Server is restarting",
+		ReconnectAfter: int(reconnectAfter / time.Second),
+	}
+	closeMsg := websocket.FormatCloseMessage(websocket.CloseServiceRestart, "server restarting")
+
+	for _, client := range clients {
+		client.mu.Lock()
+		client.Conn.SetWriteDeadline(time.Now().Add(shutdownWriteTimeout))
+		client.mu.Unlock()
+
+		h.sendToClient(client, "server_shutdown", event)
+		client.Conn.WriteControl(websocket.CloseMessage, closeMsg, time.Now().Add(shutdownWriteTimeout))
+		client.Conn.Close()
+	}
+
+	log.Printf("Sent shutdown notice to %d clients", len(clients))
+
+	// Wait for read loops to run their disconnect handling
+	ticker := time.NewTicker(50 * time.Millisecond)
+	defer ticker.Stop()
+
+	for {
+		h.mu.RLock()
+		remaining := len(h.clients)
+		h.mu.RUnlock()
+
+		if remaining == 0 {
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			log.Printf("Shutdown timed out with %d clients still registered", remaining)
+			return
+		case <-ticker.C:
+		}
+	}
+}
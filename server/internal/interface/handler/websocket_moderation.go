@@ -0,0 +1,42 @@
+package handler
+
+import (
+	"voice-chat/internal/interface/dto"
+	"voice-chat/internal/usecase/moderation"
+)
+
+// notifyModeration warns the sender and alerts admins about a message that tripped non-blocking filters
+func (h *WebSocketHandler) notifyModeration(client *Client, roomID string, msg *dto.ChatMessageDTO, review *moderation.Result) {
+	if len(review.Warnings) > 0 {
+		h.sendToClient(client, "chat_warning", dto.ChatWarningEvent{
+			RoomID:     roomID,
+			MessageID:  msg.ID,
+			Violations: dto.ToModerationViolationDTOs(review.Warnings),
+		})
+	}
+
+	if len(review.Flagged) > 0 {
+		h.broadcastToAdmins("chat_flagged", dto.ChatFlaggedEvent{
+			RoomID:     roomID,
+			Message:    msg,
+			Violations: dto.ToModerationViolationDTOs(review.Flagged),
+		})
+	}
+}
+
+// notifyDMModeration is notifyModeration for direct messages
+func (h *WebSocketHandler) notifyDMModeration(client *Client, msg *dto.DirectMessageDTO, review *moderation.Result) {
+	if len(review.Warnings) > 0 {
+		h.sendToClient(client, "chat_warning", dto.ChatWarningEvent{
+			MessageID:  msg.ID,
+			Violations: dto.ToModerationViolationDTOs(review.Warnings),
+		})
+	}
+
+	if len(review.Flagged) > 0 {
+		h.broadcastToAdmins("dm_flagged", dto.DMFlaggedEvent{
+			Message:    msg,
+			Violations: dto.ToModerationViolationDTOs(review.Flagged),
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+
+	"voice-chat/internal/domain/entity"
+	"voice-chat/internal/interface/dto"
+	"voice-chat/internal/usecase/chat"
+)
+
+func (h *WebSocketHandler) handleSetTopic(client *Client, payload json.RawMessage) {
+	if client.RoomID == "" {
+		h.sendError(client, "NOT_IN_ROOM", "You must join a room first")
+		return
+	}
+
+	var req dto.SetTopicRequest
+	if err := json.Unmarshal(payload, &req); err != nil {
+		h.sendError(client, "INVALID_PAYLOAD", "Invalid topic request")
+		return
+	}
+
+	room, err := h.topicUC.Set(chat.SetTopicInput{
+		RoomID:  client.RoomID,
+		UserID:  client.UserID,
+		IsAdmin: client.IsAdmin,
+		Topic:   req.Topic,
+	})
+	if errors.Is(err, chat.ErrNotAllowedToSetTopic) {
+		h.sendError(client, "NOT_AUTHORIZED", err.Error())
+		return
+	}
+	if err != nil {
+		h.sendError(client, "TOPIC_FAILED", err.Error())
+		return
+	}
+
+	log.Printf("UserID=%s set the topic of room %s", client.UserID, room.ID)
+	h.broadcastToRoomAll(room.ID, "room_updated", dto.ToRoomUpdatedEvent(room, client.UserName))
+}
+
+func (h *WebSocketHandler) handlePinMessage(client *Client, payload json.RawMessage, pin bool) {
+	if client.RoomID == "" {
+		h.sendError(client, "NOT_IN_ROOM", "You must join a room first")
+		return
+	}
+
+	var req dto.PinMessageRequest
+	if err := json.Unmarshal(payload, &req); err != nil {
+		h.sendError(client, "INVALID_PAYLOAD", "Invalid pin request")
+		return
+	}
+
+	input := chat.PinInput{
+		RoomID:    client.RoomID,
+		MessageID: req.MessageID,
+		UserID:    client.UserID,
+		UserName:  client.UserName,
+		IsAdmin:   client.IsAdmin,
+	}
+	var room *entity.Room
+	var err error
+	if pin {
+		room, err = h.pinUC.Pin(input)
+	} else {
+		room, err = h.pinUC.Unpin(input)
+	}
+	if errors.Is(err, chat.ErrNotAllowedToPin) {
+		h.sendError(client, "NOT_AUTHORIZED", err.Error())
+		return
+	}
+	if errors.Is(err, chat.ErrPinMessageNotFound) || errors.Is(err, entity.ErrNotPinned) {
+		h.sendError(client, "MESSAGE_NOT_FOUND", err.Error())
+		return
+	}
+	if err != nil {
+		h.sendError(client, "PIN_FAILED", err.Error())
+		return
+	}
+
+	log.Printf("UserID=%s pinned=%t message %s in room %s", client.UserID, pin, req.MessageID, room.ID)
+	h.broadcastToRoomAll(room.ID, "room_updated", dto.ToRoomUpdatedEvent(room, client.UserName))
+}
+
+// refreshPin updates a pinned message after an edit or delete and tells the room when it changed
+func (h *WebSocketHandler) refreshPin(msg *entity.ChatMessage) {
+	if room := h.pinUC.Refresh(msg); room != nil {
+		h.broadcastToRoomAll(room.ID, "room_updated", dto.ToRoomUpdatedEvent(room, ""))
+	}
+}
@@ -0,0 +1,159 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"time"
+
+	"voice-chat/internal/domain/entity"
+	"voice-chat/internal/interface/dto"
+	"voice-chat/internal/usecase/chat"
+)
+
+func (h *WebSocketHandler) handleCreatePoll(client *Client, payload json.RawMessage) {
+	if client.RoomID == "" {
+		h.sendError(client, "NOT_IN_ROOM", "You must join a room first")
+		return
+	}
+
+	var req dto.CreatePollRequest
+	if err := json.Unmarshal(payload, &req); err != nil {
+		h.sendError(client, "INVALID_PAYLOAD", "Invalid poll request")
+		return
+	}
+
+	// Polls are chat messages, so room mutes and slow mode apply to them too
+	now := time.Now()
+	if wait := h.roomMuteUC.Remaining(client.RoomID, client.UserID, now); wait > 0 {
+		h.sendRetryError(client, "CHAT_MUTED", "A moderator has muted you in this room", wait)
+		return
+	}
+	if wait := h.slowModeUC.Remaining(client.RoomID, client.UserID, client.IsAdmin, now); wait > 0 {
+		h.sendRetryError(client, "SLOW_MODE", "Slow mode is on, wait before sending another message", wait)
+		return
+	}
+
+	msg, err := h.pollUC.Create(chat.CreatePollInput{
+		RoomID:      client.RoomID,
+		UserID:      client.UserID,
+		UserName:    client.UserName,
+		Question:    req.Question,
+		Options:     req.Options,
+		MultiChoice: req.MultiChoice,
+		Anonymous:   req.Anonymous,
+		Duration:    time.Duration(req.DurationSeconds) * time.Second,
+	})
+	if errors.Is(err, entity.ErrInvalidPoll) {
+		h.sendError(client, "INVALID_POLL", err.Error())
+		return
+	}
+	if err != nil {
+		log.Printf("Error saving poll: %v", err)
+		h.sendError(client, "CHAT_ERROR", "Failed to save poll")
+		return
+	}
+
+	h.slowModeUC.Record(client.RoomID, client.UserID, now)
+	h.broadcastToRoomAll(client.RoomID, "chat_message", dto.ChatMessageEvent{
+		Message: dto.ToChatMessageDTO(msg),
+	})
+}
+
+func (h *WebSocketHandler) handlePollVote(client *Client, payload json.RawMessage) {
+	if client.RoomID == "" {
+		h.sendError(client, "NOT_IN_ROOM", "You must join a room first")
+		return
+	}
+
+	var req dto.PollVoteRequest
+	if err := json.Unmarshal(payload, &req); err != nil {
+		h.sendError(client, "INVALID_PAYLOAD", "Invalid vote request")
+		return
+	}
+
+	update, err := h.pollUC.Vote(chat.VotePollInput{
+		RoomID:    client.RoomID,
+		MessageID: req.MessageID,
+		UserID:    client.UserID,
+		Choices:   req.Choices,
+	})
+	if err != nil {
+		h.sendPollError(client, err)
+		return
+	}
+
+	h.broadcastPoll(update)
+}
+
+func (h *WebSocketHandler) handleClosePoll(client *Client, payload json.RawMessage) {
+	if client.RoomID == "" {
+		h.sendError(client, "NOT_IN_ROOM", "You must join a room first")
+		return
+	}
+
+	var req dto.ClosePollRequest
+	if err := json.Unmarshal(payload, &req); err != nil {
+		h.sendError(client, "INVALID_PAYLOAD", "Invalid close poll request")
+		return
+	}
+
+	update, err := h.pollUC.Close(chat.ClosePollInput{
+		RoomID:    client.RoomID,
+		MessageID: req.MessageID,
+		UserID:    client.UserID,
+		IsAdmin:   client.IsAdmin,
+	})
+	if errors.Is(err, chat.ErrNotAllowedToClosePoll) {
+		h.sendError(client, "NOT_AUTHORIZED", err.Error())
+		return
+	}
+	if err != nil {
+		h.sendPollError(client, err)
+		return
+	}
+
+	log.Printf("UserID=%s closed poll %s in room %s", client.UserID, req.MessageID, client.RoomID)
+	h.broadcastPoll(update)
+}
+
+// sendPollError tells a client why a vote or close failed
+func (h *WebSocketHandler) sendPollError(client *Client, err error) {
+	switch {
+	case errors.Is(err, entity.ErrPollClosed):
+		h.sendError(client, "POLL_CLOSED", err.Error())
+	case errors.Is(err, entity.ErrInvalidVote):
+		h.sendError(client, "INVALID_VOTE", err.Error())
+	case errors.Is(err, entity.ErrNotAPoll):
+		h.sendError(client, "MESSAGE_NOT_FOUND", err.Error())
+	default:
+		h.sendError(client, "MESSAGE_NOT_FOUND", "Poll not found")
+	}
+}
+
+// broadcastPoll sends a poll's results to its room
+func (h *WebSocketHandler) broadcastPoll(update *chat.PollUpdate) {
+	h.broadcastToRoomAll(update.RoomID, "poll_updated", dto.PollUpdatedEvent{
+		RoomID:    update.RoomID,
+		MessageID: update.MessageID,
+		Poll:      dto.ToPollDTO(update.Poll, time.Now()),
+	})
+}
+
+// RunPollExpiry closes polls whose timer ran out until ctx is cancelled
+func (h *WebSocketHandler) RunPollExpiry(ctx context.Context) {
+	ticker := time.NewTicker(1 * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			for _, update := range h.pollUC.CloseExpired(now) {
+				h.broadcastPoll(update)
+			}
+		}
+	}
+}
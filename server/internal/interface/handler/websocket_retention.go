@@ -0,0 +1,112 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+
+	"voice-chat/internal/domain/entity"
+	"voice-chat/internal/interface/dto"
+	"voice-chat/internal/usecase/chat"
+)
+
+func (h *WebSocketHandler) handleAdminSetRetention(client *Client, payload json.RawMessage) {
+	if !h.requireAdmin(client) {
+		return
+	}
+
+	var req dto.AdminSetRetentionRequest
+	if err := json.Unmarshal(payload, &req); err != nil {
+		h.sendError(client, "INVALID_PAYLOAD", "Invalid retention request")
+		return
+	}
+
+	room, err := h.retentionUC.Set(chat.SetRetentionInput{
+		RoomID:  req.RoomID,
+		IsAdmin: client.IsAdmin,
+		Policy: entity.ChatRetention{
+			Mode:     entity.RetentionMode(req.Mode),
+			Messages: req.Messages,
+			Days:     req.Days,
+		},
+	})
+	switch {
+	case errors.Is(err, entity.ErrInvalidRetention):
+		h.sendError(client, "INVALID_RETENTION", err.Error())
+		return
+	case errors.Is(err, chat.ErrRoomNotFound):
+		h.sendError(client, "ROOM_NOT_FOUND", err.Error())
+		return
+	case err != nil:
+		log.Printf("Error setting chat retention for room %s: %v", req.RoomID, err)
+		h.sendError(client, "RETENTION_ERROR", "Failed to change chat retention")
+		return
+	}
+
+	log.Printf("Admin %s set chat retention of room %s to %s", client.UserID, room.Name, room.GetRetention().Mode)
+	h.sendToClient(client, "room_retention", dto.ToRoomRetentionResponse(room))
+}
+
+func (h *WebSocketHandler) handleAdminListArchives(client *Client) {
+	if !h.requireAdmin(client) {
+		return
+	}
+
+	archives, err := h.retentionUC.ListArchives(client.IsAdmin)
+	if err != nil {
+		log.Printf("Error listing chat archives: %v", err)
+		h.sendError(client, "ARCHIVE_ERROR", "Failed to list chat archives")
+		return
+	}
+
+	h.sendToClient(client, "chat_archives", dto.ChatArchivesResponse{
+		Archives: dto.ToChatArchiveDTOs(archives),
+	})
+}
+
+// HandleArchiveDownload downloads an archived transcript of a closed room:
+//
+//	GET /api/archives/<archiveID>?format=jsonl|txt|html
+//	Authorization: Bearer <session token of an authenticated admin>
+func (h *WebSocketHandler) HandleArchiveDownload(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		h.writeAPIError(w, r, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", 0)
+		return
+	}
+
+	client := h.exportClient(w, r)
+	if client == nil {
+		return
+	}
+
+	archiveID := strings.Trim(strings.TrimPrefix(r.URL.Path, "/api/archives/"), "/")
+	if archiveID == "" || strings.Contains(archiveID, "/") {
+		http.NotFound(w, r)
+		return
+	}
+
+	format, err := chat.ParseExportFormat(r.URL.Query().Get("format"))
+	if err != nil {
+		h.writeAPIError(w, r, http.StatusBadRequest, "INVALID_FORMAT", err.Error(), 0)
+		return
+	}
+
+	export, err := h.retentionUC.GetArchive(archiveID, format, client.IsAdmin)
+	switch {
+	case errors.Is(err, chat.ErrNotAllowedToExport):
+		h.writeAPIError(w, r, http.StatusForbidden, "NOT_AUTHORIZED", err.Error(), 0)
+		return
+	case errors.Is(err, chat.ErrArchiveNotFound), errors.Is(err, chat.ErrArchivesDisabled):
+		h.writeAPIError(w, r, http.StatusNotFound, "ARCHIVE_NOT_FOUND", err.Error(), 0)
+		return
+	case err != nil:
+		log.Printf("Error loading chat archive %s: %v", archiveID, err)
+		h.writeAPIError(w, r, http.StatusInternalServerError, "EXPORT_FAILED", "Failed to load the archive", 0)
+		return
+	}
+
+	log.Printf("Admin %s downloaded chat archive %s of room %s as %s", client.UserID, archiveID, export.RoomName, format)
+	writeChatExport(w, export)
+}
@@ -0,0 +1,68 @@
+package handler
+
+import (
+	"encoding/json"
+	"log"
+
+	"voice-chat/internal/domain/repository"
+	"voice-chat/internal/interface/dto"
+)
+
+func (h *WebSocketHandler) handleSearchChat(client *Client, payload json.RawMessage) {
+	if client.RoomID == "" {
+		h.sendError(client, "NOT_IN_ROOM", "You must join a room first")
+		return
+	}
+
+	var req dto.SearchChatRequest
+	if err := json.Unmarshal(payload, &req); err != nil {
+		h.sendError(client, "INVALID_PAYLOAD", "Invalid search request")
+		return
+	}
+
+	results, err := h.searchUC.SearchRoom(client.RoomID, req.Query, req.Limit)
+	if err != nil {
+		h.sendError(client, "SEARCH_FAILED", err.Error())
+		return
+	}
+
+	h.sendToClient(client, "chat_search_results", dto.ChatSearchResponse{
+		Query:   req.Query,
+		Results: dto.ToChatSearchResultDTOs(results),
+	})
+}
+
+func (h *WebSocketHandler) handleAdminSearchChat(client *Client, payload json.RawMessage) {
+	if !h.requireAdmin(client) {
+		return
+	}
+
+	var req dto.AdminSearchChatRequest
+	if err := json.Unmarshal(payload, &req); err != nil {
+		h.sendError(client, "INVALID_PAYLOAD", "Invalid search request")
+		return
+	}
+
+	results, err := h.searchUC.SearchAll(repository.ChatSearchQuery{
+		Text:       req.Query,
+		RoomID:     req.RoomID,
+		RoomName:   req.RoomName,
+		SenderID:   req.SenderID,
+		SenderName: req.SenderName,
+		Since:      req.Since,
+		Until:      req.Until,
+		Limit:      req.Limit,
+	})
+	if err != nil {
+		h.sendError(client, "SEARCH_FAILED", err.Error())
+		return
+	}
+
+	log.Printf("Admin %s searched chat: query=%q room=%q sender=%q results=%d",
+		client.UserID, req.Query, req.RoomName, req.SenderName, len(results))
+
+	h.sendToClient(client, "admin_chat_search_results", dto.ChatSearchResponse{
+		Query:   req.Query,
+		Results: dto.ToChatSearchResultDTOs(results),
+	})
+}
@@ -0,0 +1,175 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"voice-chat/internal/interface/dto"
+	"voice-chat/internal/usecase/attachment"
+	"voice-chat/internal/usecase/chat"
+)
+
+// HandleUploads serves the attachment API:
+//
+//	POST /api/uploads                           multipart "file" field, Authorization: Bearer <session token>
+//	GET  /api/uploads/<roomID>/<id>[/thumbnail]  the stored file
+//
+// Uploads are only accepted from a connected client that is in a room. Files are fetched by
+// unguessable URL without a token so they work in <img> tags.
+func (h *WebSocketHandler) HandleUploads(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodPost:
+		h.handleUpload(w, r)
+	case http.MethodGet, http.MethodHead:
+		h.serveAttachment(w, r)
+	default:
+		h.writeAPIError(w, r, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", 0)
+	}
+}
+
+func (h *WebSocketHandler) handleUpload(w http.ResponseWriter, r *http.Request) {
+	client := h.clientBySession(strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer "))
+	if client == nil {
+		h.writeAPIError(w, r, http.StatusUnauthorized, "NOT_AUTHORIZED", "A valid session token is required", 0)
+		return
+	}
+	if client.RoomID == "" {
+		h.writeAPIError(w, r, http.StatusForbidden, "NOT_IN_ROOM", "You must join a room first", 0)
+		return
+	}
+
+	decision := h.floodUC.Check(chat.FloodCheckInput{
+		ConnectionID: client.ID,
+		SessionID:    client.SessionID,
+		IP:           client.IP,
+		MessageType:  "upload",
+	}, time.Now())
+	if !decision.Allowed {
+		h.writeAPIError(w, r, http.StatusTooManyRequests, "RATE_LIMITED", "You are uploading too fast, slow down", decision.RetryAfter)
+		return
+	}
+
+	// Leave room for the multipart framing around the file
+	r.Body = http.MaxBytesReader(w, r.Body, h.attachUC.MaxSize()+64<<10)
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			h.writeAPIError(w, r, http.StatusRequestEntityTooLarge, "FILE_TOO_LARGE", attachment.ErrFileTooLarge.Error(), 0)
+			return
+		}
+		h.writeAPIError(w, r, http.StatusBadRequest, "INVALID_PAYLOAD", "Expected a multipart form with a file field", 0)
+		return
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(io.LimitReader(file, h.attachUC.MaxSize()+1))
+	if err != nil {
+		h.writeAPIError(w, r, http.StatusBadRequest, "INVALID_PAYLOAD", "Failed to read the uploaded file", 0)
+		return
+	}
+
+	att, err := h.attachUC.Upload(attachment.UploadInput{
+		RoomID:   client.RoomID,
+		UserID:   client.UserID,
+		FileName: header.Filename,
+		Data:     data,
+	})
+	switch {
+	case errors.Is(err, attachment.ErrFileTooLarge):
+		h.writeAPIError(w, r, http.StatusRequestEntityTooLarge, "FILE_TOO_LARGE", err.Error(), 0)
+		return
+	case errors.Is(err, attachment.ErrUnsupportedType):
+		h.writeAPIError(w, r, http.StatusUnsupportedMediaType, "UNSUPPORTED_FILE_TYPE", err.Error(), 0)
+		return
+	case errors.Is(err, attachment.ErrEmptyFile), errors.Is(err, attachment.ErrInvalidImage):
+		h.writeAPIError(w, r, http.StatusBadRequest, "INVALID_FILE", err.Error(), 0)
+		return
+	case err != nil:
+		log.Printf("Error storing upload from UserID=%s: %v", client.UserID, err)
+		h.writeAPIError(w, r, http.StatusInternalServerError, "UPLOAD_FAILED", "Failed to store the file", 0)
+		return
+	}
+
+	log.Printf("UserID=%s uploaded %s (%s, %d bytes) to room %s", client.UserID, att.ID, att.ContentType, att.Size, client.RoomID)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(dto.ToAttachmentDTO(att))
+}
+
+func (h *WebSocketHandler) serveAttachment(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/api/uploads/"), "/")
+	thumbnail := len(parts) == 3 && parts[2] == "thumbnail"
+	if len(parts) != 2 && !thumbnail {
+		http.NotFound(w, r)
+		return
+	}
+
+	f, modTime, err := h.attachUC.Open(parts[0], parts[1], thumbnail)
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+	defer f.Close()
+
+	// Content was sniffed on upload, so only images are shown inline and nothing is re-sniffed
+	head := make([]byte, 512)
+	n, _ := io.ReadFull(f, head)
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		http.Error(w, "Failed to read file", http.StatusInternalServerError)
+		return
+	}
+	contentType := http.DetectContentType(head[:n])
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.Header().Set("Cache-Control", "private, max-age=86400")
+	if !strings.HasPrefix(contentType, "image/") {
+		w.Header().Set("Content-Disposition", "attachment")
+	}
+
+	http.ServeContent(w, r, "", modTime, f)
+}
+
+// clientBySession finds a connected client by its session token, preferring one that is in a room
+func (h *WebSocketHandler) clientBySession(sessionID string) *Client {
+	if sessionID == "" {
+		return nil
+	}
+
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	var found *Client
+	for _, c := range h.clients {
+		if c.SessionID != sessionID {
+			continue
+		}
+		if c.RoomID != "" {
+			return c
+		}
+		found = c
+	}
+	return found
+}
+
+// writeAPIError writes an HTTP API error in the same shape as WebSocket errors, localised
+// for the request's ?locale= or Accept-Language
+func (h *WebSocketHandler) writeAPIError(w http.ResponseWriter, r *http.Request, status int, code, message string, retryAfter time.Duration) {
+	if retryAfter > 0 {
+		w.Header().Set("Retry-After", strconv.Itoa(int(retryAfter.Seconds()+0.999)))
+	}
+	locale := h.catalog.Negotiate(r.URL.Query().Get("locale"), r.Header.Get("Accept-Language"))
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(h.localize(locale, dto.ErrorResponse{
+		Code:         code,
+		Message:      message,
+		RetryAfterMs: retryAfter.Milliseconds(),
+	}))
+}
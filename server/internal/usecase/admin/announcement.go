@@ -0,0 +1,231 @@
+package admin
+
+import (
+	"errors"
+	"log"
+	"strings"
+	"sync"
+
+	"github.com/google/uuid"
+
+	"voice-chat/internal/domain/entity"
+	"voice-chat/internal/domain/repository"
+)
+
+const MaxAnnouncementLength = 500
+
+var (
+	ErrEmptyAnnouncement   = errors.New("announcement content is empty")
+	ErrAnnouncementTooLong = errors.New("announcement content is too long")
+	ErrVoicePAActive       = errors.New("a voice announcement is already in progress")
+	ErrVoicePANotActive    = errors.New("no voice announcement is in progress")
+	ErrNoActiveRoomsForPA  = errors.New("no active rooms to announce to")
+	ErrInvalidTarget       = errors.New("invalid announcement target")
+	ErrNoTargetRooms       = errors.New("room target requires at least one room")
+)
+
+// BroadcastAnnouncementInput represents input for a text announcement
+type BroadcastAnnouncementInput struct {
+	AdminID   string
+	Content   string
+	Target    entity.AnnouncementTarget // Defaults to AnnouncementTargetAll
+	RoomNames []string                  // Required for AnnouncementTargetRooms
+}
+
+// BroadcastAnnouncementOutput represents the result of a text announcement
+type BroadcastAnnouncementOutput struct {
+	Announcement *entity.Announcement
+	// SystemMessages holds the stored system message for each room (roomID -> message)
+	SystemMessages map[string]*entity.ChatMessage
+	// FailedRooms are targeted rooms whose system message couldn't be stored (roomID -> name);
+	// they still get the announcement itself
+	FailedRooms map[string]string
+}
+
+// AnnouncementUseCase handles café-wide text and voice announcements
+type AnnouncementUseCase struct {
+	roomRepo     repository.RoomRepository
+	chatRepo     repository.ChatRepository
+	activityRepo repository.ActivityRepository
+	paAdminID    string         // Admin currently speaking over voice PA
+	paRooms      []*entity.Room // Rooms reached by the current voice PA
+	mu           sync.Mutex     // protects voice PA state
+}
+
+// NewAnnouncementUseCase creates a new AnnouncementUseCase
+func NewAnnouncementUseCase(
+	roomRepo repository.RoomRepository,
+	chatRepo repository.ChatRepository,
+	activityRepo repository.ActivityRepository,
+) *AnnouncementUseCase {
+	return &AnnouncementUseCase{
+		roomRepo:     roomRepo,
+		chatRepo:     chatRepo,
+		activityRepo: activityRepo,
+	}
+}
+
+// Broadcast creates a text announcement and stores it as a system message in every targeted open room
+func (uc *AnnouncementUseCase) Broadcast(input BroadcastAnnouncementInput) (*BroadcastAnnouncementOutput, error) {
+	content, err := validateAnnouncement(input.Content, input.Target, input.RoomNames)
+	if err != nil {
+		return nil, err
+	}
+
+	rooms, err := uc.roomRepo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	announcement := entity.NewAnnouncement(uuid.New().String(), content, input.AdminID)
+	if input.Target != "" {
+		announcement.Target = input.Target
+	}
+	announcement.RoomNames = input.RoomNames
+
+	targeted := make(map[string]bool, len(input.RoomNames))
+	for _, name := range input.RoomNames {
+		targeted[name] = true
+	}
+
+	// Store a system message in each targeted room (lobby announcements have no room chat).
+	// A room that fails to store it doesn't stop the others from getting theirs.
+	messages := make(map[string]*entity.ChatMessage)
+	failed := make(map[string]string)
+	for _, room := range rooms {
+		if room.IsClosed || announcement.Target == entity.AnnouncementTargetLobby {
+			continue
+		}
+		if announcement.Target == entity.AnnouncementTargetRooms && !targeted[room.Name] {
+			continue
+		}
+		msg := entity.NewSystemEvent(
+			uuid.New().String(),
+			room.ID,
+			input.AdminID,
+			entity.AnnouncementSenderName,
+			entity.SystemCodeAnnouncement,
+			map[string]string{"text": content},
+			content,
+		)
+		if err := uc.chatRepo.AddMessage(msg); err != nil {
+			log.Printf("Error storing announcement in room %s: %v", room.Name, err)
+			failed[room.ID] = room.Name
+			continue
+		}
+		messages[room.ID] = msg
+	}
+
+	// Log activity
+	if uc.activityRepo != nil {
+		activity := entity.NewActivityLog(
+			uuid.New().String(),
+			entity.ActivityTypeAnnouncement,
+			input.AdminID,
+			"admin",
+			"",
+			"",
+			"",
+		)
+		activity.AddDetail("content", content)
+		activity.AddDetail("target", string(announcement.Target))
+		activity.AddDetail("rooms", len(messages))
+		if len(failed) > 0 {
+			activity.AddDetail("failed_rooms", len(failed))
+		}
+		_ = uc.activityRepo.Log(activity)
+	}
+
+	return &BroadcastAnnouncementOutput{
+		Announcement:   announcement,
+		SystemMessages: messages,
+		FailedRooms:    failed,
+	}, nil
+}
+
+// validateAnnouncement checks announcement content and target, returning the trimmed content
+func validateAnnouncement(content string, target entity.AnnouncementTarget, roomNames []string) (string, error) {
+	content = strings.TrimSpace(content)
+	if content == "" {
+		return "", ErrEmptyAnnouncement
+	}
+	if len(content) > MaxAnnouncementLength {
+		return "", ErrAnnouncementTooLong
+	}
+	if target != "" && !target.IsValid() {
+		return "", ErrInvalidTarget
+	}
+	if target == entity.AnnouncementTargetRooms && len(roomNames) == 0 {
+		return "", ErrNoTargetRooms
+	}
+	return content, nil
+}
+
+// StartVoicePA starts a voice announcement and returns the rooms the admin should publish to
+func (uc *AnnouncementUseCase) StartVoicePA(adminID string) ([]*entity.Room, error) {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	if uc.paAdminID != "" {
+		return nil, ErrVoicePAActive
+	}
+
+	rooms, err := uc.roomRepo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	// Only rooms with someone in them can hear the announcement
+	active := make([]*entity.Room, 0, len(rooms))
+	for _, room := range rooms {
+		if !room.IsClosed && !room.IsEmpty() {
+			active = append(active, room)
+		}
+	}
+	if len(active) == 0 {
+		return nil, ErrNoActiveRoomsForPA
+	}
+
+	uc.paAdminID = adminID
+	uc.paRooms = active
+
+	// Log activity
+	if uc.activityRepo != nil {
+		activity := entity.NewActivityLog(
+			uuid.New().String(),
+			entity.ActivityTypeVoicePA,
+			adminID,
+			"admin",
+			"",
+			"",
+			"",
+		)
+		activity.AddDetail("rooms", len(active))
+		_ = uc.activityRepo.Log(activity)
+	}
+
+	return active, nil
+}
+
+// StopVoicePA ends the current voice announcement and returns the rooms it reached
+func (uc *AnnouncementUseCase) StopVoicePA(adminID string) ([]*entity.Room, error) {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	if uc.paAdminID == "" || uc.paAdminID != adminID {
+		return nil, ErrVoicePANotActive
+	}
+
+	rooms := uc.paRooms
+	uc.paAdminID = ""
+	uc.paRooms = nil
+	return rooms, nil
+}
+
+// IsVoicePAActive reports whether the given admin is running a voice announcement
+func (uc *AnnouncementUseCase) IsVoicePAActive(adminID string) bool {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	return uc.paAdminID != "" && uc.paAdminID == adminID
+}
@@ -0,0 +1,117 @@
+package admin
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"voice-chat/internal/domain/entity"
+	"voice-chat/internal/infrastructure/persistence"
+)
+
+func TestAnnouncementUseCase_Broadcast(t *testing.T) {
+	roomRepo := persistence.NewInMemoryRoomRepository()
+	chatRepo := persistence.NewInMemoryChatRepository()
+	roomRepo.Create(entity.NewRoom("room-1", "Lobby", entity.RoomTypePublic, "admin"))
+	roomRepo.Create(entity.NewRoom("room-2", "Team A", entity.RoomTypePrivate, "user-1"))
+	closed := entity.NewRoom("room-3", "Closed", entity.RoomTypePublic, "admin")
+	closed.Close()
+	roomRepo.Create(closed)
+	uc := NewAnnouncementUseCase(roomRepo, chatRepo, nil)
+
+	result, err := uc.Broadcast(BroadcastAnnouncementInput{
+		AdminID: "admin-1",
+		Content: "  Closing in 15 minutes ",
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Closing in 15 minutes", result.Announcement.Content)
+	assert.Len(t, result.SystemMessages, 2)
+
+	messages, _ := chatRepo.GetMessages("room-2", 10)
+	assert.Len(t, messages, 1)
+	assert.Equal(t, entity.ChatMessageTypeSystem, messages[0].Type)
+	assert.Equal(t, entity.AnnouncementSenderName, messages[0].SenderName)
+	assert.Equal(t, entity.SystemCodeAnnouncement, messages[0].Code)
+	assert.Equal(t, map[string]string{"text": "Closing in 15 minutes"}, messages[0].Params)
+
+	messages, _ = chatRepo.GetMessages("room-3", 10)
+	assert.Empty(t, messages)
+}
+
+// failingChatRepository fails to store messages for one room
+type failingChatRepository struct {
+	*persistence.InMemoryChatRepository
+	roomID string
+}
+
+func (r *failingChatRepository) AddMessage(msg *entity.ChatMessage) error {
+	if msg.RoomID == r.roomID {
+		return errors.New("disk full")
+	}
+	return r.InMemoryChatRepository.AddMessage(msg)
+}
+
+func TestAnnouncementUseCase_Broadcast_PartialFailure(t *testing.T) {
+	roomRepo := persistence.NewInMemoryRoomRepository()
+	chatRepo := &failingChatRepository{InMemoryChatRepository: persistence.NewInMemoryChatRepository(), roomID: "room-2"}
+	roomRepo.Create(entity.NewRoom("room-1", "Lobby", entity.RoomTypePublic, "admin"))
+	roomRepo.Create(entity.NewRoom("room-2", "Team A", entity.RoomTypePublic, "admin"))
+	roomRepo.Create(entity.NewRoom("room-3", "Team B", entity.RoomTypePublic, "admin"))
+	uc := NewAnnouncementUseCase(roomRepo, chatRepo, nil)
+
+	result, err := uc.Broadcast(BroadcastAnnouncementInput{AdminID: "admin-1", Content: "Last orders"})
+
+	// The other rooms still get their message
+	assert.NoError(t, err)
+	assert.Len(t, result.SystemMessages, 2)
+	assert.Equal(t, map[string]string{"room-2": "Team A"}, result.FailedRooms)
+
+	messages, _ := chatRepo.GetMessages("room-3", 10)
+	assert.Len(t, messages, 1)
+}
+
+func TestAnnouncementUseCase_Broadcast_Empty(t *testing.T) {
+	uc := NewAnnouncementUseCase(persistence.NewInMemoryRoomRepository(), persistence.NewInMemoryChatRepository(), nil)
+
+	_, err := uc.Broadcast(BroadcastAnnouncementInput{AdminID: "admin-1", Content: "   "})
+
+	assert.ErrorIs(t, err, ErrEmptyAnnouncement)
+}
+
+func TestAnnouncementUseCase_VoicePA(t *testing.T) {
+	roomRepo := persistence.NewInMemoryRoomRepository()
+	active := entity.NewRoom("room-1", "Lobby", entity.RoomTypePublic, "admin")
+	active.AddParticipant(entity.NewUser("user-1", "User1", "127.0.0.1", entity.VoiceModePTT))
+	roomRepo.Create(active)
+	roomRepo.Create(entity.NewRoom("room-2", "Empty", entity.RoomTypePublic, "admin"))
+	uc := NewAnnouncementUseCase(roomRepo, persistence.NewInMemoryChatRepository(), nil)
+
+	rooms, err := uc.StartVoicePA("admin-1")
+	assert.NoError(t, err)
+	assert.Len(t, rooms, 1)
+	assert.Equal(t, "room-1", rooms[0].ID)
+	assert.True(t, uc.IsVoicePAActive("admin-1"))
+
+	_, err = uc.StartVoicePA("admin-2")
+	assert.ErrorIs(t, err, ErrVoicePAActive)
+
+	_, err = uc.StopVoicePA("admin-2")
+	assert.ErrorIs(t, err, ErrVoicePANotActive)
+
+	rooms, err = uc.StopVoicePA("admin-1")
+	assert.NoError(t, err)
+	assert.Len(t, rooms, 1)
+	assert.False(t, uc.IsVoicePAActive("admin-1"))
+}
+
+func TestAnnouncementUseCase_VoicePA_NoActiveRooms(t *testing.T) {
+	roomRepo := persistence.NewInMemoryRoomRepository()
+	roomRepo.Create(entity.NewRoom("room-1", "Empty", entity.RoomTypePublic, "admin"))
+	uc := NewAnnouncementUseCase(roomRepo, persistence.NewInMemoryChatRepository(), nil)
+
+	_, err := uc.StartVoicePA("admin-1")
+
+	assert.ErrorIs(t, err, ErrNoActiveRoomsForPA)
+}
@@ -0,0 +1,182 @@
+package admin
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"voice-chat/internal/domain/entity"
+	"voice-chat/internal/domain/repository"
+)
+
+const (
+	DefaultMaintenanceMessage = "The server is going down for maintenance"
+	MaxMaintenanceDelay       = 24 * time.Hour
+)
+
+var (
+	ErrMaintenanceScheduled    = errors.New("maintenance is already scheduled")
+	ErrMaintenanceNotScheduled = errors.New("no maintenance is scheduled")
+	ErrInvalidMaintenanceDelay = errors.New("maintenance delay must be between 0 and 24 hours")
+	ErrMaintenanceMode         = errors.New("server is in maintenance mode, new joins are disabled")
+)
+
+// maintenanceReminders are the remaining times at which a countdown is re-broadcast
+var maintenanceReminders = []time.Duration{
+	30 * time.Minute,
+	15 * time.Minute,
+	10 * time.Minute,
+	5 * time.Minute,
+	2 * time.Minute,
+	1 * time.Minute,
+	30 * time.Second,
+	10 * time.Second,
+}
+
+// ScheduleMaintenanceInput represents input for scheduling maintenance
+type ScheduleMaintenanceInput struct {
+	AdminID string
+	Delay   time.Duration
+	Message string
+}
+
+// MaintenanceTick reports what the countdown should announce at a point in time
+type MaintenanceTick struct {
+	Window   *entity.MaintenanceWindow
+	Reminder bool // A countdown reminder threshold was crossed
+	Started  bool // Maintenance has just become active
+}
+
+// MaintenanceUseCase handles admin-triggered maintenance mode
+type MaintenanceUseCase struct {
+	activityRepo repository.ActivityRepository
+	window       *entity.MaintenanceWindow
+	nextReminder int  // index into maintenanceReminders
+	started      bool // whether the start has been announced
+	mu           sync.Mutex
+}
+
+// NewMaintenanceUseCase creates a new MaintenanceUseCase
+func NewMaintenanceUseCase(activityRepo repository.ActivityRepository) *MaintenanceUseCase {
+	return &MaintenanceUseCase{
+		activityRepo: activityRepo,
+	}
+}
+
+// Schedule starts a maintenance countdown
+func (uc *MaintenanceUseCase) Schedule(input ScheduleMaintenanceInput) (*entity.MaintenanceWindow, error) {
+	if input.Delay < 0 || input.Delay > MaxMaintenanceDelay {
+		return nil, ErrInvalidMaintenanceDelay
+	}
+
+	message := strings.TrimSpace(input.Message)
+	if message == "" {
+		message = DefaultMaintenanceMessage
+	}
+
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	if uc.window != nil {
+		return nil, ErrMaintenanceScheduled
+	}
+
+	uc.window = entity.NewMaintenanceWindow(message, input.AdminID, input.Delay)
+	uc.started = false
+
+	// Skip reminders that are already past
+	uc.nextReminder = 0
+	for uc.nextReminder < len(maintenanceReminders) && maintenanceReminders[uc.nextReminder] >= input.Delay {
+		uc.nextReminder++
+	}
+
+	uc.logActivity(input.AdminID, "scheduled")
+
+	return uc.window, nil
+}
+
+// Cancel ends maintenance mode (scheduled or active)
+func (uc *MaintenanceUseCase) Cancel(adminID string) error {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	if uc.window == nil {
+		return ErrMaintenanceNotScheduled
+	}
+
+	uc.window = nil
+	uc.logActivity(adminID, "cancelled")
+	return nil
+}
+
+// Current returns the scheduled maintenance window, or nil if none
+func (uc *MaintenanceUseCase) Current() *entity.MaintenanceWindow {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	return uc.window
+}
+
+// CheckJoinAllowed returns ErrMaintenanceMode once maintenance is active
+func (uc *MaintenanceUseCase) CheckJoinAllowed(now time.Time) error {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	if uc.window != nil && uc.window.IsActive(now) {
+		return ErrMaintenanceMode
+	}
+	return nil
+}
+
+// Tick advances the countdown and reports whether a reminder or the start should be announced
+func (uc *MaintenanceUseCase) Tick(now time.Time) *MaintenanceTick {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	if uc.window == nil || uc.started {
+		return nil
+	}
+
+	if uc.window.IsActive(now) {
+		uc.started = true
+		return &MaintenanceTick{Window: uc.window, Started: true}
+	}
+
+	remaining := uc.window.TimeRemaining(now)
+	reminder := false
+	for uc.nextReminder < len(maintenanceReminders) && remaining <= maintenanceReminders[uc.nextReminder] {
+		uc.nextReminder++
+		reminder = true
+	}
+
+	if !reminder {
+		return nil
+	}
+	return &MaintenanceTick{Window: uc.window, Reminder: true}
+}
+
+// logActivity records a maintenance action (caller must hold the lock)
+func (uc *MaintenanceUseCase) logActivity(adminID, action string) {
+	if uc.activityRepo == nil {
+		return
+	}
+
+	activity := entity.NewActivityLog(
+		uuid.New().String(),
+		entity.ActivityTypeMaintenance,
+		adminID,
+		"admin",
+		"",
+		"",
+		"",
+	)
+	activity.AddDetail("action", action)
+	if uc.window != nil {
+		activity.AddDetail("starts_at", uc.window.StartsAt)
+		activity.AddDetail("message", uc.window.Message)
+	}
+	_ = uc.activityRepo.Log(activity)
+}
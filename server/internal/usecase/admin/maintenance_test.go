@@ -0,0 +1,79 @@
+package admin
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"voice-chat/internal/infrastructure/persistence"
+)
+
+func TestMaintenanceUseCase_Schedule(t *testing.T) {
+	activityRepo := persistence.NewInMemoryActivityRepository()
+	uc := NewMaintenanceUseCase(activityRepo)
+
+	window, err := uc.Schedule(ScheduleMaintenanceInput{
+		AdminID: "admin-1",
+		Delay:   5 * time.Minute,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, DefaultMaintenanceMessage, window.Message)
+	assert.Same(t, window, uc.Current())
+	assert.NoError(t, uc.CheckJoinAllowed(time.Now()))
+	assert.ErrorIs(t, uc.CheckJoinAllowed(window.StartsAt), ErrMaintenanceMode)
+
+	_, err = uc.Schedule(ScheduleMaintenanceInput{AdminID: "admin-1", Delay: time.Minute})
+	assert.ErrorIs(t, err, ErrMaintenanceScheduled)
+}
+
+func TestMaintenanceUseCase_Schedule_InvalidDelay(t *testing.T) {
+	uc := NewMaintenanceUseCase(nil)
+
+	_, err := uc.Schedule(ScheduleMaintenanceInput{Delay: -time.Minute})
+	assert.ErrorIs(t, err, ErrInvalidMaintenanceDelay)
+
+	_, err = uc.Schedule(ScheduleMaintenanceInput{Delay: 25 * time.Hour})
+	assert.ErrorIs(t, err, ErrInvalidMaintenanceDelay)
+}
+
+func TestMaintenanceUseCase_Cancel(t *testing.T) {
+	uc := NewMaintenanceUseCase(nil)
+
+	assert.ErrorIs(t, uc.Cancel("admin-1"), ErrMaintenanceNotScheduled)
+
+	window, _ := uc.Schedule(ScheduleMaintenanceInput{AdminID: "admin-1"})
+	assert.ErrorIs(t, uc.CheckJoinAllowed(window.StartsAt), ErrMaintenanceMode)
+
+	assert.NoError(t, uc.Cancel("admin-1"))
+	assert.Nil(t, uc.Current())
+	assert.NoError(t, uc.CheckJoinAllowed(time.Now()))
+}
+
+func TestMaintenanceUseCase_Tick(t *testing.T) {
+	uc := NewMaintenanceUseCase(nil)
+	window, _ := uc.Schedule(ScheduleMaintenanceInput{AdminID: "admin-1", Delay: 3 * time.Minute})
+
+	// Nothing new until the 2 minute reminder
+	assert.Nil(t, uc.Tick(window.StartsAt.Add(-150*time.Second)))
+
+	tick := uc.Tick(window.StartsAt.Add(-2 * time.Minute))
+	assert.NotNil(t, tick)
+	assert.True(t, tick.Reminder)
+	assert.False(t, tick.Started)
+	assert.Nil(t, uc.Tick(window.StartsAt.Add(-90*time.Second)))
+
+	// Skipped thresholds collapse into a single reminder
+	tick = uc.Tick(window.StartsAt.Add(-5 * time.Second))
+	assert.NotNil(t, tick)
+	assert.True(t, tick.Reminder)
+	assert.Nil(t, uc.Tick(window.StartsAt.Add(-4*time.Second)))
+
+	tick = uc.Tick(window.StartsAt)
+	assert.NotNil(t, tick)
+	assert.True(t, tick.Started)
+
+	// The start is only announced once
+	assert.Nil(t, uc.Tick(window.StartsAt.Add(time.Second)))
+}
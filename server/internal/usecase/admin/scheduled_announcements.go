@@ -0,0 +1,203 @@
+package admin
+
+import (
+	"errors"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"voice-chat/internal/domain/entity"
+	"voice-chat/internal/domain/repository"
+	"voice-chat/pkg/cron"
+)
+
+const (
+	MaxMessageOfTheDayLength = 1000
+
+	// missedRunGrace is how late a scheduled run may fire; older runs (e.g. while the server was down) are skipped
+	missedRunGrace = 2 * time.Minute
+)
+
+var ErrMessageOfTheDayTooLong = errors.New("message of the day is too long")
+
+// ScheduleAnnouncementInput represents input for creating a scheduled announcement
+type ScheduleAnnouncementInput struct {
+	AdminID   string
+	Content   string
+	Cron      string
+	Target    entity.AnnouncementTarget
+	RoomNames []string
+}
+
+// ScheduledAnnouncementsUseCase manages recurring announcements and the message of the day
+type ScheduledAnnouncementsUseCase struct {
+	announcementRepo repository.AnnouncementRepository
+	announcementUC   *AnnouncementUseCase
+	location         *time.Location // time zone cron expressions are evaluated in
+	mu               sync.Mutex     // serializes RunDue so a schedule never fires twice
+}
+
+// NewScheduledAnnouncementsUseCase creates a new ScheduledAnnouncementsUseCase
+func NewScheduledAnnouncementsUseCase(
+	announcementRepo repository.AnnouncementRepository,
+	announcementUC *AnnouncementUseCase,
+	location *time.Location,
+) *ScheduledAnnouncementsUseCase {
+	if location == nil {
+		location = time.Local
+	}
+	return &ScheduledAnnouncementsUseCase{
+		announcementRepo: announcementRepo,
+		announcementUC:   announcementUC,
+		location:         location,
+	}
+}
+
+// Create validates and stores a new scheduled announcement
+func (uc *ScheduledAnnouncementsUseCase) Create(input ScheduleAnnouncementInput) (*entity.ScheduledAnnouncement, error) {
+	target := input.Target
+	if target == "" {
+		target = entity.AnnouncementTargetAll
+	}
+
+	content, err := validateAnnouncement(input.Content, target, input.RoomNames)
+	if err != nil {
+		return nil, err
+	}
+
+	schedule, err := cron.Parse(input.Cron)
+	if err != nil {
+		return nil, err
+	}
+
+	scheduled := entity.NewScheduledAnnouncement(
+		uuid.New().String(),
+		content,
+		schedule.String(),
+		target,
+		input.RoomNames,
+		input.AdminID,
+	)
+
+	if err := uc.announcementRepo.SaveSchedule(scheduled); err != nil {
+		return nil, err
+	}
+
+	return scheduled, nil
+}
+
+// List returns all scheduled announcements
+func (uc *ScheduledAnnouncementsUseCase) List() ([]*entity.ScheduledAnnouncement, error) {
+	return uc.announcementRepo.GetSchedules()
+}
+
+// SetEnabled pauses or resumes a scheduled announcement
+func (uc *ScheduledAnnouncementsUseCase) SetEnabled(id string, enabled bool) (*entity.ScheduledAnnouncement, error) {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	scheduled, err := uc.announcementRepo.GetSchedule(id)
+	if err != nil {
+		return nil, err
+	}
+
+	scheduled.Enabled = enabled
+	if enabled {
+		// Don't fire runs that were missed while paused
+		scheduled.LastRunAt = time.Now()
+	}
+
+	if err := uc.announcementRepo.SaveSchedule(scheduled); err != nil {
+		return nil, err
+	}
+	return scheduled, nil
+}
+
+// Delete removes a scheduled announcement
+func (uc *ScheduledAnnouncementsUseCase) Delete(id string) error {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	return uc.announcementRepo.DeleteSchedule(id)
+}
+
+// MessageOfTheDay returns the current message of the day
+func (uc *ScheduledAnnouncementsUseCase) MessageOfTheDay() string {
+	motd, err := uc.announcementRepo.GetMessageOfTheDay()
+	if err != nil {
+		log.Printf("Error reading message of the day: %v", err)
+		return ""
+	}
+	return motd
+}
+
+// SetMessageOfTheDay replaces the message of the day (empty clears it)
+func (uc *ScheduledAnnouncementsUseCase) SetMessageOfTheDay(motd string) error {
+	motd = strings.TrimSpace(motd)
+	if len(motd) > MaxMessageOfTheDayLength {
+		return ErrMessageOfTheDayTooLong
+	}
+	return uc.announcementRepo.SetMessageOfTheDay(motd)
+}
+
+// RunDue broadcasts every enabled schedule whose next run is due at now and returns the results
+func (uc *ScheduledAnnouncementsUseCase) RunDue(now time.Time) []*BroadcastAnnouncementOutput {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	schedules, err := uc.announcementRepo.GetSchedules()
+	if err != nil {
+		log.Printf("Error loading scheduled announcements: %v", err)
+		return nil
+	}
+
+	results := make([]*BroadcastAnnouncementOutput, 0)
+	for _, scheduled := range schedules {
+		if !scheduled.Enabled {
+			continue
+		}
+
+		schedule, err := cron.Parse(scheduled.Cron)
+		if err != nil {
+			log.Printf("Skipping scheduled announcement %s: %v", scheduled.ID, err)
+			continue
+		}
+
+		last := scheduled.LastRunAt
+		if last.IsZero() {
+			last = scheduled.CreatedAt
+		}
+		next := schedule.Next(last.In(uc.location))
+		if next.IsZero() || next.After(now) {
+			continue
+		}
+
+		scheduled.LastRunAt = now
+		if err := uc.announcementRepo.SaveSchedule(scheduled); err != nil {
+			log.Printf("Error saving scheduled announcement %s: %v", scheduled.ID, err)
+			continue
+		}
+
+		if now.Sub(next) > missedRunGrace {
+			log.Printf("Skipping missed run of scheduled announcement %s (was due %s)", scheduled.ID, next.Format(time.RFC3339))
+			continue
+		}
+
+		result, err := uc.announcementUC.Broadcast(BroadcastAnnouncementInput{
+			AdminID:   scheduled.CreatedBy,
+			Content:   scheduled.Content,
+			Target:    scheduled.Target,
+			RoomNames: scheduled.RoomNames,
+		})
+		if err != nil {
+			log.Printf("Error broadcasting scheduled announcement %s: %v", scheduled.ID, err)
+			continue
+		}
+		results = append(results, result)
+	}
+
+	return results
+}
@@ -0,0 +1,172 @@
+package admin
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"voice-chat/internal/domain/entity"
+	"voice-chat/internal/infrastructure/persistence"
+	"voice-chat/pkg/cron"
+)
+
+func newTestScheduledAnnouncementsUseCase(t *testing.T) (*ScheduledAnnouncementsUseCase, *persistence.InMemoryRoomRepository, *persistence.InMemoryChatRepository, string) {
+	path := filepath.Join(t.TempDir(), "announcements.json")
+	announcementRepo, err := persistence.NewFileAnnouncementRepository(path)
+	assert.NoError(t, err)
+
+	roomRepo := persistence.NewInMemoryRoomRepository()
+	chatRepo := persistence.NewInMemoryChatRepository()
+	announcementUC := NewAnnouncementUseCase(roomRepo, chatRepo, nil)
+	return NewScheduledAnnouncementsUseCase(announcementRepo, announcementUC, time.UTC), roomRepo, chatRepo, path
+}
+
+func TestScheduledAnnouncementsUseCase_Create_InvalidCron(t *testing.T) {
+	uc, _, _, _ := newTestScheduledAnnouncementsUseCase(t)
+
+	_, err := uc.Create(ScheduleAnnouncementInput{
+		AdminID: "admin-1",
+		Content: "Happy hour starts at 18:00",
+		Cron:    "every day at six",
+	})
+
+	assert.ErrorIs(t, err, cron.ErrInvalidExpression)
+}
+
+func TestScheduledAnnouncementsUseCase_Create_RoomsTargetNeedsRooms(t *testing.T) {
+	uc, _, _, _ := newTestScheduledAnnouncementsUseCase(t)
+
+	_, err := uc.Create(ScheduleAnnouncementInput{
+		AdminID: "admin-1",
+		Content: "Tournament check-in opens in 10 minutes",
+		Cron:    "50 19 * * 5",
+		Target:  entity.AnnouncementTargetRooms,
+	})
+
+	assert.ErrorIs(t, err, ErrNoTargetRooms)
+}
+
+func TestScheduledAnnouncementsUseCase_PersistedAcrossRestarts(t *testing.T) {
+	uc, _, _, path := newTestScheduledAnnouncementsUseCase(t)
+
+	created, err := uc.Create(ScheduleAnnouncementInput{
+		AdminID: "admin-1",
+		Content: "Happy hour starts at 18:00",
+		Cron:    "0 18 * * *",
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, uc.SetMessageOfTheDay("  Welcome to the café!  "))
+
+	// Reload from disk as if the server restarted
+	reloaded, err := persistence.NewFileAnnouncementRepository(path)
+	assert.NoError(t, err)
+
+	schedules, _ := reloaded.GetSchedules()
+	assert.Len(t, schedules, 1)
+	assert.Equal(t, created.ID, schedules[0].ID)
+	assert.Equal(t, entity.AnnouncementTargetAll, schedules[0].Target)
+
+	motd, _ := reloaded.GetMessageOfTheDay()
+	assert.Equal(t, "Welcome to the café!", motd)
+}
+
+func TestScheduledAnnouncementsUseCase_RunDue(t *testing.T) {
+	uc, roomRepo, chatRepo, _ := newTestScheduledAnnouncementsUseCase(t)
+	roomRepo.Create(entity.NewRoom("room-1", "Lobby", entity.RoomTypePublic, "admin"))
+	roomRepo.Create(entity.NewRoom("room-2", "Team A", entity.RoomTypePublic, "admin"))
+
+	created, _ := uc.Create(ScheduleAnnouncementInput{
+		AdminID:   "admin-1",
+		Content:   "Tournament check-in opens in 10 minutes",
+		Cron:      "* * * * *",
+		Target:    entity.AnnouncementTargetRooms,
+		RoomNames: []string{"Team A"},
+	})
+
+	// Not yet due within the creation minute
+	assert.Empty(t, uc.RunDue(created.CreatedAt))
+
+	due := created.CreatedAt.Truncate(time.Minute).Add(time.Minute)
+	results := uc.RunDue(due)
+	assert.Len(t, results, 1)
+	assert.Len(t, results[0].SystemMessages, 1)
+	assert.Contains(t, results[0].SystemMessages, "room-2")
+
+	messages, _ := chatRepo.GetMessages("room-1", 10)
+	assert.Empty(t, messages)
+
+	// Does not fire twice for the same minute
+	assert.Empty(t, uc.RunDue(due.Add(10*time.Second)))
+}
+
+func TestScheduledAnnouncementsUseCase_RunDue_SkipsMissedRuns(t *testing.T) {
+	uc, _, _, _ := newTestScheduledAnnouncementsUseCase(t)
+
+	created, _ := uc.Create(ScheduleAnnouncementInput{
+		AdminID: "admin-1",
+		Content: "Happy hour starts at 18:00",
+		Cron:    "* * * * *",
+		Target:  entity.AnnouncementTargetLobby,
+	})
+
+	// Server was down for an hour
+	assert.Empty(t, uc.RunDue(created.CreatedAt.Add(time.Hour)))
+
+	schedules, _ := uc.List()
+	assert.False(t, schedules[0].LastRunAt.IsZero())
+}
+
+func TestScheduledAnnouncementsUseCase_RunDue_Disabled(t *testing.T) {
+	uc, _, _, _ := newTestScheduledAnnouncementsUseCase(t)
+
+	created, _ := uc.Create(ScheduleAnnouncementInput{
+		AdminID: "admin-1",
+		Content: "Happy hour starts at 18:00",
+		Cron:    "* * * * *",
+	})
+	_, err := uc.SetEnabled(created.ID, false)
+	assert.NoError(t, err)
+
+	assert.Empty(t, uc.RunDue(created.CreatedAt.Add(time.Minute)))
+}
+
+func TestScheduledAnnouncementsUseCase_RunDue_TimeZone(t *testing.T) {
+	announcementRepo, err := persistence.NewFileAnnouncementRepository(filepath.Join(t.TempDir(), "announcements.json"))
+	assert.NoError(t, err)
+	announcementUC := NewAnnouncementUseCase(persistence.NewInMemoryRoomRepository(), persistence.NewInMemoryChatRepository(), nil)
+	uc := NewScheduledAnnouncementsUseCase(announcementRepo, announcementUC, time.FixedZone("UTC+2", 2*60*60))
+
+	scheduled := entity.NewScheduledAnnouncement("schedule-1", "Happy hour starts now", "0 18 * * *", entity.AnnouncementTargetAll, nil, "admin-1")
+	scheduled.CreatedAt = time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	assert.NoError(t, announcementRepo.SaveSchedule(scheduled))
+
+	// 18:00 in the schedule's time zone is 16:00 UTC
+	assert.Empty(t, uc.RunDue(time.Date(2026, 6, 1, 15, 59, 0, 0, time.UTC)))
+	assert.Len(t, uc.RunDue(time.Date(2026, 6, 1, 16, 0, 15, 0, time.UTC)), 1)
+	assert.Empty(t, uc.RunDue(time.Date(2026, 6, 1, 18, 0, 15, 0, time.UTC)))
+}
+
+func TestScheduledAnnouncementsUseCase_ListReturnsCopies(t *testing.T) {
+	uc, _, _, _ := newTestScheduledAnnouncementsUseCase(t)
+
+	roomNames := []string{"Team A"}
+	created, _ := uc.Create(ScheduleAnnouncementInput{
+		AdminID:   "admin-1",
+		Content:   "Tournament check-in opens in 10 minutes",
+		Cron:      "50 19 * * 5",
+		Target:    entity.AnnouncementTargetRooms,
+		RoomNames: roomNames,
+	})
+	created.Enabled = false
+	roomNames[0] = "Team B"
+
+	schedules, _ := uc.List()
+	schedules[0].LastRunAt = time.Now()
+
+	schedules, _ = uc.List()
+	assert.True(t, schedules[0].Enabled)
+	assert.Equal(t, []string{"Team A"}, schedules[0].RoomNames)
+	assert.True(t, schedules[0].LastRunAt.IsZero())
+}
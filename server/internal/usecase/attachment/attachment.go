@@ -0,0 +1,272 @@
+package attachment
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/google/uuid"
+
+	"voice-chat/internal/domain/entity"
+	"voice-chat/internal/domain/repository"
+)
+
+const (
+	DefaultMaxUploadSize = 8 << 20   // Bytes
+	PendingUploadTTL     = time.Hour // Uploads not attached to a message by then are deleted
+	MaxFileNameLength    = 100
+)
+
+var (
+	ErrEmptyFile           = errors.New("file is empty")
+	ErrFileTooLarge        = errors.New("file is too large")
+	ErrUnsupportedType     = errors.New("file type is not allowed")
+	ErrInvalidImage        = errors.New("image could not be read")
+	ErrAttachmentNotFound  = errors.New("attachment not found or already used")
+	ErrTooManyAttachments  = fmt.Errorf("a message can carry at most %d attachments", entity.MaxAttachmentsPerMessage)
+	ErrDuplicateAttachment = errors.New("attachment listed more than once")
+)
+
+// AllowedContentTypes are the sniffed content types that can be uploaded. The browser's
+// claimed type is never trusted.
+var AllowedContentTypes = map[string]bool{
+	"image/png":       true,
+	"image/jpeg":      true,
+	"image/gif":       true,
+	"application/pdf": true,
+	"text/plain":      true,
+}
+
+// UploadInput represents a file uploaded by a user in a room
+type UploadInput struct {
+	RoomID   string
+	UserID   string
+	FileName string
+	Data     []byte
+}
+
+// pendingUpload is a stored file waiting to be attached to a message
+type pendingUpload struct {
+	attachment *entity.Attachment
+	expiresAt  time.Time
+}
+
+// AttachmentUseCase validates and stores uploads, and hands them to chat messages. Files are
+// uploaded first and then claimed by the chat message that carries them.
+type AttachmentUseCase struct {
+	blobStore repository.BlobStore
+	maxSize   int64
+	pending   map[string]*pendingUpload // attachmentID -> upload
+	mu        sync.Mutex
+}
+
+// NewAttachmentUseCase creates a new AttachmentUseCase; maxSize <= 0 uses DefaultMaxUploadSize
+func NewAttachmentUseCase(blobStore repository.BlobStore, maxSize int64) *AttachmentUseCase {
+	if maxSize <= 0 {
+		maxSize = DefaultMaxUploadSize
+	}
+	return &AttachmentUseCase{
+		blobStore: blobStore,
+		maxSize:   maxSize,
+		pending:   make(map[string]*pendingUpload),
+	}
+}
+
+// MaxSize returns the largest file that can be uploaded, in bytes
+func (uc *AttachmentUseCase) MaxSize() int64 {
+	return uc.maxSize
+}
+
+// Upload validates a file, strips image metadata, generates a thumbnail for images and
+// stores the result until a message claims it
+func (uc *AttachmentUseCase) Upload(input UploadInput) (*entity.Attachment, error) {
+	if len(input.Data) == 0 {
+		return nil, ErrEmptyFile
+	}
+	if int64(len(input.Data)) > uc.maxSize {
+		return nil, ErrFileTooLarge
+	}
+
+	contentType := sniff(input.Data)
+	if !AllowedContentTypes[contentType] {
+		return nil, ErrUnsupportedType
+	}
+
+	att := &entity.Attachment{
+		ID:          uuid.New().String(),
+		RoomID:      input.RoomID,
+		UploaderID:  input.UserID,
+		FileName:    sanitizeFileName(input.FileName),
+		ContentType: contentType,
+		CreatedAt:   time.Now(),
+	}
+
+	data := input.Data
+	var thumbnail []byte
+	if att.IsImage() {
+		processed, err := processImage(data, contentType)
+		if err != nil {
+			return nil, err
+		}
+		data = processed.data
+		thumbnail = processed.thumbnail
+		att.Width = processed.width
+		att.Height = processed.height
+	}
+	att.Size = int64(len(data))
+
+	if err := uc.blobStore.Put(att.BlobKey(), data); err != nil {
+		return nil, err
+	}
+	if thumbnail != nil {
+		if err := uc.blobStore.Put(att.ThumbnailKey(), thumbnail); err != nil {
+			log.Printf("Error storing thumbnail for attachment %s: %v", att.ID, err)
+		} else {
+			att.HasThumbnail = true
+		}
+	}
+
+	uc.mu.Lock()
+	uc.pending[att.ID] = &pendingUpload{attachment: att, expiresAt: att.CreatedAt.Add(PendingUploadTTL)}
+	uc.mu.Unlock()
+
+	return att, nil
+}
+
+// Claim takes the user's pending uploads for a chat message. Either all IDs are claimed or none.
+func (uc *AttachmentUseCase) Claim(roomID, userID string, ids []string) ([]*entity.Attachment, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	if len(ids) > entity.MaxAttachmentsPerMessage {
+		return nil, ErrTooManyAttachments
+	}
+
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	seen := make(map[string]bool, len(ids))
+	attachments := make([]*entity.Attachment, 0, len(ids))
+	for _, id := range ids {
+		if seen[id] {
+			return nil, ErrDuplicateAttachment
+		}
+		seen[id] = true
+
+		p, exists := uc.pending[id]
+		if !exists || p.attachment.RoomID != roomID || p.attachment.UploaderID != userID {
+			return nil, ErrAttachmentNotFound
+		}
+		attachments = append(attachments, p.attachment)
+	}
+
+	for _, id := range ids {
+		delete(uc.pending, id)
+	}
+	return attachments, nil
+}
+
+// Release returns claimed attachments to the pending set, for when the message carrying them
+// could not be sent
+func (uc *AttachmentUseCase) Release(attachments []*entity.Attachment) {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	for _, att := range attachments {
+		uc.pending[att.ID] = &pendingUpload{attachment: att, expiresAt: time.Now().Add(PendingUploadTTL)}
+	}
+}
+
+// Open returns an attachment's file, or its thumbnail, and when it was stored
+func (uc *AttachmentUseCase) Open(roomID, attachmentID string, thumbnail bool) (io.ReadSeekCloser, time.Time, error) {
+	if roomID == "" || attachmentID == "" || strings.ContainsAny(roomID+attachmentID, "/.\\") {
+		return nil, time.Time{}, ErrAttachmentNotFound
+	}
+
+	key := entity.AttachmentBlobKey(roomID, attachmentID)
+	if thumbnail {
+		key = entity.AttachmentThumbnailKey(roomID, attachmentID)
+	}
+	f, modTime, err := uc.blobStore.Open(key)
+	if err != nil {
+		return nil, time.Time{}, ErrAttachmentNotFound
+	}
+	return f, modTime, nil
+}
+
+// DeleteRoom removes every file uploaded to a room, attached or not
+func (uc *AttachmentUseCase) DeleteRoom(roomID string) error {
+	uc.mu.Lock()
+	for id, p := range uc.pending {
+		if p.attachment.RoomID == roomID {
+			delete(uc.pending, id)
+		}
+	}
+	uc.mu.Unlock()
+
+	return uc.blobStore.DeletePrefix(entity.RoomAttachmentPrefix(roomID))
+}
+
+// Cleanup deletes uploads that were never attached to a message and returns how many were removed
+func (uc *AttachmentUseCase) Cleanup(now time.Time) int {
+	uc.mu.Lock()
+	expired := make([]*entity.Attachment, 0)
+	for id, p := range uc.pending {
+		if !now.Before(p.expiresAt) {
+			expired = append(expired, p.attachment)
+			delete(uc.pending, id)
+		}
+	}
+	uc.mu.Unlock()
+
+	for _, att := range expired {
+		if err := uc.blobStore.Delete(att.BlobKey()); err != nil {
+			log.Printf("Error deleting unused attachment %s: %v", att.ID, err)
+		}
+		if att.HasThumbnail {
+			_ = uc.blobStore.Delete(att.ThumbnailKey())
+		}
+	}
+	return len(expired)
+}
+
+// sniff detects a file's content type from its bytes. Text is only accepted as UTF-8.
+func sniff(data []byte) string {
+	contentType := http.DetectContentType(data)
+	if contentType == "text/plain; charset=utf-8" {
+		return "text/plain"
+	}
+	return contentType
+}
+
+// sanitizeFileName keeps the base name of an uploaded file, without control characters,
+// and shortens it to MaxFileNameLength
+func sanitizeFileName(name string) string {
+	name = filepath.Base(strings.ReplaceAll(name, "\\", "/"))
+	name = strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, name)
+	name = strings.TrimSpace(name)
+
+	if name == "" || name == "." || name == "/" {
+		return "file"
+	}
+	if runes := []rune(name); len(runes) > MaxFileNameLength {
+		ext := []rune(filepath.Ext(name))
+		if len(ext) > 10 {
+			ext = nil
+		}
+		name = string(runes[:MaxFileNameLength-len(ext)]) + string(ext)
+	}
+	return name
+}
@@ -0,0 +1,171 @@
+package attachment
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"voice-chat/internal/infrastructure/persistence"
+)
+
+func setupAttachments(t *testing.T, maxSize int64) *AttachmentUseCase {
+	store, err := persistence.NewLocalBlobStore(t.TempDir())
+	assert.NoError(t, err)
+	return NewAttachmentUseCase(store, maxSize)
+}
+
+func testImage(w, h int) image.Image {
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			img.Set(x, y, color.RGBA{R: uint8(x), G: uint8(y), B: 128, A: 255})
+		}
+	}
+	return img
+}
+
+// jpegWithExif encodes a JPEG and inserts an APP1 EXIF segment after the SOI marker
+func jpegWithExif(t *testing.T, w, h int, exif string) []byte {
+	var buf bytes.Buffer
+	assert.NoError(t, jpeg.Encode(&buf, testImage(w, h), nil))
+
+	payload := append([]byte("Exif\x00\x00"), exif...)
+	segment := []byte{0xFF, 0xE1, byte((len(payload) + 2) >> 8), byte(len(payload) + 2)}
+	segment = append(segment, payload...)
+
+	data := buf.Bytes()
+	return append(append(append([]byte{}, data[:2]...), segment...), data[2:]...)
+}
+
+func readBlob(t *testing.T, uc *AttachmentUseCase, roomID, id string, thumbnail bool) []byte {
+	f, _, err := uc.Open(roomID, id, thumbnail)
+	assert.NoError(t, err)
+	defer f.Close()
+	data, _ := io.ReadAll(f)
+	return data
+}
+
+func TestAttachmentUseCase_UploadStripsExifAndThumbnails(t *testing.T) {
+	uc := setupAttachments(t, 0)
+	data := jpegWithExif(t, 800, 600, "GPS 51.5N 0.12W")
+
+	att, err := uc.Upload(UploadInput{RoomID: "room-1", UserID: "user-1", FileName: "../../score.jpg", Data: data})
+	assert.NoError(t, err)
+	assert.Equal(t, "image/jpeg", att.ContentType)
+	assert.Equal(t, "score.jpg", att.FileName)
+	assert.Equal(t, 800, att.Width)
+	assert.Equal(t, 600, att.Height)
+	assert.True(t, att.HasThumbnail)
+
+	stored := readBlob(t, uc, "room-1", att.ID, false)
+	assert.Equal(t, att.Size, int64(len(stored)))
+	assert.True(t, bytes.Contains(data, []byte("GPS 51.5N")))
+	assert.False(t, bytes.Contains(stored, []byte("GPS 51.5N")))
+
+	thumb, _, err := image.DecodeConfig(bytes.NewReader(readBlob(t, uc, "room-1", att.ID, true)))
+	assert.NoError(t, err)
+	assert.Equal(t, ThumbnailMaxSize, thumb.Width)
+	assert.Equal(t, 240, thumb.Height)
+}
+
+func TestAttachmentUseCase_UploadValidation(t *testing.T) {
+	uc := setupAttachments(t, 1024)
+
+	var small bytes.Buffer
+	assert.NoError(t, png.Encode(&small, testImage(16, 16)))
+	att, err := uc.Upload(UploadInput{RoomID: "room-1", UserID: "user-1", FileName: "icon.png", Data: small.Bytes()})
+	assert.NoError(t, err)
+	assert.False(t, att.HasThumbnail)
+
+	att, err = uc.Upload(UploadInput{RoomID: "room-1", UserID: "user-1", FileName: "notes.txt", Data: []byte("gg wp")})
+	assert.NoError(t, err)
+	assert.Equal(t, "text/plain", att.ContentType)
+
+	_, err = uc.Upload(UploadInput{RoomID: "room-1", UserID: "user-1", FileName: "page.txt", Data: []byte("<html><script>alert(1)</script>")})
+	assert.ErrorIs(t, err, ErrUnsupportedType)
+
+	_, err = uc.Upload(UploadInput{RoomID: "room-1", UserID: "user-1", FileName: "big.txt", Data: bytes.Repeat([]byte("a"), 2048)})
+	assert.ErrorIs(t, err, ErrFileTooLarge)
+
+	_, err = uc.Upload(UploadInput{RoomID: "room-1", UserID: "user-1", FileName: "empty.txt"})
+	assert.ErrorIs(t, err, ErrEmptyFile)
+
+	// Claims to be a PNG but isn't one
+	_, err = uc.Upload(UploadInput{RoomID: "room-1", UserID: "user-1", FileName: "x.png", Data: []byte("\x89PNG\r\n\x1a\ngarbage")})
+	assert.ErrorIs(t, err, ErrInvalidImage)
+}
+
+func TestAttachmentUseCase_Claim(t *testing.T) {
+	uc := setupAttachments(t, 0)
+	a, _ := uc.Upload(UploadInput{RoomID: "room-1", UserID: "user-1", FileName: "a.txt", Data: []byte("a")})
+	b, _ := uc.Upload(UploadInput{RoomID: "room-1", UserID: "user-1", FileName: "b.txt", Data: []byte("b")})
+
+	// Someone else's upload, another room, or a bad ID claims nothing
+	_, err := uc.Claim("room-1", "user-2", []string{a.ID})
+	assert.ErrorIs(t, err, ErrAttachmentNotFound)
+	_, err = uc.Claim("room-2", "user-1", []string{a.ID})
+	assert.ErrorIs(t, err, ErrAttachmentNotFound)
+	_, err = uc.Claim("room-1", "user-1", []string{a.ID, "nope"})
+	assert.ErrorIs(t, err, ErrAttachmentNotFound)
+	_, err = uc.Claim("room-1", "user-1", []string{a.ID, a.ID})
+	assert.ErrorIs(t, err, ErrDuplicateAttachment)
+	_, err = uc.Claim("room-1", "user-1", []string{"1", "2", "3", "4", "5"})
+	assert.ErrorIs(t, err, ErrTooManyAttachments)
+
+	claimed, err := uc.Claim("room-1", "user-1", []string{b.ID, a.ID})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{b.ID, a.ID}, []string{claimed[0].ID, claimed[1].ID})
+
+	// An upload goes on one message only, unless the send failed
+	_, err = uc.Claim("room-1", "user-1", []string{a.ID})
+	assert.ErrorIs(t, err, ErrAttachmentNotFound)
+	uc.Release(claimed)
+	_, err = uc.Claim("room-1", "user-1", []string{a.ID})
+	assert.NoError(t, err)
+}
+
+func TestAttachmentUseCase_CleanupAndDeleteRoom(t *testing.T) {
+	uc := setupAttachments(t, 0)
+	pending, _ := uc.Upload(UploadInput{RoomID: "room-1", UserID: "user-1", FileName: "a.txt", Data: []byte("a")})
+	sent, _ := uc.Upload(UploadInput{RoomID: "room-1", UserID: "user-1", FileName: "b.txt", Data: []byte("b")})
+	other, _ := uc.Upload(UploadInput{RoomID: "room-2", UserID: "user-1", FileName: "c.txt", Data: []byte("c")})
+	uc.Claim("room-1", "user-1", []string{sent.ID})
+	uc.Claim("room-2", "user-1", []string{other.ID})
+
+	assert.Zero(t, uc.Cleanup(time.Now()))
+	assert.Equal(t, 1, uc.Cleanup(time.Now().Add(PendingUploadTTL)))
+	_, _, err := uc.Open("room-1", pending.ID, false)
+	assert.ErrorIs(t, err, ErrAttachmentNotFound)
+	_, _, err = uc.Open("room-1", sent.ID, false)
+	assert.NoError(t, err)
+
+	assert.NoError(t, uc.DeleteRoom("room-1"))
+	_, _, err = uc.Open("room-1", sent.ID, false)
+	assert.ErrorIs(t, err, ErrAttachmentNotFound)
+	assert.Equal(t, []byte("c"), readBlob(t, uc, "room-2", other.ID, false))
+}
+
+func TestAttachmentUseCase_OpenRejectsPaths(t *testing.T) {
+	uc := setupAttachments(t, 0)
+	for _, ids := range [][2]string{{"..", "x"}, {"room-1", "../room-2"}, {"room-1", ""}, {"a/b", "c"}} {
+		_, _, err := uc.Open(ids[0], ids[1], false)
+		assert.ErrorIs(t, err, ErrAttachmentNotFound)
+	}
+}
+
+func TestSanitizeFileName(t *testing.T) {
+	assert.Equal(t, "file", sanitizeFileName(""))
+	assert.Equal(t, "report.pdf", sanitizeFileName(`C:\Users\me\report.pdf`))
+	assert.Equal(t, "ab.txt", sanitizeFileName("a\x00b\n.txt"))
+
+	long := sanitizeFileName(string(bytes.Repeat([]byte("x"), 300)) + ".png")
+	assert.Len(t, long, MaxFileNameLength)
+	assert.Equal(t, ".png", long[len(long)-4:])
+}
@@ -0,0 +1,114 @@
+package attachment
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/draw"
+	_ "image/gif" // Registers the GIF decoder
+	"image/jpeg"
+	"image/png"
+)
+
+const (
+	MaxImagePixels   = 25_000_000 // Larger images are rejected before decoding
+	ThumbnailMaxSize = 320        // Longest side of a thumbnail, in pixels
+	jpegQuality      = 90
+	thumbnailQuality = 80
+)
+
+// processedImage is an image ready to be stored
+type processedImage struct {
+	data      []byte
+	thumbnail []byte // nil when the image is already thumbnail-sized
+	width     int
+	height    int
+}
+
+// processImage decodes an uploaded image, re-encodes it to drop EXIF and other metadata
+// (GPS position, camera serials) and builds a thumbnail
+func processImage(data []byte, contentType string) (*processedImage, error) {
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrInvalidImage
+	}
+	if cfg.Width <= 0 || cfg.Height <= 0 || cfg.Width*cfg.Height > MaxImagePixels {
+		return nil, ErrFileTooLarge
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrInvalidImage
+	}
+
+	out := &processedImage{width: cfg.Width, height: cfg.Height}
+
+	var buf bytes.Buffer
+	switch contentType {
+	case "image/jpeg":
+		// EXIF orientation is lost along with the rest of the metadata
+		err = jpeg.Encode(&buf, img, &jpeg.Options{Quality: jpegQuality})
+	case "image/png":
+		err = png.Encode(&buf, img)
+	case "image/gif":
+		// GIFs carry no EXIF, and re-encoding would drop every frame after the first
+		buf.Write(data)
+	}
+	if err != nil {
+		return nil, err
+	}
+	out.data = buf.Bytes()
+
+	if cfg.Width > ThumbnailMaxSize || cfg.Height > ThumbnailMaxSize {
+		thumb := thumbnail(img, ThumbnailMaxSize)
+		var tb bytes.Buffer
+		if contentType == "image/jpeg" {
+			err = jpeg.Encode(&tb, thumb, &jpeg.Options{Quality: thumbnailQuality})
+		} else {
+			err = png.Encode(&tb, thumb)
+		}
+		if err != nil {
+			return nil, err
+		}
+		out.thumbnail = tb.Bytes()
+	}
+
+	return out, nil
+}
+
+// thumbnail scales img down so its longest side is maxSize, averaging the source pixels
+// that fall into each destination pixel
+func thumbnail(img image.Image, maxSize int) image.Image {
+	sw, sh := img.Bounds().Dx(), img.Bounds().Dy()
+	src := image.NewRGBA(image.Rect(0, 0, sw, sh))
+	draw.Draw(src, src.Bounds(), img, img.Bounds().Min, draw.Src)
+
+	dw, dh := maxSize, sh*maxSize/sw
+	if sh > sw {
+		dw, dh = sw*maxSize/sh, maxSize
+	}
+	dw, dh = max(dw, 1), max(dh, 1)
+
+	dst := image.NewRGBA(image.Rect(0, 0, dw, dh))
+	for y := 0; y < dh; y++ {
+		y0, y1 := y*sh/dh, max((y+1)*sh/dh, y*sh/dh+1)
+		for x := 0; x < dw; x++ {
+			x0, x1 := x*sw/dw, max((x+1)*sw/dw, x*sw/dw+1)
+
+			var r, g, b, a, n uint32
+			for sy := y0; sy < y1; sy++ {
+				off := src.PixOffset(x0, sy)
+				for sx := x0; sx < x1; sx++ {
+					r += uint32(src.Pix[off])
+					g += uint32(src.Pix[off+1])
+					b += uint32(src.Pix[off+2])
+					a += uint32(src.Pix[off+3])
+					off += 4
+					n++
+				}
+			}
+			dst.SetRGBA(x, y, color.RGBA{R: uint8(r / n), G: uint8(g / n), B: uint8(b / n), A: uint8(a / n)})
+		}
+	}
+	return dst
+}
@@ -0,0 +1,153 @@
+package chat
+
+import (
+	"errors"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+
+	"voice-chat/internal/domain/entity"
+	"voice-chat/internal/domain/repository"
+)
+
+// DeleteMessageInput represents the input for deleting a chat message
+type DeleteMessageInput struct {
+	RoomID    string
+	MessageID string
+	UserID    string
+	UserName  string
+	IP        string
+	IsAdmin   bool
+}
+
+// DeleteMessageUseCase handles deleting chat messages by their author or a moderator
+type DeleteMessageUseCase struct {
+	chatRepo     repository.ChatRepository
+	roomRepo     repository.RoomRepository
+	searchRepo   repository.ChatSearchRepository
+	activityRepo repository.ActivityRepository
+	blobStore    repository.BlobStore
+}
+
+// NewDeleteMessageUseCase creates a new DeleteMessageUseCase
+func NewDeleteMessageUseCase(
+	chatRepo repository.ChatRepository,
+	roomRepo repository.RoomRepository,
+	searchRepo repository.ChatSearchRepository,
+	activityRepo repository.ActivityRepository,
+	blobStore repository.BlobStore,
+) *DeleteMessageUseCase {
+	return &DeleteMessageUseCase{
+		chatRepo:     chatRepo,
+		roomRepo:     roomRepo,
+		searchRepo:   searchRepo,
+		activityRepo: activityRepo,
+		blobStore:    blobStore,
+	}
+}
+
+// Execute replaces a message with a tombstone
+func (uc *DeleteMessageUseCase) Execute(input DeleteMessageInput) (*entity.ChatMessage, error) {
+	// The repository checks who may delete the message as it writes the tombstone
+	isModerator := IsModerator(uc.roomRepo, input.RoomID, input.UserID, input.IsAdmin)
+	now := time.Now()
+	original, err := uc.chatRepo.DeleteMessage(input.RoomID, input.MessageID, input.UserID, isModerator, now)
+	if err != nil {
+		return nil, err
+	}
+	isAuthor := original.IsAuthor(input.UserID)
+
+	msg := original.Clone()
+	msg.Delete(input.UserID, now)
+	uc.clearQuotes(msg)
+	uc.deleteAttachments(original.Attachments)
+
+	// Deleted messages must not turn up in search
+	if uc.searchRepo != nil {
+		if err := uc.searchRepo.Remove(msg.RoomID, msg.ID); err != nil {
+			log.Printf("Error removing deleted message %s from search: %v", msg.ID, err)
+		}
+	}
+
+	// Keep an audit trail of moderator removals
+	if !isAuthor && uc.activityRepo != nil {
+		roomName := ""
+		if room, err := uc.roomRepo.GetByID(input.RoomID); err == nil {
+			roomName = room.Name
+		}
+
+		activity := entity.NewActivityLog(
+			uuid.New().String(),
+			entity.ActivityTypeChatDelete,
+			input.UserID,
+			input.UserName,
+			input.RoomID,
+			roomName,
+			input.IP,
+		)
+		activity.AddDetail("message_id", msg.ID)
+		activity.AddDetail("author_id", msg.SenderID)
+		activity.AddDetail("author_name", msg.SenderName)
+		activity.AddDetail("content", original.Content)
+		if len(original.Attachments) > 0 {
+			activity.AddDetail("attachments", len(original.Attachments))
+		}
+		_ = uc.activityRepo.Log(activity)
+	}
+
+	return msg, nil
+}
+
+// clearQuotes removes the deleted message's content from replies quoting it
+func (uc *DeleteMessageUseCase) clearQuotes(deleted *entity.ChatMessage) {
+	threadID := deleted.ID
+	if deleted.IsReply() {
+		threadID = deleted.ReplyTo
+	}
+
+	replies, err := uc.chatRepo.GetReplies(deleted.RoomID, threadID, 0)
+	if err != nil {
+		log.Printf("Error loading replies to clear quotes of message %s: %v", deleted.ID, err)
+		return
+	}
+
+	for _, reply := range replies {
+		if reply.Quote == nil || reply.Quote.MessageID != deleted.ID || reply.IsDeleted() {
+			continue
+		}
+		reply.ClearQuote()
+		if err := uc.chatRepo.UpdateMessage(reply); err != nil && !errors.Is(err, entity.ErrMessageDeleted) {
+			log.Printf("Error clearing quote in message %s: %v", reply.ID, err)
+		}
+	}
+}
+
+// deleteAttachments removes a deleted message's files so they can't be fetched by URL
+func (uc *DeleteMessageUseCase) deleteAttachments(attachments []*entity.Attachment) {
+	if uc.blobStore == nil {
+		return
+	}
+
+	for _, att := range attachments {
+		if err := uc.blobStore.Delete(att.BlobKey()); err != nil {
+			log.Printf("Error deleting attachment %s: %v", att.ID, err)
+		}
+		if att.HasThumbnail {
+			_ = uc.blobStore.Delete(att.ThumbnailKey())
+		}
+	}
+}
+
+// IsModerator checks if a user may moderate a room's chat (admins and the room's creator)
+func IsModerator(roomRepo repository.RoomRepository, roomID, userID string, isAdmin bool) bool {
+	if isAdmin {
+		return true
+	}
+
+	room, err := roomRepo.GetByID(roomID)
+	if err != nil || room == nil {
+		return false
+	}
+	return room.CreatedBy == userID
+}
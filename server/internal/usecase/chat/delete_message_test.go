@@ -0,0 +1,69 @@
+package chat
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"voice-chat/internal/domain/entity"
+	"voice-chat/internal/infrastructure/persistence"
+)
+
+func setupDeleteTest() (*persistence.InMemoryChatRepository, *persistence.InMemoryActivityRepository, *DeleteMessageUseCase) {
+	chatRepo := persistence.NewInMemoryChatRepository()
+	roomRepo := persistence.NewInMemoryRoomRepository()
+	activityRepo := persistence.NewInMemoryActivityRepository()
+	roomRepo.Create(entity.NewRoom("room-1", "Team A", entity.RoomTypePublic, "owner-1"))
+	msg := entity.NewChatMessage("msg-1", "room-1", "user-1", "Alice", "rude words")
+	msg.AddReaction("👍", "user-2")
+	chatRepo.AddMessage(msg)
+	return chatRepo, activityRepo, NewDeleteMessageUseCase(chatRepo, roomRepo, nil, activityRepo, nil)
+}
+
+func TestDeleteMessageUseCase_Author(t *testing.T) {
+	chatRepo, activityRepo, uc := setupDeleteTest()
+
+	msg, err := uc.Execute(DeleteMessageInput{RoomID: "room-1", MessageID: "msg-1", UserID: "user-1"})
+
+	assert.NoError(t, err)
+	assert.True(t, msg.IsDeleted())
+	assert.Empty(t, msg.Content)
+	assert.Empty(t, msg.Reactions)
+	assert.Equal(t, "user-1", msg.DeletedBy)
+
+	stored, _ := chatRepo.GetMessage("room-1", "msg-1")
+	assert.True(t, stored.IsDeleted())
+
+	// Authors retracting their own message are not audited
+	logs, _ := activityRepo.GetByType(entity.ActivityTypeChatDelete)
+	assert.Empty(t, logs)
+}
+
+func TestDeleteMessageUseCase_Moderators(t *testing.T) {
+	_, activityRepo, uc := setupDeleteTest()
+
+	_, err := uc.Execute(DeleteMessageInput{RoomID: "room-1", MessageID: "msg-1", UserID: "owner-1"})
+	assert.NoError(t, err)
+	logs, _ := activityRepo.GetByType(entity.ActivityTypeChatDelete)
+	assert.Len(t, logs, 1)
+	assert.Equal(t, "rude words", logs[0].Details["content"])
+
+	_, activityRepo, uc = setupDeleteTest()
+	_, err = uc.Execute(DeleteMessageInput{RoomID: "room-1", MessageID: "msg-1", UserID: "admin-1", IsAdmin: true})
+	assert.NoError(t, err)
+	logs, _ = activityRepo.GetByType(entity.ActivityTypeChatDelete)
+	assert.Len(t, logs, 1)
+}
+
+func TestDeleteMessageUseCase_NotAllowed(t *testing.T) {
+	_, _, uc := setupDeleteTest()
+
+	_, err := uc.Execute(DeleteMessageInput{RoomID: "room-1", MessageID: "msg-1", UserID: "user-2"})
+	assert.ErrorIs(t, err, ErrNotAllowedToDelete)
+
+	_, err = uc.Execute(DeleteMessageInput{RoomID: "room-1", MessageID: "msg-1", UserID: "user-1"})
+	assert.NoError(t, err)
+
+	_, err = uc.Execute(DeleteMessageInput{RoomID: "room-1", MessageID: "msg-1", UserID: "user-1"})
+	assert.ErrorIs(t, err, ErrMessageDeleted)
+}
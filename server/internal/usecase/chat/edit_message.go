@@ -0,0 +1,109 @@
+package chat
+
+import (
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	"voice-chat/internal/domain/entity"
+	"voice-chat/internal/domain/repository"
+	"voice-chat/internal/usecase/moderation"
+)
+
+var (
+	ErrEmptyMessage = errors.New("message content is empty")
+
+	// Checked by the repository while it changes the message
+	ErrMessageDeleted     = entity.ErrMessageDeleted
+	ErrNotMessageAuthor   = entity.ErrNotMessageAuthor
+	ErrEditWindowExpired  = entity.ErrEditWindowExpired
+	ErrSystemMessage      = entity.ErrSystemMessage
+	ErrNotAllowedToDelete = entity.ErrNotAllowedToDelete
+	ErrPollNotEditable    = entity.ErrPollNotEditable
+)
+
+// EditMessageInput represents the input for editing a chat message
+type EditMessageInput struct {
+	RoomID    string
+	MessageID string
+	UserID    string
+	UserName  string
+	IP        string
+	Content   string
+}
+
+// EditMessageUseCase handles editing chat messages by their author
+type EditMessageUseCase struct {
+	chatRepo     repository.ChatRepository
+	searchRepo   repository.ChatSearchRepository
+	moderationUC *moderation.ModerationUseCase
+	editWindow   time.Duration // 0 disables editing
+}
+
+// NewEditMessageUseCase creates a new EditMessageUseCase
+func NewEditMessageUseCase(
+	chatRepo repository.ChatRepository,
+	searchRepo repository.ChatSearchRepository,
+	moderationUC *moderation.ModerationUseCase,
+	editWindow time.Duration,
+) *EditMessageUseCase {
+	return &EditMessageUseCase{
+		chatRepo:     chatRepo,
+		searchRepo:   searchRepo,
+		moderationUC: moderationUC,
+		editWindow:   editWindow,
+	}
+}
+
+// Execute edits a message, keeping the previous content as a revision
+func (uc *EditMessageUseCase) Execute(input EditMessageInput) (*entity.ChatMessage, error) {
+	if strings.TrimSpace(input.Content) == "" {
+		return nil, ErrEmptyMessage
+	}
+
+	msg, err := uc.chatRepo.GetMessage(input.RoomID, input.MessageID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Checked up front so a refused edit isn't reviewed; the repository checks again as it edits
+	now := time.Now()
+	if err := msg.CheckEdit(input.UserID, uc.editWindow, now); err != nil {
+		return nil, err
+	}
+
+	// Edits go through the same filters as new messages, so they can't sneak content past them
+	if uc.moderationUC != nil {
+		review, err := uc.moderationUC.Review(moderation.ReviewInput{
+			RoomID:    input.RoomID,
+			MessageID: input.MessageID,
+			UserID:    input.UserID,
+			UserName:  input.UserName,
+			IP:        input.IP,
+			Content:   input.Content,
+		})
+		if err != nil {
+			return nil, err
+		}
+		input.Content = review.Content
+	}
+
+	if msg.Content == input.Content {
+		return msg, nil
+	}
+
+	msg, err = uc.chatRepo.EditMessage(input.RoomID, input.MessageID, input.UserID, input.Content, uc.editWindow, now)
+	if err != nil {
+		return nil, err
+	}
+
+	// Search should find what the message says now, not what it used to say
+	if uc.searchRepo != nil {
+		if err := uc.searchRepo.Index(msg, ""); err != nil {
+			log.Printf("Error re-indexing edited message %s: %v", msg.ID, err)
+		}
+	}
+
+	return msg, nil
+}
@@ -0,0 +1,73 @@
+package chat
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"voice-chat/internal/domain/entity"
+	"voice-chat/internal/infrastructure/persistence"
+)
+
+func TestEditMessageUseCase_Success(t *testing.T) {
+	chatRepo := persistence.NewInMemoryChatRepository()
+	chatRepo.AddMessage(entity.NewChatMessage("msg-1", "room-1", "user-1", "Alice", "helo"))
+	uc := NewEditMessageUseCase(chatRepo, nil, nil, 15*time.Minute)
+
+	msg, err := uc.Execute(EditMessageInput{
+		RoomID:    "room-1",
+		MessageID: "msg-1",
+		UserID:    "user-1",
+		Content:   "hello",
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "hello", msg.Content)
+	assert.True(t, msg.IsEdited())
+	assert.Len(t, msg.Revisions, 1)
+	assert.Equal(t, "helo", msg.Revisions[0].Content)
+
+	stored, _ := chatRepo.GetMessage("room-1", "msg-1")
+	assert.Equal(t, "hello", stored.Content)
+}
+
+func TestEditMessageUseCase_NotAuthor(t *testing.T) {
+	chatRepo := persistence.NewInMemoryChatRepository()
+	chatRepo.AddMessage(entity.NewChatMessage("msg-1", "room-1", "user-1", "Alice", "hi"))
+	uc := NewEditMessageUseCase(chatRepo, nil, nil, 15*time.Minute)
+
+	_, err := uc.Execute(EditMessageInput{RoomID: "room-1", MessageID: "msg-1", UserID: "user-2", Content: "pwned"})
+
+	assert.ErrorIs(t, err, ErrNotMessageAuthor)
+}
+
+func TestEditMessageUseCase_WindowExpired(t *testing.T) {
+	chatRepo := persistence.NewInMemoryChatRepository()
+	msg := entity.NewChatMessage("msg-1", "room-1", "user-1", "Alice", "hi")
+	msg.Timestamp = time.Now().Add(-time.Hour)
+	chatRepo.AddMessage(msg)
+	uc := NewEditMessageUseCase(chatRepo, nil, nil, 15*time.Minute)
+
+	_, err := uc.Execute(EditMessageInput{RoomID: "room-1", MessageID: "msg-1", UserID: "user-1", Content: "hey"})
+
+	assert.ErrorIs(t, err, ErrEditWindowExpired)
+}
+
+func TestEditMessageUseCase_Rejected(t *testing.T) {
+	chatRepo := persistence.NewInMemoryChatRepository()
+	chatRepo.AddMessage(entity.NewSystemMessage("msg-1", "room-1", "user-1", "Alice", "joined the room"))
+	deleted := entity.NewChatMessage("msg-2", "room-1", "user-1", "Alice", "hi")
+	deleted.Delete("user-1", time.Now())
+	chatRepo.AddMessage(deleted)
+	uc := NewEditMessageUseCase(chatRepo, nil, nil, 15*time.Minute)
+
+	_, err := uc.Execute(EditMessageInput{RoomID: "room-1", MessageID: "msg-1", UserID: "user-1", Content: "left"})
+	assert.ErrorIs(t, err, ErrSystemMessage)
+
+	_, err = uc.Execute(EditMessageInput{RoomID: "room-1", MessageID: "msg-2", UserID: "user-1", Content: "hey"})
+	assert.ErrorIs(t, err, ErrMessageDeleted)
+
+	_, err = uc.Execute(EditMessageInput{RoomID: "room-1", MessageID: "msg-2", UserID: "user-1", Content: "  "})
+	assert.ErrorIs(t, err, ErrEmptyMessage)
+}
@@ -0,0 +1,470 @@
+package chat
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html/template"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"voice-chat/internal/domain/entity"
+	"voice-chat/internal/domain/repository"
+)
+
+// ExportFormat selects how an exported transcript is written
+type ExportFormat string
+
+const (
+	ExportFormatJSONL ExportFormat = "jsonl"
+	ExportFormatText  ExportFormat = "txt"
+	ExportFormatHTML  ExportFormat = "html"
+)
+
+var (
+	ErrUnknownExportFormat = errors.New("export format must be jsonl, txt or html")
+	ErrInvalidExportRange  = errors.New("export range ends before it starts")
+	ErrNotAllowedToExport  = errors.New("only admins and, if enabled, the room owner can export a room's chat")
+)
+
+// ContentType is the MIME type of the format
+func (f ExportFormat) ContentType() string {
+	switch f {
+	case ExportFormatJSONL:
+		return "application/x-ndjson; charset=utf-8"
+	case ExportFormatHTML:
+		return "text/html; charset=utf-8"
+	default:
+		return "text/plain; charset=utf-8"
+	}
+}
+
+// ParseExportFormat interprets a requested format; empty selects JSON Lines
+func ParseExportFormat(format string) (ExportFormat, error) {
+	switch strings.ToLower(format) {
+	case "", "json", "jsonl":
+		return ExportFormatJSONL, nil
+	case "txt", "text":
+		return ExportFormatText, nil
+	case "html":
+		return ExportFormatHTML, nil
+	default:
+		return "", ErrUnknownExportFormat
+	}
+}
+
+// ExportInput represents a request to download a room's chat
+type ExportInput struct {
+	RoomID  string
+	UserID  string
+	IsAdmin bool
+	Format  ExportFormat
+	From    time.Time // Zero exports from the oldest kept message
+	To      time.Time // Zero exports up to the newest message
+}
+
+// ChatExport is a room's transcript ready to be written out
+type ChatExport struct {
+	RoomID     string
+	RoomName   string
+	Format     ExportFormat
+	From       time.Time
+	To         time.Time
+	ExportedAt time.Time
+	Messages   []*entity.ChatMessage // Oldest first
+}
+
+// FileName suggests a download name like "lobby-20261018-1504.html"
+func (e *ChatExport) FileName() string {
+	name := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			return r
+		case r >= 'A' && r <= 'Z':
+			return r + ('a' - 'A')
+		default:
+			return '-'
+		}
+	}, e.RoomName)
+	name = strings.Trim(name, "-")
+	if name == "" {
+		name = "chat"
+	}
+	return fmt.Sprintf("%s-%s.%s", name, e.ExportedAt.UTC().Format("20060102-1504"), e.Format)
+}
+
+// ExportUseCase collects a room's chat history, including system messages and reactions,
+// for admins and optionally for the room's owner
+type ExportUseCase struct {
+	chatRepo      repository.ChatRepository
+	roomRepo      repository.RoomRepository
+	ownersAllowed bool
+}
+
+// NewExportUseCase creates a new ExportUseCase
+func NewExportUseCase(chatRepo repository.ChatRepository, roomRepo repository.RoomRepository, ownersAllowed bool) *ExportUseCase {
+	return &ExportUseCase{
+		chatRepo:      chatRepo,
+		roomRepo:      roomRepo,
+		ownersAllowed: ownersAllowed,
+	}
+}
+
+// Export gathers the room's messages sent within the input's time range
+func (uc *ExportUseCase) Export(input ExportInput) (*ChatExport, error) {
+	if !input.From.IsZero() && !input.To.IsZero() && input.To.Before(input.From) {
+		return nil, ErrInvalidExportRange
+	}
+
+	room, err := uc.roomRepo.GetByID(input.RoomID)
+	if err != nil || room == nil {
+		return nil, ErrRoomNotFound
+	}
+	if !input.IsAdmin && !(uc.ownersAllowed && room.CreatedBy == input.UserID) {
+		return nil, ErrNotAllowedToExport
+	}
+
+	messages, err := collectMessages(uc.chatRepo, input.RoomID, input.From, input.To)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ChatExport{
+		RoomID:     room.ID,
+		RoomName:   room.Name,
+		Format:     input.Format,
+		From:       input.From,
+		To:         input.To,
+		ExportedAt: time.Now(),
+		Messages:   messages,
+	}, nil
+}
+
+// collectMessages pages backwards through a room's history until it passes the start of the range
+func collectMessages(chatRepo repository.ChatRepository, roomID string, from, to time.Time) ([]*entity.ChatMessage, error) {
+	var pages [][]*entity.ChatMessage
+	cursor := repository.ChatHistoryCursor{}
+	if !to.IsZero() {
+		// The cursor excludes its timestamp, so step past it to make the end inclusive
+		cursor.Before = to.Add(time.Nanosecond)
+	}
+
+	for {
+		page, hasMore, err := chatRepo.GetMessagesBefore(roomID, cursor, repository.MaxChatHistoryPageSize)
+		if err != nil {
+			return nil, err
+		}
+		if len(page) == 0 {
+			break
+		}
+
+		start := 0
+		if !from.IsZero() {
+			start = sort.Search(len(page), func(i int) bool {
+				return !page[i].Timestamp.Before(from)
+			})
+		}
+		pages = append(pages, page[start:])
+		if start > 0 || !hasMore {
+			break
+		}
+		cursor = repository.ChatHistoryCursor{BeforeID: page[0].ID}
+	}
+
+	var messages []*entity.ChatMessage
+	for i := len(pages) - 1; i >= 0; i-- {
+		messages = append(messages, pages[i]...)
+	}
+	return messages, nil
+}
+
+// Write writes the transcript in its format
+func (e *ChatExport) Write(w io.Writer) error {
+	switch e.Format {
+	case ExportFormatText:
+		return e.writeText(w)
+	case ExportFormatHTML:
+		return e.writeHTML(w)
+	default:
+		return e.writeJSONL(w)
+	}
+}
+
+// exportedMessage is one line of a JSON Lines export
+type exportedMessage struct {
+	ID          string              `json:"id"`
+	Type        string              `json:"type"`
+	Timestamp   time.Time           `json:"timestamp"`
+	SenderID    string              `json:"sender_id"`
+	SenderName  string              `json:"sender_name"`
+	Content     string              `json:"content"`
+	ReplyTo     string              `json:"reply_to,omitempty"`
+	EditedAt    *time.Time          `json:"edited_at,omitempty"`
+	DeletedAt   *time.Time          `json:"deleted_at,omitempty"`
+	DeletedBy   string              `json:"deleted_by,omitempty"`
+	Reactions   map[string][]string `json:"reactions,omitempty"` // emoji -> []userID
+	Attachments []exportedFile      `json:"attachments,omitempty"`
+	Poll        *exportedPoll       `json:"poll,omitempty"`
+}
+
+type exportedFile struct {
+	FileName    string `json:"file_name"`
+	ContentType string `json:"content_type"`
+	Size        int64  `json:"size"`
+}
+
+type exportedPoll struct {
+	Question    string               `json:"question"`
+	Options     []exportedPollOption `json:"options"`
+	MultiChoice bool                 `json:"multi_choice,omitempty"`
+	Anonymous   bool                 `json:"anonymous,omitempty"`
+	ClosedAt    *time.Time           `json:"closed_at,omitempty"`
+}
+
+type exportedPollOption struct {
+	Text   string   `json:"text"`
+	Votes  int      `json:"votes"`
+	Voters []string `json:"voters,omitempty"` // Left out of anonymous polls
+}
+
+func toExportedMessage(msg *entity.ChatMessage) exportedMessage {
+	out := exportedMessage{
+		ID:         msg.ID,
+		Type:       string(msg.Type),
+		Timestamp:  msg.Timestamp.UTC(),
+		SenderID:   msg.SenderID,
+		SenderName: msg.SenderName,
+		Content:    msg.Content,
+		ReplyTo:    msg.ReplyTo,
+		EditedAt:   msg.EditedAt,
+		DeletedAt:  msg.DeletedAt,
+		DeletedBy:  msg.DeletedBy,
+	}
+	if len(msg.Reactions) > 0 {
+		out.Reactions = make(map[string][]string, len(msg.Reactions))
+		for emoji, users := range msg.Reactions {
+			out.Reactions[emoji] = append([]string(nil), users...)
+		}
+	}
+	for _, att := range msg.Attachments {
+		out.Attachments = append(out.Attachments, exportedFile{FileName: att.FileName, ContentType: att.ContentType, Size: att.Size})
+	}
+	if msg.Poll != nil {
+		out.Poll = toExportedPoll(msg.Poll)
+	}
+	return out
+}
+
+func toExportedPoll(poll *entity.Poll) *exportedPoll {
+	tally := poll.Tally()
+	voters := poll.Voters()
+	out := &exportedPoll{
+		Question:    poll.Question,
+		MultiChoice: poll.MultiChoice,
+		Anonymous:   poll.Anonymous,
+		ClosedAt:    poll.ClosedAt,
+	}
+	for i, text := range poll.Options {
+		option := exportedPollOption{Text: text, Votes: tally[i]}
+		if !poll.Anonymous {
+			option.Voters = voters[i]
+		}
+		out.Options = append(out.Options, option)
+	}
+	return out
+}
+
+func (e *ChatExport) writeJSONL(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	for _, msg := range e.Messages {
+		if err := enc.Encode(toExportedMessage(msg)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// exportTimeFormat is how message times are shown in text and HTML transcripts
+const exportTimeFormat = "2006-01-02 15:04:05 MST"
+
+// rangeLabel describes the exported time range for transcript headers
+func (e *ChatExport) rangeLabel() string {
+	from, to := "start of history", "now"
+	if !e.From.IsZero() {
+		from = e.From.UTC().Format(exportTimeFormat)
+	}
+	if !e.To.IsZero() {
+		to = e.To.UTC().Format(exportTimeFormat)
+	}
+	return from + " to " + to
+}
+
+// reactionSummary lists reactions as "👍 3, 🎉 1" in a stable order
+func reactionSummary(reactions map[string][]string) string {
+	emojis := make([]string, 0, len(reactions))
+	for emoji, users := range reactions {
+		if len(users) > 0 {
+			emojis = append(emojis, emoji)
+		}
+	}
+	sort.Strings(emojis)
+
+	parts := make([]string, len(emojis))
+	for i, emoji := range emojis {
+		parts[i] = fmt.Sprintf("%s %d", emoji, len(reactions[emoji]))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// transcriptLine is a message prepared for the text and HTML transcripts
+type transcriptLine struct {
+	Time        string
+	Sender      string
+	System      bool
+	Deleted     bool
+	Edited      bool
+	ReplyTo     string // Sender of the quoted parent
+	Content     string
+	Attachments []string
+	Poll        []string // One line per option
+	Reactions   string
+}
+
+func toTranscriptLine(msg *entity.ChatMessage) transcriptLine {
+	line := transcriptLine{
+		Time:      msg.Timestamp.UTC().Format(exportTimeFormat),
+		Sender:    msg.SenderName,
+		System:    msg.Type == entity.ChatMessageTypeSystem,
+		Deleted:   msg.IsDeleted(),
+		Edited:    msg.EditedAt != nil,
+		Content:   msg.Content,
+		Reactions: reactionSummary(msg.Reactions),
+	}
+	if msg.Quote != nil {
+		line.ReplyTo = msg.Quote.SenderName
+	}
+	for _, att := range msg.Attachments {
+		line.Attachments = append(line.Attachments, fmt.Sprintf("%s (%s, %d bytes)", att.FileName, att.ContentType, att.Size))
+	}
+	if msg.Poll != nil {
+		status := "open"
+		if msg.Poll.ClosedAt != nil {
+			status = "closed"
+		}
+		line.Content = fmt.Sprintf("Poll (%s): %s", status, msg.Poll.Question)
+		tally := msg.Poll.Tally()
+		for i, option := range msg.Poll.Options {
+			line.Poll = append(line.Poll, fmt.Sprintf("%d. %s: %d", i+1, option, tally[i]))
+		}
+	}
+	return line
+}
+
+func (e *ChatExport) writeText(w io.Writer) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Chat export: %s (%s)\n", e.RoomName, e.RoomID)
+	fmt.Fprintf(&b, "Range: %s\n", e.rangeLabel())
+	fmt.Fprintf(&b, "Exported: %s, %d messages\n\n", e.ExportedAt.UTC().Format(exportTimeFormat), len(e.Messages))
+
+	for _, msg := range e.Messages {
+		line := toTranscriptLine(msg)
+		switch {
+		case line.System:
+			fmt.Fprintf(&b, "[%s] * %s\n", line.Time, line.Content)
+		case line.Deleted:
+			fmt.Fprintf(&b, "[%s] %s: [message deleted]\n", line.Time, line.Sender)
+		default:
+			sender := line.Sender
+			if line.ReplyTo != "" {
+				sender += " (reply to " + line.ReplyTo + ")"
+			}
+			content := strings.ReplaceAll(line.Content, "\n", "\n    ")
+			if line.Edited {
+				content += " (edited)"
+			}
+			fmt.Fprintf(&b, "[%s] %s: %s\n", line.Time, sender, content)
+		}
+		for _, att := range line.Attachments {
+			fmt.Fprintf(&b, "    attachment: %s\n", att)
+		}
+		for _, option := range line.Poll {
+			fmt.Fprintf(&b, "    %s\n", option)
+		}
+		if line.Reactions != "" {
+			fmt.Fprintf(&b, "    reactions: %s\n", line.Reactions)
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// exportHTML is a standalone page with inline styles so the file opens anywhere
+var exportHTML = template.Must(template.New("export").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{.Room}} chat export</title>
+<style>
+body { font-family: system-ui, sans-serif; max-width: 860px; margin: 2em auto; padding: 0 1em; color: #1f2328; }
+header { border-bottom: 1px solid #d0d7de; margin-bottom: 1em; }
+header p { color: #59636e; margin: 0.2em 0 1em; }
+.msg { padding: 0.35em 0; border-bottom: 1px solid #f0f2f4; }
+.time { color: #59636e; font-size: 0.85em; margin-right: 0.5em; }
+.sender { font-weight: 600; }
+.reply, .edited, .deleted { color: #59636e; font-size: 0.85em; }
+.system { color: #59636e; font-style: italic; }
+.content { white-space: pre-wrap; overflow-wrap: anywhere; }
+.extra { margin: 0.2em 0 0 1.5em; font-size: 0.9em; color: #424a53; }
+ul.extra { padding-left: 1em; }
+</style>
+</head>
+<body>
+<header>
+<h1>{{.Room}}</h1>
+<p>{{.Range}} &middot; exported {{.ExportedAt}} &middot; {{len .Lines}} messages</p>
+</header>
+{{range .Lines}}<div class="msg{{if .System}} system{{end}}">
+<span class="time">{{.Time}}</span>
+{{- if .System}}
+<span class="content">{{.Content}}</span>
+{{- else}}
+<span class="sender">{{.Sender}}</span>{{if .ReplyTo}} <span class="reply">replying to {{.ReplyTo}}</span>{{end}}:
+{{- if .Deleted}} <span class="deleted">message deleted</span>
+{{- else}} <span class="content">{{.Content}}</span>{{if .Edited}} <span class="edited">(edited)</span>{{end}}
+{{- end}}
+{{- end}}
+{{- if .Attachments}}
+<ul class="extra">{{range .Attachments}}<li>Attachment: {{.}}</li>{{end}}</ul>
+{{- end}}
+{{- if .Poll}}
+<ul class="extra">{{range .Poll}}<li>{{.}}</li>{{end}}</ul>
+{{- end}}
+{{- if .Reactions}}
+<div class="extra">{{.Reactions}}</div>
+{{- end}}
+</div>
+{{end}}</body>
+</html>
+`))
+
+func (e *ChatExport) writeHTML(w io.Writer) error {
+	lines := make([]transcriptLine, len(e.Messages))
+	for i, msg := range e.Messages {
+		lines[i] = toTranscriptLine(msg)
+	}
+	return exportHTML.Execute(w, struct {
+		Room       string
+		Range      string
+		ExportedAt string
+		Lines      []transcriptLine
+	}{
+		Room:       e.RoomName,
+		Range:      e.rangeLabel(),
+		ExportedAt: e.ExportedAt.UTC().Format(exportTimeFormat),
+		Lines:      lines,
+	})
+}
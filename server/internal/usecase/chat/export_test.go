@@ -0,0 +1,133 @@
+package chat
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"voice-chat/internal/domain/entity"
+	"voice-chat/internal/infrastructure/persistence"
+)
+
+var exportStart = time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+func setupExport(ownersAllowed bool, count int) (*ExportUseCase, *persistence.InMemoryChatRepository) {
+	chatRepo := persistence.NewInMemoryChatRepository()
+	roomRepo := persistence.NewInMemoryRoomRepository()
+	roomRepo.Create(entity.NewRoom("room-1", "Finals <Night>", entity.RoomTypePublic, "owner"))
+	chatRepo.SetRoomLimits("room-1", count, 0) // Keep the whole history, even past the default cap
+	for i := 0; i < count; i++ {
+		msg := entity.NewChatMessage(fmt.Sprintf("msg-%d", i), "room-1", "user-1", "Alice", fmt.Sprintf("message %d", i))
+		msg.Timestamp = exportStart.Add(time.Duration(i) * time.Minute)
+		chatRepo.AddMessage(msg)
+	}
+	return NewExportUseCase(chatRepo, roomRepo, ownersAllowed), chatRepo
+}
+
+func TestExportUseCase_Permissions(t *testing.T) {
+	uc, _ := setupExport(false, 1)
+	_, err := uc.Export(ExportInput{RoomID: "room-1", UserID: "owner"})
+	assert.ErrorIs(t, err, ErrNotAllowedToExport)
+	_, err = uc.Export(ExportInput{RoomID: "room-1", UserID: "admin", IsAdmin: true})
+	assert.NoError(t, err)
+	_, err = uc.Export(ExportInput{RoomID: "missing", UserID: "admin", IsAdmin: true})
+	assert.ErrorIs(t, err, ErrRoomNotFound)
+
+	uc, _ = setupExport(true, 1)
+	_, err = uc.Export(ExportInput{RoomID: "room-1", UserID: "owner"})
+	assert.NoError(t, err)
+	_, err = uc.Export(ExportInput{RoomID: "room-1", UserID: "user-1"})
+	assert.ErrorIs(t, err, ErrNotAllowedToExport)
+}
+
+func TestExportUseCase_Range(t *testing.T) {
+	uc, _ := setupExport(false, 250)
+
+	all, err := uc.Export(ExportInput{RoomID: "room-1", IsAdmin: true})
+	assert.NoError(t, err)
+	assert.Len(t, all.Messages, 250)
+	assert.Equal(t, "msg-0", all.Messages[0].ID)
+	assert.Equal(t, "msg-249", all.Messages[249].ID)
+
+	// Both ends are inclusive and the range spans several history pages
+	ranged, err := uc.Export(ExportInput{
+		RoomID:  "room-1",
+		IsAdmin: true,
+		From:    exportStart.Add(10 * time.Minute),
+		To:      exportStart.Add(220 * time.Minute),
+	})
+	assert.NoError(t, err)
+	assert.Len(t, ranged.Messages, 211)
+	assert.Equal(t, "msg-10", ranged.Messages[0].ID)
+	assert.Equal(t, "msg-220", ranged.Messages[210].ID)
+
+	_, err = uc.Export(ExportInput{RoomID: "room-1", IsAdmin: true, From: exportStart.Add(time.Hour), To: exportStart})
+	assert.ErrorIs(t, err, ErrInvalidExportRange)
+}
+
+func TestExportUseCase_Formats(t *testing.T) {
+	uc, chatRepo := setupExport(false, 0)
+
+	system := entity.NewSystemMessage("sys-1", "room-1", "user-2", "Bob", "Bob joined the room")
+	system.Timestamp = exportStart
+	chatRepo.AddMessage(system)
+
+	msg := entity.NewChatMessage("msg-1", "room-1", "user-1", "Alice", "<b>gg</b>")
+	msg.Timestamp = exportStart.Add(time.Minute)
+	msg.AddReaction("👍", "user-2")
+	msg.AddReaction("👍", "user-3")
+	chatRepo.AddMessage(msg)
+
+	poll, _ := entity.NewPoll("Rematch?", []string{"Yes", "No"}, false, true, 0, exportStart)
+	poll.Vote("user-2", []int{0}, exportStart)
+	pollMsg := entity.NewPollMessage("poll-1", "room-1", "user-1", "Alice", poll)
+	pollMsg.Timestamp = exportStart.Add(2 * time.Minute)
+	chatRepo.AddMessage(pollMsg)
+
+	export := func(format ExportFormat) string {
+		out, err := uc.Export(ExportInput{RoomID: "room-1", IsAdmin: true, Format: format})
+		assert.NoError(t, err)
+		var buf bytes.Buffer
+		assert.NoError(t, out.Write(&buf))
+		return buf.String()
+	}
+
+	lines := strings.Split(strings.TrimSpace(export(ExportFormatJSONL)), "\n")
+	assert.Len(t, lines, 3)
+	var record exportedMessage
+	assert.NoError(t, json.Unmarshal([]byte(lines[1]), &record))
+	assert.Equal(t, "<b>gg</b>", record.Content)
+	assert.Equal(t, []string{"user-2", "user-3"}, record.Reactions["👍"])
+	assert.NoError(t, json.Unmarshal([]byte(lines[2]), &record))
+	assert.Equal(t, 1, record.Poll.Options[0].Votes)
+	assert.Nil(t, record.Poll.Options[0].Voters, "anonymous poll voters are not exported")
+
+	text := export(ExportFormatText)
+	assert.Contains(t, text, "Chat export: Finals <Night> (room-1)")
+	assert.Contains(t, text, "[2026-03-01 12:00:00 UTC] * Bob joined the room")
+	assert.Contains(t, text, "[2026-03-01 12:01:00 UTC] Alice: <b>gg</b>")
+	assert.Contains(t, text, "reactions: 👍 2")
+	assert.Contains(t, text, "Poll (open): Rematch?")
+	assert.Contains(t, text, "1. Yes: 1")
+
+	html := export(ExportFormatHTML)
+	assert.Contains(t, html, "<title>Finals &lt;Night&gt; chat export</title>")
+	assert.Contains(t, html, "&lt;b&gt;gg&lt;/b&gt;")
+	assert.NotContains(t, html, "<b>gg</b>")
+	assert.Contains(t, html, "Bob joined the room")
+}
+
+func TestParseExportFormat(t *testing.T) {
+	for input, want := range map[string]ExportFormat{"": ExportFormatJSONL, "JSON": ExportFormatJSONL, "text": ExportFormatText, "html": ExportFormatHTML} {
+		got, err := ParseExportFormat(input)
+		assert.NoError(t, err)
+		assert.Equal(t, want, got)
+	}
+	_, err := ParseExportFormat("pdf")
+	assert.ErrorIs(t, err, ErrUnknownExportFormat)
+}
@@ -0,0 +1,182 @@
+package chat
+
+import (
+	"sync"
+	"time"
+
+	"voice-chat/internal/domain/entity"
+	"voice-chat/pkg/ratelimit"
+)
+
+// FloodAction is the consequence of going over a rate limit
+type FloodAction string
+
+const (
+	FloodActionNone FloodAction = ""     // Within limits
+	FloodActionDrop FloodAction = "drop" // Message is ignored
+	FloodActionWarn FloodAction = "warn" // Message is ignored and the sender is told to slow down
+	FloodActionMute FloodAction = "mute" // Sender can't chat until the mute ends
+)
+
+// ChatMutedTypes are the message types a temporary chat mute blocks
+var ChatMutedTypes = map[string]bool{
+	"chat_message":       true,
+	"chat_edit":          true,
+	"chat_reaction_add":  true,
+	"dm_send":            true,
+	"create_poll":        true,
+	"poll_vote":          true,
+	"lobby_chat_message": true,
+}
+
+// FloodCheckInput identifies an incoming message
+type FloodCheckInput struct {
+	ConnectionID string
+	SessionID    string // Strikes and mutes follow the session so reconnecting doesn't clear them
+	IP           string
+	MessageType  string
+}
+
+// FloodDecision is the outcome of a flood check
+type FloodDecision struct {
+	Allowed    bool
+	Action     FloodAction
+	RetryAfter time.Duration // Until the next message would be accepted
+	NewlyMuted bool          // The mute started with this message
+}
+
+// offender tracks strikes against a session
+type offender struct {
+	strikes    int
+	lastStrike time.Time
+	mutedUntil time.Time
+}
+
+// floodLimiters are the buckets of one message type
+type floodLimiters struct {
+	connection *ratelimit.Limiter // keyed by connection
+	ip         *ratelimit.Limiter // keyed by IP
+}
+
+func newFloodLimiters(limit entity.FloodLimit) *floodLimiters {
+	return &floodLimiters{
+		connection: ratelimit.NewLimiter(ratelimit.Limit{Burst: limit.Connection.Burst, Rate: limit.Connection.Rate}),
+		ip:         ratelimit.NewLimiter(ratelimit.Limit{Burst: limit.IP.Burst, Rate: limit.IP.Rate}),
+	}
+}
+
+// FloodControlUseCase rate limits incoming messages per connection and per IP
+type FloodControlUseCase struct {
+	escalation entity.FloodEscalation
+	limiters   map[string]*floodLimiters // message type -> limiters
+	fallback   *floodLimiters            // Shared by every other message type
+	offenders  map[string]*offender      // sessionID -> strikes
+	mu         sync.Mutex
+}
+
+// NewFloodControlUseCase creates a new FloodControlUseCase. Message types without limits of
+// their own share one bucket with the default limits, whatever the client sends.
+func NewFloodControlUseCase(config *entity.FloodControlConfig) *FloodControlUseCase {
+	uc := &FloodControlUseCase{
+		escalation: config.Escalation,
+		limiters:   make(map[string]*floodLimiters, len(config.Types)),
+		fallback:   newFloodLimiters(config.Default),
+		offenders:  make(map[string]*offender),
+	}
+	for msgType, limit := range config.Types {
+		uc.limiters[msgType] = newFloodLimiters(limit)
+	}
+	return uc
+}
+
+// limitersFor returns the buckets of a message type
+func (uc *FloodControlUseCase) limitersFor(msgType string) *floodLimiters {
+	if l, exists := uc.limiters[msgType]; exists {
+		return l
+	}
+	return uc.fallback
+}
+
+// Check decides whether a message may be handled
+func (uc *FloodControlUseCase) Check(input FloodCheckInput, now time.Time) FloodDecision {
+	if ChatMutedTypes[input.MessageType] {
+		if remaining := uc.Muted(input.SessionID, now); remaining > 0 {
+			return FloodDecision{Action: FloodActionMute, RetryAfter: remaining}
+		}
+	}
+
+	limiters := uc.limitersFor(input.MessageType)
+	if ok, wait := limiters.connection.Allow(input.ConnectionID, now); !ok {
+		return uc.strike(input.SessionID, wait, now)
+	}
+
+	// Everyone behind the address shares the IP bucket, so running it dry is nobody's strike
+	if input.IP != "" {
+		if ok, wait := limiters.ip.Allow(input.IP, now); !ok {
+			return FloodDecision{Action: FloodActionDrop, RetryAfter: wait}
+		}
+	}
+
+	return FloodDecision{Allowed: true}
+}
+
+// strike records a rejected message and escalates
+func (uc *FloodControlUseCase) strike(sessionID string, wait time.Duration, now time.Time) FloodDecision {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	o, exists := uc.offenders[sessionID]
+	if !exists {
+		o = &offender{}
+		uc.offenders[sessionID] = o
+	}
+	escalation := uc.escalation
+	if now.Sub(o.lastStrike) > time.Duration(escalation.StrikeWindowSeconds)*time.Second {
+		o.strikes = 0
+	}
+	o.strikes++
+	o.lastStrike = now
+
+	switch {
+	case escalation.MuteAfter > 0 && o.strikes >= escalation.MuteAfter:
+		duration := time.Duration(escalation.MuteSeconds) * time.Second
+		o.strikes = 0
+		o.mutedUntil = now.Add(duration)
+		return FloodDecision{Action: FloodActionMute, RetryAfter: duration, NewlyMuted: true}
+	case o.strikes > escalation.WarnAfter:
+		return FloodDecision{Action: FloodActionWarn, RetryAfter: wait}
+	default:
+		return FloodDecision{Action: FloodActionDrop, RetryAfter: wait}
+	}
+}
+
+// Muted returns how long the session's chat mute has left (0 if not muted)
+func (uc *FloodControlUseCase) Muted(sessionID string, now time.Time) time.Duration {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	if o, exists := uc.offenders[sessionID]; exists && now.Before(o.mutedUntil) {
+		return o.mutedUntil.Sub(now)
+	}
+	return 0
+}
+
+// Cleanup drops full buckets and forgotten offenders, returning how many entries were removed
+func (uc *FloodControlUseCase) Cleanup(now time.Time) int {
+	removed := uc.fallback.connection.Cleanup(now) + uc.fallback.ip.Cleanup(now)
+	for _, l := range uc.limiters {
+		removed += l.connection.Cleanup(now)
+		removed += l.ip.Cleanup(now)
+	}
+
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+	window := time.Duration(uc.escalation.StrikeWindowSeconds) * time.Second
+	for sessionID, o := range uc.offenders {
+		if now.Sub(o.lastStrike) > window && !now.Before(o.mutedUntil) {
+			delete(uc.offenders, sessionID)
+			removed++
+		}
+	}
+	return removed
+}
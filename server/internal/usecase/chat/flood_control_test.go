@@ -0,0 +1,101 @@
+package chat
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"voice-chat/internal/domain/entity"
+)
+
+func newTestFloodControl() *FloodControlUseCase {
+	return NewFloodControlUseCase(&entity.FloodControlConfig{
+		Default: entity.FloodLimit{Connection: entity.FloodBucket{Burst: 2, Rate: 1}},
+		Types: map[string]entity.FloodLimit{
+			"chat_message": {Connection: entity.FloodBucket{Burst: 2, Rate: 1}, IP: entity.FloodBucket{Burst: 3, Rate: 1}},
+		},
+		Escalation: entity.FloodEscalation{WarnAfter: 1, MuteAfter: 3, StrikeWindowSeconds: 60, MuteSeconds: 60},
+	})
+}
+
+func TestFloodControlUseCase_Escalation(t *testing.T) {
+	uc := newTestFloodControl()
+	now := time.Now()
+	input := FloodCheckInput{ConnectionID: "c1", SessionID: "s1", IP: "1.2.3.4", MessageType: "chat_message"}
+
+	assert.True(t, uc.Check(input, now).Allowed)
+	assert.True(t, uc.Check(input, now).Allowed)
+
+	decision := uc.Check(input, now)
+	assert.False(t, decision.Allowed)
+	assert.Equal(t, FloodActionDrop, decision.Action)
+	assert.Equal(t, time.Second, decision.RetryAfter)
+
+	assert.Equal(t, FloodActionWarn, uc.Check(input, now).Action)
+
+	decision = uc.Check(input, now)
+	assert.Equal(t, FloodActionMute, decision.Action)
+	assert.True(t, decision.NewlyMuted)
+
+	// The mute outlasts the bucket refilling and follows the session to a new connection
+	later := now.Add(10 * time.Second)
+	input.ConnectionID = "c2"
+	decision = uc.Check(input, later)
+	assert.False(t, decision.Allowed)
+	assert.False(t, decision.NewlyMuted)
+	assert.Equal(t, 50*time.Second, decision.RetryAfter)
+
+	// Non-chat messages are not affected by the mute
+	assert.True(t, uc.Check(FloodCheckInput{ConnectionID: "c2", SessionID: "s1", MessageType: "get_room"}, later).Allowed)
+
+	assert.True(t, uc.Check(input, now.Add(time.Minute)).Allowed)
+}
+
+func TestFloodControlUseCase_SharedIP(t *testing.T) {
+	uc := newTestFloodControl()
+	now := time.Now()
+
+	for _, conn := range []string{"c1", "c2", "c3"} {
+		assert.True(t, uc.Check(FloodCheckInput{ConnectionID: conn, SessionID: conn, IP: "cafe", MessageType: "chat_message"}, now).Allowed)
+	}
+
+	// The shared address is out of tokens, but nobody gets a strike for it
+	for i := 0; i < 2; i++ {
+		decision := uc.Check(FloodCheckInput{ConnectionID: "c4", SessionID: "s4", IP: "cafe", MessageType: "chat_message"}, now)
+		assert.Equal(t, FloodActionDrop, decision.Action)
+	}
+	assert.Zero(t, uc.Muted("s4", now))
+
+	assert.True(t, uc.Check(FloodCheckInput{ConnectionID: "c5", SessionID: "s5", IP: "home", MessageType: "chat_message"}, now).Allowed)
+}
+
+func TestFloodControlUseCase_Cleanup(t *testing.T) {
+	uc := newTestFloodControl()
+	now := time.Now()
+	input := FloodCheckInput{ConnectionID: "c1", SessionID: "s1", IP: "1.2.3.4", MessageType: "chat_message"}
+
+	for i := 0; i < 5; i++ {
+		uc.Check(input, now)
+	}
+	assert.NotZero(t, uc.Muted("s1", now))
+
+	// The buckets have refilled, but the offender is kept while muted
+	assert.Equal(t, 2, uc.Cleanup(now.Add(30*time.Second)))
+	assert.NotZero(t, uc.Muted("s1", now.Add(30*time.Second)))
+
+	assert.Equal(t, 1, uc.Cleanup(now.Add(2*time.Minute)))
+}
+
+func TestFloodControlUseCase_DefaultLimits(t *testing.T) {
+	uc := newTestFloodControl()
+	now := time.Now()
+
+	// Types without limits of their own share the default bucket, so made-up types can't dodge it
+	assert.True(t, uc.Check(FloodCheckInput{ConnectionID: "c1", SessionID: "s1", MessageType: "get_room"}, now).Allowed)
+	assert.True(t, uc.Check(FloodCheckInput{ConnectionID: "c1", SessionID: "s1", MessageType: "made_up"}, now).Allowed)
+	assert.False(t, uc.Check(FloodCheckInput{ConnectionID: "c1", SessionID: "s1", MessageType: "typing"}, now).Allowed)
+
+	// The chat bucket is separate
+	assert.True(t, uc.Check(FloodCheckInput{ConnectionID: "c1", SessionID: "s1", MessageType: "chat_message"}, now).Allowed)
+}
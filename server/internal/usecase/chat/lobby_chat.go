@@ -0,0 +1,223 @@
+package chat
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"voice-chat/internal/domain/entity"
+	"voice-chat/internal/domain/repository"
+	"voice-chat/internal/usecase/moderation"
+)
+
+// MaxLobbyChatNameLength is the longest name, in characters, shown in the lobby chat
+const MaxLobbyChatNameLength = 32
+
+// reservedLobbyChatNames can't be taken by regular users, so nobody passes as staff or the server
+var reservedLobbyChatNames = []string{"admin", "administrator", "moderator", "system"}
+
+var (
+	ErrLobbyChatDisabled         = errors.New("lobby chat is disabled")
+	ErrLobbyChatNameRequired     = errors.New("choose a name to chat in the lobby")
+	ErrLobbyChatNameReserved     = errors.New("that name is reserved")
+	ErrLobbyChatNameTaken        = errors.New("that name is already in use in the lobby chat")
+	ErrNotAllowedToModerateLobby = errors.New("only admins can moderate the lobby chat")
+	ErrInvalidLobbyMute          = errors.New("mute must be between 1 minute and 1 hour")
+)
+
+// LobbyChatInput represents a message sent to the lobby chat
+type LobbyChatInput struct {
+	UserID    string
+	SessionID string // Cooldowns follow the session, so reconnecting doesn't reset them
+	UserName  string
+	Content   string
+	IsAdmin   bool
+	IP        string
+}
+
+// LobbyMuteInput represents an admin muting someone in the lobby chat
+type LobbyMuteInput struct {
+	SessionID string // Session being muted, so reconnecting doesn't lift the mute
+	IsAdmin   bool
+	Duration  time.Duration // 0 lifts the mute
+}
+
+// LobbyChatUseCase runs the café-wide lobby chat that users in the lobby and in rooms can opt
+// into. It keeps its own history in the chat store, its own cooldown between messages, and
+// runs messages through the moderation policy configured for the lobby chat.
+type LobbyChatUseCase struct {
+	chatRepo     repository.ChatRepository
+	moderationUC *moderation.ModerationUseCase
+	muteUC       *RoomMuteUseCase
+	enabled      bool
+	cooldown     time.Duration        // Minimum time between a user's messages (admins are exempt)
+	lastSent     map[string]time.Time // sessionID -> last message
+	mu           sync.Mutex
+}
+
+// NewLobbyChatUseCase creates a new LobbyChatUseCase
+func NewLobbyChatUseCase(
+	chatRepo repository.ChatRepository,
+	moderationUC *moderation.ModerationUseCase,
+	muteUC *RoomMuteUseCase,
+	enabled bool,
+	cooldown time.Duration,
+) *LobbyChatUseCase {
+	return &LobbyChatUseCase{
+		chatRepo:     chatRepo,
+		moderationUC: moderationUC,
+		muteUC:       muteUC,
+		enabled:      enabled,
+		cooldown:     cooldown,
+		lastSent:     make(map[string]time.Time),
+	}
+}
+
+// Enabled reports whether the lobby chat is available
+func (uc *LobbyChatUseCase) Enabled() bool {
+	return uc.enabled
+}
+
+// DisplayName checks the name a user chats under in the lobby, shortening long ones. Only
+// admins may use reserved names; inUse reports whether someone else already chats under a name.
+func (uc *LobbyChatUseCase) DisplayName(name string, isAdmin bool, inUse func(name string) bool) (string, error) {
+	if !uc.enabled {
+		return "", ErrLobbyChatDisabled
+	}
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", ErrLobbyChatNameRequired
+	}
+	if runes := []rune(name); len(runes) > MaxLobbyChatNameLength {
+		name = strings.TrimSpace(string(runes[:MaxLobbyChatNameLength]))
+	}
+	if !isAdmin {
+		for _, reserved := range reservedLobbyChatNames {
+			if strings.EqualFold(name, reserved) {
+				return "", ErrLobbyChatNameReserved
+			}
+		}
+	}
+	if inUse != nil && inUse(name) {
+		return "", ErrLobbyChatNameTaken
+	}
+	return name, nil
+}
+
+// History returns the newest lobby chat messages (chronological order)
+func (uc *LobbyChatUseCase) History(limit int) ([]*entity.ChatMessage, error) {
+	if !uc.enabled {
+		return nil, ErrLobbyChatDisabled
+	}
+	return uc.chatRepo.GetMessages(entity.LobbyChatRoomID, limit)
+}
+
+// Cooldown returns the minimum time between a user's lobby messages
+func (uc *LobbyChatUseCase) Cooldown() time.Duration {
+	return uc.cooldown
+}
+
+// Muted returns how long an admin muted the session in the lobby chat for (0 if not muted)
+func (uc *LobbyChatUseCase) Muted(sessionID string, now time.Time) time.Duration {
+	return uc.muteUC.Remaining(entity.LobbyChatRoomID, sessionID, now)
+}
+
+// Remaining returns how long the session must wait before sending another lobby message
+func (uc *LobbyChatUseCase) Remaining(sessionID string, isAdmin bool, now time.Time) time.Duration {
+	if uc.cooldown <= 0 || isAdmin {
+		return 0
+	}
+
+	uc.mu.Lock()
+	last, ok := uc.lastSent[sessionID]
+	uc.mu.Unlock()
+	if !ok {
+		return 0
+	}
+
+	if wait := uc.cooldown - now.Sub(last); wait > 0 {
+		return wait
+	}
+	return 0
+}
+
+// Send moderates and stores a lobby chat message; callers check Muted and Remaining first
+func (uc *LobbyChatUseCase) Send(input LobbyChatInput, now time.Time) (*entity.ChatMessage, *moderation.Result, error) {
+	if !uc.enabled {
+		return nil, nil, ErrLobbyChatDisabled
+	}
+	if input.UserName == "" {
+		return nil, nil, ErrLobbyChatNameRequired
+	}
+	if strings.TrimSpace(input.Content) == "" {
+		return nil, nil, ErrEmptyMessage
+	}
+
+	messageID := uuid.New().String()
+
+	// Moderation may rewrite the content, so it runs before the message is built
+	var review *moderation.Result
+	if uc.moderationUC != nil {
+		var err error
+		review, err = uc.moderationUC.Review(moderation.ReviewInput{
+			RoomID:    entity.LobbyChatRoomID,
+			RoomName:  entity.LobbyChatName,
+			MessageID: messageID,
+			UserID:    input.UserID,
+			UserName:  input.UserName,
+			IP:        input.IP,
+			Content:   input.Content,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		input.Content = review.Content
+	}
+
+	msg := entity.NewChatMessage(messageID, entity.LobbyChatRoomID, input.UserID, input.UserName, input.Content)
+	if err := uc.chatRepo.AddMessage(msg); err != nil {
+		return nil, nil, err
+	}
+
+	if uc.cooldown > 0 && !input.IsAdmin {
+		uc.mu.Lock()
+		uc.lastSent[input.SessionID] = now
+		uc.mu.Unlock()
+	}
+
+	return msg, review, nil
+}
+
+// Mute stops a session chatting in the lobby for a while, or lifts its mute; admins only
+func (uc *LobbyChatUseCase) Mute(input LobbyMuteInput, now time.Time) error {
+	if !input.IsAdmin {
+		return ErrNotAllowedToModerateLobby
+	}
+	if input.Duration == 0 {
+		uc.muteUC.Unmute(entity.LobbyChatRoomID, input.SessionID, now)
+		return nil
+	}
+	if input.Duration < time.Minute || input.Duration > MaxRoomMute {
+		return ErrInvalidLobbyMute
+	}
+	uc.muteUC.Mute(entity.LobbyChatRoomID, input.SessionID, now.Add(input.Duration))
+	return nil
+}
+
+// Cleanup drops cooldowns that can no longer apply and returns how many were removed
+func (uc *LobbyChatUseCase) Cleanup(now time.Time) int {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	removed := 0
+	for sessionID, last := range uc.lastSent {
+		if now.Sub(last) >= uc.cooldown {
+			delete(uc.lastSent, sessionID)
+			removed++
+		}
+	}
+	return removed
+}
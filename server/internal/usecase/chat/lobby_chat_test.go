@@ -0,0 +1,135 @@
+package chat
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"voice-chat/internal/domain/entity"
+	"voice-chat/internal/infrastructure/persistence"
+	"voice-chat/internal/usecase/moderation"
+)
+
+func setupLobbyChat(moderationUC *moderation.ModerationUseCase, cooldown time.Duration) (*LobbyChatUseCase, *persistence.InMemoryChatRepository) {
+	chatRepo := persistence.NewInMemoryChatRepository()
+	return NewLobbyChatUseCase(chatRepo, moderationUC, NewRoomMuteUseCase(), true, cooldown), chatRepo
+}
+
+func TestLobbyChatUseCase_SendAndHistory(t *testing.T) {
+	uc, chatRepo := setupLobbyChat(nil, 0)
+	now := time.Now()
+
+	msg, review, err := uc.Send(LobbyChatInput{UserID: "user-1", UserName: "Alice", Content: "hi all"}, now)
+	assert.NoError(t, err)
+	assert.Nil(t, review)
+	assert.Equal(t, entity.LobbyChatRoomID, msg.RoomID)
+
+	_, _, err = uc.Send(LobbyChatInput{UserID: "user-1", UserName: "Alice", Content: "   "}, now)
+	assert.ErrorIs(t, err, ErrEmptyMessage)
+	_, _, err = uc.Send(LobbyChatInput{UserID: "user-2", Content: "hello"}, now)
+	assert.ErrorIs(t, err, ErrLobbyChatNameRequired)
+
+	history, err := uc.History(10)
+	assert.NoError(t, err)
+	assert.Len(t, history, 1)
+	assert.Equal(t, "hi all", history[0].Content)
+
+	// Lobby messages don't leak into rooms
+	stored, _ := chatRepo.GetMessages("room-1", 10)
+	assert.Empty(t, stored)
+}
+
+func TestLobbyChatUseCase_DisplayName(t *testing.T) {
+	uc, _ := setupLobbyChat(nil, 0)
+
+	name, err := uc.DisplayName("  Alice ", false, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "Alice", name)
+
+	name, err = uc.DisplayName(strings.Repeat("é", MaxLobbyChatNameLength+5), false, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, MaxLobbyChatNameLength, len([]rune(name)))
+
+	_, err = uc.DisplayName(" ", false, nil)
+	assert.ErrorIs(t, err, ErrLobbyChatNameRequired)
+
+	// Reserved names are for admins only
+	_, err = uc.DisplayName(" System", false, nil)
+	assert.ErrorIs(t, err, ErrLobbyChatNameReserved)
+	_, err = uc.DisplayName("ADMIN", false, nil)
+	assert.ErrorIs(t, err, ErrLobbyChatNameReserved)
+	name, err = uc.DisplayName("Admin", true, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "Admin", name)
+
+	// Names are checked for use after trimming
+	inUse := func(name string) bool { return strings.EqualFold(name, "alice") }
+	_, err = uc.DisplayName(" Alice ", false, inUse)
+	assert.ErrorIs(t, err, ErrLobbyChatNameTaken)
+	_, err = uc.DisplayName("Bob", false, inUse)
+	assert.NoError(t, err)
+}
+
+func TestLobbyChatUseCase_Cooldown(t *testing.T) {
+	uc, _ := setupLobbyChat(nil, 3*time.Second)
+	now := time.Now()
+
+	_, _, err := uc.Send(LobbyChatInput{UserID: "user-1", SessionID: "session-1", UserName: "Alice", Content: "one"}, now)
+	assert.NoError(t, err)
+	assert.Equal(t, 2*time.Second, uc.Remaining("session-1", false, now.Add(time.Second)))
+	assert.Zero(t, uc.Remaining("session-1", false, now.Add(3*time.Second)))
+	assert.Zero(t, uc.Remaining("session-2", false, now))
+
+	// Admins are exempt
+	_, _, err = uc.Send(LobbyChatInput{UserID: "admin", SessionID: "session-admin", UserName: "Admin", Content: "one", IsAdmin: true}, now)
+	assert.NoError(t, err)
+	assert.Zero(t, uc.Remaining("session-admin", true, now))
+
+	assert.Equal(t, 0, uc.Cleanup(now.Add(time.Second)))
+	assert.Equal(t, 1, uc.Cleanup(now.Add(3*time.Second)))
+}
+
+func TestLobbyChatUseCase_Mute(t *testing.T) {
+	uc, _ := setupLobbyChat(nil, 0)
+	now := time.Now()
+
+	err := uc.Mute(LobbyMuteInput{SessionID: "session-1", Duration: 5 * time.Minute}, now)
+	assert.ErrorIs(t, err, ErrNotAllowedToModerateLobby)
+	err = uc.Mute(LobbyMuteInput{SessionID: "session-1", IsAdmin: true, Duration: time.Second}, now)
+	assert.ErrorIs(t, err, ErrInvalidLobbyMute)
+
+	assert.NoError(t, uc.Mute(LobbyMuteInput{SessionID: "session-1", IsAdmin: true, Duration: 5 * time.Minute}, now))
+	assert.Equal(t, 5*time.Minute, uc.Muted("session-1", now))
+	assert.Zero(t, uc.Muted("session-2", now))
+
+	assert.NoError(t, uc.Mute(LobbyMuteInput{SessionID: "session-1", IsAdmin: true}, now))
+	assert.Zero(t, uc.Muted("session-1", now))
+}
+
+func TestLobbyChatUseCase_Moderation(t *testing.T) {
+	moderationUC := moderation.NewModerationUseCase(&entity.ModerationConfig{
+		Rooms: map[string]entity.ModerationPolicy{
+			"lobby chat": {MaxLength: &entity.MaxLengthRule{Action: entity.ModerationActionBlock, Limit: 10}},
+		},
+	}, persistence.NewInMemoryRoomRepository(), nil)
+	uc, _ := setupLobbyChat(moderationUC, 0)
+
+	_, _, err := uc.Send(LobbyChatInput{UserID: "user-1", UserName: "Alice", Content: strings.Repeat("a", 11)}, time.Now())
+	assert.ErrorIs(t, err, moderation.ErrMessageBlocked)
+
+	history, _ := uc.History(10)
+	assert.Empty(t, history)
+}
+
+func TestLobbyChatUseCase_Disabled(t *testing.T) {
+	uc := NewLobbyChatUseCase(persistence.NewInMemoryChatRepository(), nil, NewRoomMuteUseCase(), false, 0)
+
+	_, err := uc.DisplayName("Alice", false, nil)
+	assert.ErrorIs(t, err, ErrLobbyChatDisabled)
+	_, err = uc.History(10)
+	assert.ErrorIs(t, err, ErrLobbyChatDisabled)
+	_, _, err = uc.Send(LobbyChatInput{UserID: "user-1", UserName: "Alice", Content: "hi"}, time.Now())
+	assert.ErrorIs(t, err, ErrLobbyChatDisabled)
+}
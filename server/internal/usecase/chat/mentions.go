@@ -0,0 +1,85 @@
+package chat
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"voice-chat/internal/domain/entity"
+)
+
+// hereMention is the keyword moderators use to ping everyone in the room
+const hereMention = "here"
+
+// ParseMentions resolves @name mentions in content against the given participants.
+// Names are matched case-insensitively, longest first, so "@Alice_banana" never
+// resolves to "Alice". It returns the mentioned user IDs and whether @here was used.
+func ParseMentions(content string, participants []*entity.User) ([]string, bool) {
+	lowered := strings.ToLower(content)
+	if !strings.Contains(lowered, "@") {
+		return nil, false
+	}
+
+	type candidate struct {
+		name   string
+		userID string
+	}
+	candidates := make([]candidate, 0, len(participants))
+	for _, p := range participants {
+		candidates = append(candidates, candidate{name: strings.ToLower(p.Name), userID: p.ID})
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		return len(candidates[i].name) > len(candidates[j].name)
+	})
+
+	seen := make(map[string]bool)
+	userIDs := make([]string, 0)
+	here := false
+
+	for i := 0; i < len(lowered); i++ {
+		if lowered[i] != '@' {
+			continue
+		}
+
+		// Skip e-mail addresses and the like
+		if i > 0 {
+			prev, _ := utf8.DecodeLastRuneInString(lowered[:i])
+			if isNameRune(prev) {
+				continue
+			}
+		}
+
+		rest := lowered[i+1:]
+		if hasMention(rest, hereMention) {
+			here = true
+			continue
+		}
+
+		for _, c := range candidates {
+			if c.name != "" && hasMention(rest, c.name) {
+				if !seen[c.userID] {
+					seen[c.userID] = true
+					userIDs = append(userIDs, c.userID)
+				}
+				break
+			}
+		}
+	}
+
+	return userIDs, here
+}
+
+// hasMention checks if s starts with name followed by a non-name character
+func hasMention(s, name string) bool {
+	if !strings.HasPrefix(s, name) {
+		return false
+	}
+	next, size := utf8.DecodeRuneInString(s[len(name):])
+	return size == 0 || !isNameRune(next)
+}
+
+// isNameRune checks if r can be part of a user name next to a mention
+func isNameRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_'
+}
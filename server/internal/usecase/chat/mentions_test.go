@@ -0,0 +1,91 @@
+package chat
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"voice-chat/internal/domain/entity"
+	"voice-chat/internal/infrastructure/persistence"
+)
+
+func newMentionParticipants() []*entity.User {
+	return []*entity.User{
+		entity.NewUser("user-1", "Alice", "", entity.VoiceModePTT),
+		entity.NewUser("user-2", "Alice_banana", "", entity.VoiceModePTT),
+		entity.NewUser("user-3", "Bob Smith", "", entity.VoiceModePTT),
+	}
+}
+
+func TestParseMentions(t *testing.T) {
+	participants := newMentionParticipants()
+
+	tests := []struct {
+		content string
+		userIDs []string
+		here    bool
+	}{
+		{"hi @alice", []string{"user-1"}, false},
+		{"@Alice_banana you there?", []string{"user-2"}, false},
+		{"@Alice, @Alice_banana and @bob smith!", []string{"user-1", "user-2", "user-3"}, false},
+		{"@Alicex is not a mention", []string{}, false},
+		{"mail alice@alice.com", []string{}, false},
+		{"@here lineup in 5", []string{}, true},
+		{"@alice @alice", []string{"user-1"}, false},
+		{"no mentions", nil, false},
+	}
+
+	for _, tt := range tests {
+		userIDs, here := ParseMentions(tt.content, participants)
+		assert.Equal(t, tt.userIDs, userIDs, tt.content)
+		assert.Equal(t, tt.here, here, tt.content)
+	}
+}
+
+func setupMentionTest() (*persistence.InMemoryChatRepository, *SendMessageUseCase) {
+	chatRepo := persistence.NewInMemoryChatRepository()
+	roomRepo := persistence.NewInMemoryRoomRepository()
+	room := entity.NewRoom("room-1", "Lobby", entity.RoomTypePublic, "owner-1")
+	for _, p := range newMentionParticipants() {
+		room.AddParticipant(p)
+	}
+	stealth := entity.NewUser("admin-1", "Ghost", "", entity.VoiceModePTT)
+	stealth.IsStealth = true
+	room.AddParticipant(stealth)
+	roomRepo.Create(room)
+	return chatRepo, NewSendMessageUseCase(chatRepo, roomRepo, nil, nil)
+}
+
+func TestSendMessageUseCase_Mentions(t *testing.T) {
+	_, uc := setupMentionTest()
+
+	result, err := uc.Execute(SendMessageInput{
+		RoomID: "room-1", UserID: "user-1", UserName: "Alice", Content: "@alice @Bob Smith @ghost",
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"user-1", "user-3"}, result.Message.Mentions)
+	assert.Equal(t, []string{"user-3"}, result.Notify)
+}
+
+func TestSendMessageUseCase_HereRequiresModerator(t *testing.T) {
+	_, uc := setupMentionTest()
+
+	result, err := uc.Execute(SendMessageInput{RoomID: "room-1", UserID: "user-1", UserName: "Alice", Content: "@here hi"})
+
+	assert.NoError(t, err)
+	assert.False(t, result.Message.AtHere)
+	assert.Empty(t, result.Notify)
+}
+
+func TestSendMessageUseCase_HereRateLimited(t *testing.T) {
+	_, uc := setupMentionTest()
+
+	result, err := uc.Execute(SendMessageInput{RoomID: "room-1", UserID: "admin-1", UserName: "Ghost", Content: "@here hi", IsAdmin: true})
+	assert.NoError(t, err)
+	assert.True(t, result.Message.AtHere)
+	assert.ElementsMatch(t, []string{"user-1", "user-2", "user-3"}, result.Notify)
+
+	_, err = uc.Execute(SendMessageInput{RoomID: "room-1", UserID: "admin-1", UserName: "Ghost", Content: "@here again", IsAdmin: true})
+	assert.ErrorIs(t, err, ErrHereMentionRateLimited)
+}
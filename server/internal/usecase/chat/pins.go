@@ -0,0 +1,114 @@
+package chat
+
+import (
+	"errors"
+	"time"
+
+	"voice-chat/internal/domain/entity"
+	"voice-chat/internal/domain/repository"
+)
+
+var (
+	ErrNotAllowedToPin    = errors.New("only the room owner or an admin can pin messages")
+	ErrPinMessageNotFound = errors.New("message to pin was not found")
+)
+
+// PinInput represents the input for pinning or unpinning a message
+type PinInput struct {
+	RoomID    string
+	MessageID string
+	UserID    string
+	UserName  string
+	IsAdmin   bool
+}
+
+// PinUseCase lets room owners and admins pin chat messages to the top of their room.
+// Pins are stored on the room, so they stay after the message drops out of chat history.
+type PinUseCase struct {
+	chatRepo repository.ChatRepository
+	roomRepo repository.RoomRepository
+	maxPins  int
+}
+
+// NewPinUseCase creates a new PinUseCase; maxPins <= 0 uses entity.DefaultMaxPins
+func NewPinUseCase(chatRepo repository.ChatRepository, roomRepo repository.RoomRepository, maxPins int) *PinUseCase {
+	if maxPins <= 0 {
+		maxPins = entity.DefaultMaxPins
+	}
+	return &PinUseCase{
+		chatRepo: chatRepo,
+		roomRepo: roomRepo,
+		maxPins:  maxPins,
+	}
+}
+
+// Pin pins a message in the room
+func (uc *PinUseCase) Pin(input PinInput) (*entity.Room, error) {
+	room, err := uc.moderatedRoom(input)
+	if err != nil {
+		return nil, err
+	}
+
+	msg, err := uc.chatRepo.GetMessage(input.RoomID, input.MessageID)
+	if err != nil || msg.IsDeleted() {
+		return nil, ErrPinMessageNotFound
+	}
+
+	if err := room.Pin(msg, input.UserName, time.Now(), uc.maxPins); err != nil {
+		return nil, err
+	}
+	if err := uc.roomRepo.Update(room); err != nil {
+		return nil, err
+	}
+	return room, nil
+}
+
+// Unpin removes a pin; the message itself is untouched
+func (uc *PinUseCase) Unpin(input PinInput) (*entity.Room, error) {
+	room, err := uc.moderatedRoom(input)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := room.Unpin(input.MessageID); err != nil {
+		return nil, err
+	}
+	if err := uc.roomRepo.Update(room); err != nil {
+		return nil, err
+	}
+	return room, nil
+}
+
+// Refresh brings a pin up to date after its message was edited or deleted (deleted messages are
+// unpinned). It returns the room when its pins changed, nil otherwise.
+func (uc *PinUseCase) Refresh(msg *entity.ChatMessage) *entity.Room {
+	room, err := uc.roomRepo.GetByID(msg.RoomID)
+	if err != nil || room == nil {
+		return nil
+	}
+
+	if msg.IsDeleted() {
+		if room.Unpin(msg.ID) != nil {
+			return nil
+		}
+	} else if !room.RefreshPin(msg) {
+		return nil
+	}
+
+	if err := uc.roomRepo.Update(room); err != nil {
+		return nil
+	}
+	return room
+}
+
+// moderatedRoom loads the room if the user may moderate it
+func (uc *PinUseCase) moderatedRoom(input PinInput) (*entity.Room, error) {
+	room, err := uc.roomRepo.GetByID(input.RoomID)
+	if err != nil || room == nil {
+		return nil, ErrRoomNotFound
+	}
+	if !IsModerator(uc.roomRepo, input.RoomID, input.UserID, input.IsAdmin) {
+		return nil, ErrNotAllowedToPin
+	}
+	return room, nil
+}
@@ -0,0 +1,95 @@
+package chat
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"voice-chat/internal/domain/entity"
+	"voice-chat/internal/infrastructure/persistence"
+)
+
+func setupPins(maxPins int) (*PinUseCase, *persistence.InMemoryChatRepository, *persistence.InMemoryRoomRepository) {
+	chatRepo := persistence.NewInMemoryChatRepository()
+	roomRepo := persistence.NewInMemoryRoomRepository()
+	roomRepo.Create(entity.NewRoom("room-1", "Room", entity.RoomTypePublic, "owner"))
+	for _, id := range []string{"msg-1", "msg-2", "msg-3"} {
+		chatRepo.AddMessage(entity.NewChatMessage(id, "room-1", "user-1", "Alice", "bracket: "+id))
+	}
+	return NewPinUseCase(chatRepo, roomRepo, maxPins), chatRepo, roomRepo
+}
+
+func TestPinUseCase_Pin(t *testing.T) {
+	uc, _, _ := setupPins(2)
+	pin := func(userID, messageID string) error {
+		_, err := uc.Pin(PinInput{RoomID: "room-1", MessageID: messageID, UserID: userID, UserName: userID})
+		return err
+	}
+
+	assert.ErrorIs(t, pin("user-1", "msg-1"), ErrNotAllowedToPin)
+	assert.ErrorIs(t, pin("owner", "missing"), ErrPinMessageNotFound)
+
+	assert.NoError(t, pin("owner", "msg-1"))
+	assert.ErrorIs(t, pin("owner", "msg-1"), entity.ErrAlreadyPinned)
+	assert.NoError(t, pin("owner", "msg-2"))
+	assert.ErrorIs(t, pin("owner", "msg-3"), entity.ErrTooManyPins)
+
+	room, err := uc.Unpin(PinInput{RoomID: "room-1", MessageID: "msg-1", UserID: "admin", IsAdmin: true})
+	assert.NoError(t, err)
+	pinned := room.GetPinned()
+	assert.Len(t, pinned, 1)
+	assert.Equal(t, "msg-2", pinned[0].Message.ID)
+	assert.Equal(t, "owner", pinned[0].PinnedBy)
+
+	_, err = uc.Unpin(PinInput{RoomID: "room-1", MessageID: "msg-1", UserID: "owner"})
+	assert.ErrorIs(t, err, entity.ErrNotPinned)
+}
+
+func TestPinUseCase_SurvivesHistoryTrimming(t *testing.T) {
+	uc, chatRepo, roomRepo := setupPins(0)
+	_, err := uc.Pin(PinInput{RoomID: "room-1", MessageID: "msg-1", UserID: "owner"})
+	assert.NoError(t, err)
+
+	assert.NoError(t, chatRepo.DeleteRoomMessages("room-1"))
+
+	room, _ := roomRepo.GetByID("room-1")
+	assert.Equal(t, "bracket: msg-1", room.GetPinned()[0].Message.Content)
+}
+
+func TestPinUseCase_Refresh(t *testing.T) {
+	uc, chatRepo, _ := setupPins(0)
+	uc.Pin(PinInput{RoomID: "room-1", MessageID: "msg-1", UserID: "owner"})
+
+	msg, _ := chatRepo.GetMessage("room-1", "msg-1")
+	msg.Edit("bracket v2", time.Now())
+	room := uc.Refresh(msg)
+	assert.NotNil(t, room)
+	assert.Equal(t, "bracket v2", room.GetPinned()[0].Message.Content)
+
+	// Messages that aren't pinned don't touch the room
+	other, _ := chatRepo.GetMessage("room-1", "msg-2")
+	assert.Nil(t, uc.Refresh(other))
+
+	msg.Delete("owner", time.Now())
+	room = uc.Refresh(msg)
+	assert.NotNil(t, room)
+	assert.Empty(t, room.GetPinned())
+}
+
+func TestTopicUseCase_Set(t *testing.T) {
+	roomRepo := persistence.NewInMemoryRoomRepository()
+	roomRepo.Create(entity.NewRoom("room-1", "Room", entity.RoomTypePublic, "owner"))
+	uc := NewTopicUseCase(roomRepo)
+
+	_, err := uc.Set(SetTopicInput{RoomID: "room-1", UserID: "user-1", Topic: "hi"})
+	assert.ErrorIs(t, err, ErrNotAllowedToSetTopic)
+
+	_, err = uc.Set(SetTopicInput{RoomID: "room-1", UserID: "owner", Topic: strings.Repeat("x", entity.MaxTopicLength+1)})
+	assert.ErrorIs(t, err, ErrTopicTooLong)
+
+	room, err := uc.Set(SetTopicInput{RoomID: "room-1", UserID: "owner", Topic: "  Finals at 8pm  "})
+	assert.NoError(t, err)
+	assert.Equal(t, "Finals at 8pm", room.GetTopic())
+}
@@ -0,0 +1,166 @@
+package chat
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"voice-chat/internal/domain/entity"
+	"voice-chat/internal/domain/repository"
+)
+
+var ErrNotAllowedToClosePoll = errors.New("only the poll's author or a moderator can close it")
+
+// CreatePollInput represents the input for posting a poll to a room
+type CreatePollInput struct {
+	RoomID      string
+	UserID      string
+	UserName    string
+	Question    string
+	Options     []string
+	MultiChoice bool
+	Anonymous   bool
+	Duration    time.Duration // 0 keeps the poll open until it is closed by hand
+}
+
+// VotePollInput represents a user's choices on a poll
+type VotePollInput struct {
+	RoomID    string
+	MessageID string
+	UserID    string
+	Choices   []int // Option indexes; empty withdraws the vote
+}
+
+// ClosePollInput represents the input for closing a poll early
+type ClosePollInput struct {
+	RoomID    string
+	MessageID string
+	UserID    string
+	IsAdmin   bool
+}
+
+// PollUpdate is a poll's state after a vote or after it closed
+type PollUpdate struct {
+	RoomID    string
+	MessageID string
+	Poll      *entity.Poll
+}
+
+// pollTimer tracks a poll that closes on its own
+type pollTimer struct {
+	roomID   string
+	closesAt time.Time
+}
+
+// PollUseCase posts polls to room chat, records votes and closes polls when their timer runs
+// out. Votes go through the chat repository so concurrent votes are never lost.
+type PollUseCase struct {
+	chatRepo repository.ChatRepository
+	roomRepo repository.RoomRepository
+	timers   map[string]pollTimer // messageID -> timer
+	mu       sync.Mutex
+}
+
+// NewPollUseCase creates a new PollUseCase
+func NewPollUseCase(chatRepo repository.ChatRepository, roomRepo repository.RoomRepository) *PollUseCase {
+	return &PollUseCase{
+		chatRepo: chatRepo,
+		roomRepo: roomRepo,
+		timers:   make(map[string]pollTimer),
+	}
+}
+
+// Create stores a poll message
+func (uc *PollUseCase) Create(input CreatePollInput) (*entity.ChatMessage, error) {
+	now := time.Now()
+	poll, err := entity.NewPoll(input.Question, input.Options, input.MultiChoice, input.Anonymous, input.Duration, now)
+	if err != nil {
+		return nil, err
+	}
+
+	msg := entity.NewPollMessage(uuid.New().String(), input.RoomID, input.UserID, input.UserName, poll)
+	if err := uc.chatRepo.AddMessage(msg); err != nil {
+		return nil, err
+	}
+
+	if poll.ClosesAt != nil {
+		uc.mu.Lock()
+		uc.timers[msg.ID] = pollTimer{roomID: input.RoomID, closesAt: *poll.ClosesAt}
+		uc.mu.Unlock()
+	}
+	return msg, nil
+}
+
+// Vote records a user's choices
+func (uc *PollUseCase) Vote(input VotePollInput) (*PollUpdate, error) {
+	poll, err := uc.chatRepo.VotePoll(input.RoomID, input.MessageID, input.UserID, input.Choices, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	return &PollUpdate{RoomID: input.RoomID, MessageID: input.MessageID, Poll: poll}, nil
+}
+
+// Close ends a poll before its timer, or one without a timer
+func (uc *PollUseCase) Close(input ClosePollInput) (*PollUpdate, error) {
+	msg, err := uc.chatRepo.GetMessage(input.RoomID, input.MessageID)
+	if err != nil {
+		return nil, err
+	}
+	if msg.SenderID != input.UserID && !IsModerator(uc.roomRepo, input.RoomID, input.UserID, input.IsAdmin) {
+		return nil, ErrNotAllowedToClosePoll
+	}
+
+	poll, closed, err := uc.chatRepo.ClosePoll(input.RoomID, input.MessageID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if !closed {
+		return nil, entity.ErrPollClosed
+	}
+
+	uc.mu.Lock()
+	delete(uc.timers, input.MessageID)
+	uc.mu.Unlock()
+
+	return &PollUpdate{RoomID: input.RoomID, MessageID: input.MessageID, Poll: poll}, nil
+}
+
+// CloseExpired closes polls whose timer ran out and returns them so the rooms can be told
+func (uc *PollUseCase) CloseExpired(now time.Time) []*PollUpdate {
+	return uc.closeWhere(now, func(t pollTimer) bool {
+		return !now.Before(t.closesAt)
+	})
+}
+
+// CloseRoom closes every timed poll in a room that is being cleaned up
+func (uc *PollUseCase) CloseRoom(roomID string, now time.Time) []*PollUpdate {
+	return uc.closeWhere(now, func(t pollTimer) bool {
+		return t.roomID == roomID
+	})
+}
+
+// closeWhere closes the tracked polls matching the filter
+func (uc *PollUseCase) closeWhere(now time.Time, match func(t pollTimer) bool) []*PollUpdate {
+	uc.mu.Lock()
+	due := make(map[string]pollTimer)
+	for messageID, t := range uc.timers {
+		if match(t) {
+			due[messageID] = t
+			delete(uc.timers, messageID)
+		}
+	}
+	uc.mu.Unlock()
+
+	updates := make([]*PollUpdate, 0, len(due))
+	for messageID, t := range due {
+		// Deleted or expired messages and polls closed by hand need no update
+		poll, closed, err := uc.chatRepo.ClosePoll(t.roomID, messageID, now)
+		if err != nil || !closed {
+			continue
+		}
+		updates = append(updates, &PollUpdate{RoomID: t.roomID, MessageID: messageID, Poll: poll})
+	}
+	return updates
+}
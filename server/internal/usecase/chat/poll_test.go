@@ -0,0 +1,185 @@
+package chat
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"voice-chat/internal/domain/entity"
+	"voice-chat/internal/infrastructure/persistence"
+)
+
+func setupPolls() (*PollUseCase, *persistence.InMemoryChatRepository) {
+	chatRepo := persistence.NewInMemoryChatRepository()
+	roomRepo := persistence.NewInMemoryRoomRepository()
+	roomRepo.Create(entity.NewRoom("room-1", "Room", entity.RoomTypePublic, "owner"))
+	roomRepo.Create(entity.NewRoom("room-2", "Other", entity.RoomTypePublic, "owner"))
+	return NewPollUseCase(chatRepo, roomRepo), chatRepo
+}
+
+func createPoll(t *testing.T, uc *PollUseCase, roomID string, multi bool, duration time.Duration) *entity.ChatMessage {
+	msg, err := uc.Create(CreatePollInput{
+		RoomID:      roomID,
+		UserID:      "user-1",
+		UserName:    "Alice",
+		Question:    " Lunch? ",
+		Options:     []string{"Pizza", "Sushi", "Tacos"},
+		MultiChoice: multi,
+		Duration:    duration,
+	})
+	assert.NoError(t, err)
+	return msg
+}
+
+func TestPollUseCase_Create(t *testing.T) {
+	uc, chatRepo := setupPolls()
+
+	msg := createPoll(t, uc, "room-1", false, 0)
+	assert.Equal(t, entity.ChatMessageTypePoll, msg.Type)
+	assert.Equal(t, "Lunch?", msg.Content)
+	assert.Equal(t, "Lunch?", msg.Poll.Question)
+	assert.Nil(t, msg.Poll.ClosesAt)
+
+	stored, err := chatRepo.GetMessage("room-1", msg.ID)
+	assert.NoError(t, err)
+	assert.NotNil(t, stored.Poll)
+
+	invalid := []CreatePollInput{
+		{Question: "", Options: []string{"a", "b"}},
+		{Question: "Q", Options: []string{"only one"}},
+		{Question: "Q", Options: []string{"Same", "same"}},
+		{Question: "Q", Options: []string{"a", " "}},
+		{Question: "Q", Options: []string{"a", "b"}, Duration: 48 * time.Hour},
+	}
+	for _, input := range invalid {
+		input.RoomID = "room-1"
+		_, err := uc.Create(input)
+		assert.ErrorIs(t, err, entity.ErrInvalidPoll)
+	}
+}
+
+func TestPollUseCase_Vote(t *testing.T) {
+	uc, _ := setupPolls()
+	single := createPoll(t, uc, "room-1", false, 0)
+	multi := createPoll(t, uc, "room-1", true, 0)
+
+	vote := func(messageID, userID string, choices ...int) (*PollUpdate, error) {
+		return uc.Vote(VotePollInput{RoomID: "room-1", MessageID: messageID, UserID: userID, Choices: choices})
+	}
+
+	_, err := vote(single.ID, "user-2", 0)
+	assert.NoError(t, err)
+	update, err := vote(single.ID, "user-3", 1)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 1, 0}, update.Poll.Tally())
+
+	// Voting again replaces the earlier choice
+	update, err = vote(single.ID, "user-2", 1)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{0, 2, 0}, update.Poll.Tally())
+	assert.Equal(t, []string{"user-2", "user-3"}, update.Poll.Voters()[1])
+
+	// No choices withdraws the vote
+	update, err = vote(single.ID, "user-3")
+	assert.NoError(t, err)
+	assert.Equal(t, []int{0, 1, 0}, update.Poll.Tally())
+
+	_, err = vote(single.ID, "user-2", 0, 1)
+	assert.ErrorIs(t, err, entity.ErrInvalidVote)
+	_, err = vote(single.ID, "user-2", 3)
+	assert.ErrorIs(t, err, entity.ErrInvalidVote)
+
+	update, err = vote(multi.ID, "user-2", 2, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{0, 2}, update.Poll.Votes["user-2"])
+	_, err = vote(multi.ID, "user-2", 1, 1)
+	assert.ErrorIs(t, err, entity.ErrInvalidVote)
+}
+
+func TestPollUseCase_VoteNotAPoll(t *testing.T) {
+	uc, chatRepo := setupPolls()
+	chatRepo.AddMessage(entity.NewChatMessage("msg-1", "room-1", "user-1", "Alice", "hello"))
+
+	_, err := uc.Vote(VotePollInput{RoomID: "room-1", MessageID: "msg-1", UserID: "user-2", Choices: []int{0}})
+	assert.ErrorIs(t, err, entity.ErrNotAPoll)
+	_, err = uc.Vote(VotePollInput{RoomID: "room-1", MessageID: "missing", UserID: "user-2", Choices: []int{0}})
+	assert.Error(t, err)
+}
+
+func TestPollUseCase_ConcurrentVotes(t *testing.T) {
+	uc, chatRepo := setupPolls()
+	msg := createPoll(t, uc, "room-1", false, 0)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, err := uc.Vote(VotePollInput{RoomID: "room-1", MessageID: msg.ID, UserID: fmt.Sprintf("user-%d", i), Choices: []int{i % 3}})
+			assert.NoError(t, err)
+		}(i)
+	}
+	wg.Wait()
+
+	stored, err := chatRepo.GetMessage("room-1", msg.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{17, 17, 16}, stored.Poll.Tally())
+}
+
+func TestPollUseCase_Close(t *testing.T) {
+	uc, _ := setupPolls()
+	msg := createPoll(t, uc, "room-1", false, 0)
+
+	_, err := uc.Close(ClosePollInput{RoomID: "room-1", MessageID: msg.ID, UserID: "user-2"})
+	assert.ErrorIs(t, err, ErrNotAllowedToClosePoll)
+
+	update, err := uc.Close(ClosePollInput{RoomID: "room-1", MessageID: msg.ID, UserID: "user-1"})
+	assert.NoError(t, err)
+	assert.NotNil(t, update.Poll.ClosedAt)
+
+	_, err = uc.Close(ClosePollInput{RoomID: "room-1", MessageID: msg.ID, UserID: "owner"})
+	assert.ErrorIs(t, err, entity.ErrPollClosed)
+
+	_, err = uc.Vote(VotePollInput{RoomID: "room-1", MessageID: msg.ID, UserID: "user-2", Choices: []int{0}})
+	assert.ErrorIs(t, err, entity.ErrPollClosed)
+
+	// Room owners moderate their room
+	other := createPoll(t, uc, "room-1", false, 0)
+	_, err = uc.Close(ClosePollInput{RoomID: "room-1", MessageID: other.ID, UserID: "owner"})
+	assert.NoError(t, err)
+}
+
+func TestPollUseCase_CloseExpired(t *testing.T) {
+	uc, chatRepo := setupPolls()
+	timed := createPoll(t, uc, "room-1", false, time.Minute)
+	untimed := createPoll(t, uc, "room-1", false, 0)
+
+	assert.Empty(t, uc.CloseExpired(time.Now()))
+
+	later := time.Now().Add(2 * time.Minute)
+	updates := uc.CloseExpired(later)
+	assert.Len(t, updates, 1)
+	assert.Equal(t, timed.ID, updates[0].MessageID)
+	assert.Equal(t, *timed.Poll.ClosesAt, *updates[0].Poll.ClosedAt)
+
+	// Each poll is closed once
+	assert.Empty(t, uc.CloseExpired(later))
+
+	stored, _ := chatRepo.GetMessage("room-1", untimed.ID)
+	assert.Nil(t, stored.Poll.ClosedAt)
+}
+
+func TestPollUseCase_CloseRoom(t *testing.T) {
+	uc, _ := setupPolls()
+	first := createPoll(t, uc, "room-1", false, time.Hour)
+	createPoll(t, uc, "room-2", false, time.Hour)
+
+	updates := uc.CloseRoom("room-1", time.Now())
+	assert.Len(t, updates, 1)
+	assert.Equal(t, first.ID, updates[0].MessageID)
+
+	assert.Len(t, uc.CloseExpired(time.Now().Add(2*time.Hour)), 1)
+}
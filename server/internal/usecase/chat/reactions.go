@@ -0,0 +1,96 @@
+package chat
+
+import (
+	"errors"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"voice-chat/internal/domain/entity"
+	"voice-chat/internal/domain/repository"
+)
+
+// MaxReactionLength is the longest reaction in bytes when no allowed set is configured; it fits
+// multi-codepoint emoji such as flags and family sequences
+const MaxReactionLength = 32
+
+// DefaultMaxReactionsPerMessage is how many different reactions a message can have unless configured otherwise
+const DefaultMaxReactionsPerMessage = 20
+
+var (
+	ErrReactionNotAllowed      = errors.New("this reaction is not allowed")
+	ErrReactionMessageNotFound = errors.New("message not found")
+)
+
+// ReactionInput represents a user adding or removing a reaction
+type ReactionInput struct {
+	RoomID    string
+	MessageID string
+	UserID    string
+	Emoji     string
+}
+
+// ReactionUseCase adds and removes reactions on chat messages. Updates go through the chat
+// repository's atomic operations so simultaneous reactions are never lost.
+type ReactionUseCase struct {
+	chatRepo  repository.ChatRepository
+	allowed   map[string]bool // Empty allows any short emoji-like reaction
+	maxEmojis int             // Different reactions per message (0 = no cap)
+}
+
+// NewReactionUseCase creates a new ReactionUseCase
+func NewReactionUseCase(chatRepo repository.ChatRepository, allowed []string, maxEmojis int) *ReactionUseCase {
+	set := make(map[string]bool, len(allowed))
+	for _, emoji := range allowed {
+		if emoji = strings.TrimSpace(emoji); emoji != "" {
+			set[emoji] = true
+		}
+	}
+	return &ReactionUseCase{
+		chatRepo:  chatRepo,
+		allowed:   set,
+		maxEmojis: maxEmojis,
+	}
+}
+
+// Add records a user's reaction and returns everyone who reacted with that emoji
+func (uc *ReactionUseCase) Add(input ReactionInput) ([]string, error) {
+	if !uc.isAllowed(input.Emoji) {
+		return nil, ErrReactionNotAllowed
+	}
+
+	users, err := uc.chatRepo.AddReaction(input.RoomID, input.MessageID, input.Emoji, input.UserID, uc.maxEmojis)
+	return users, reactionError(err)
+}
+
+// Remove withdraws a user's reaction and returns everyone still reacting with that emoji. Any
+// reaction can be removed, even one that is no longer allowed.
+func (uc *ReactionUseCase) Remove(input ReactionInput) ([]string, error) {
+	users, err := uc.chatRepo.RemoveReaction(input.RoomID, input.MessageID, input.Emoji, input.UserID)
+	return users, reactionError(err)
+}
+
+// isAllowed checks a reaction against the allowed set, or without one, that it is a short
+// string with no spaces or control characters
+func (uc *ReactionUseCase) isAllowed(emoji string) bool {
+	if len(uc.allowed) > 0 {
+		return uc.allowed[emoji]
+	}
+	if emoji == "" || len(emoji) > MaxReactionLength || !utf8.ValidString(emoji) {
+		return false
+	}
+	for _, r := range emoji {
+		if unicode.IsSpace(r) || unicode.IsControl(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// reactionError passes the domain errors through and reports anything else as a missing message
+func reactionError(err error) error {
+	if err == nil || errors.Is(err, entity.ErrTooManyReactions) || errors.Is(err, entity.ErrReactionOnDeleted) {
+		return err
+	}
+	return ErrReactionMessageNotFound
+}
@@ -0,0 +1,124 @@
+package chat
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"voice-chat/internal/domain/entity"
+	"voice-chat/internal/infrastructure/persistence"
+)
+
+func setupReactions(allowed []string, maxEmojis int) (*ReactionUseCase, *persistence.InMemoryChatRepository) {
+	chatRepo := persistence.NewInMemoryChatRepository()
+	chatRepo.AddMessage(entity.NewChatMessage("msg-1", "room-1", "user-1", "Alice", "hello"))
+	return NewReactionUseCase(chatRepo, allowed, maxEmojis), chatRepo
+}
+
+func TestReactionUseCase_AddAndRemove(t *testing.T) {
+	uc, chatRepo := setupReactions(nil, DefaultMaxReactionsPerMessage)
+
+	users, err := uc.Add(ReactionInput{RoomID: "room-1", MessageID: "msg-1", UserID: "user-2", Emoji: "👍"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"user-2"}, users)
+
+	// Reacting twice is a no-op
+	users, err = uc.Add(ReactionInput{RoomID: "room-1", MessageID: "msg-1", UserID: "user-2", Emoji: "👍"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"user-2"}, users)
+
+	users, err = uc.Add(ReactionInput{RoomID: "room-1", MessageID: "msg-1", UserID: "user-3", Emoji: "👍"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"user-2", "user-3"}, users)
+
+	users, err = uc.Remove(ReactionInput{RoomID: "room-1", MessageID: "msg-1", UserID: "user-2", Emoji: "👍"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"user-3"}, users)
+
+	users, err = uc.Remove(ReactionInput{RoomID: "room-1", MessageID: "msg-1", UserID: "user-3", Emoji: "👍"})
+	assert.NoError(t, err)
+	assert.Empty(t, users)
+
+	stored, _ := chatRepo.GetMessage("room-1", "msg-1")
+	assert.Empty(t, stored.Reactions)
+
+	_, err = uc.Add(ReactionInput{RoomID: "room-1", MessageID: "missing", UserID: "user-2", Emoji: "👍"})
+	assert.ErrorIs(t, err, ErrReactionMessageNotFound)
+	_, err = uc.Remove(ReactionInput{RoomID: "room-1", MessageID: "missing", UserID: "user-2", Emoji: "👍"})
+	assert.ErrorIs(t, err, ErrReactionMessageNotFound)
+}
+
+func TestReactionUseCase_Validation(t *testing.T) {
+	uc, _ := setupReactions(nil, 0)
+
+	for _, emoji := range []string{"🇳🇱", "👨‍👩‍👧", "+1"} {
+		_, err := uc.Add(ReactionInput{RoomID: "room-1", MessageID: "msg-1", UserID: "user-2", Emoji: emoji})
+		assert.NoError(t, err, emoji)
+	}
+	for _, emoji := range []string{"", "a b", "x\n", string([]byte{0xff}), "this reaction is far too long to be an emoji"} {
+		_, err := uc.Add(ReactionInput{RoomID: "room-1", MessageID: "msg-1", UserID: "user-2", Emoji: emoji})
+		assert.ErrorIs(t, err, ErrReactionNotAllowed, emoji)
+	}
+
+	// An allowed set replaces the default check
+	uc, chatRepo := setupReactions([]string{"👍", " ❤️ "}, 0)
+	_, err := uc.Add(ReactionInput{RoomID: "room-1", MessageID: "msg-1", UserID: "user-2", Emoji: "❤️"})
+	assert.NoError(t, err)
+	_, err = uc.Add(ReactionInput{RoomID: "room-1", MessageID: "msg-1", UserID: "user-2", Emoji: "🎉"})
+	assert.ErrorIs(t, err, ErrReactionNotAllowed)
+
+	// Reactions added before the set was narrowed can still be removed
+	msg, _ := chatRepo.GetMessage("room-1", "msg-1")
+	msg.AddReaction("🎉", "user-3")
+	users, err := uc.Remove(ReactionInput{RoomID: "room-1", MessageID: "msg-1", UserID: "user-3", Emoji: "🎉"})
+	assert.NoError(t, err)
+	assert.Empty(t, users)
+}
+
+func TestReactionUseCase_Cap(t *testing.T) {
+	uc, _ := setupReactions(nil, 2)
+
+	_, err := uc.Add(ReactionInput{RoomID: "room-1", MessageID: "msg-1", UserID: "user-2", Emoji: "👍"})
+	assert.NoError(t, err)
+	_, err = uc.Add(ReactionInput{RoomID: "room-1", MessageID: "msg-1", UserID: "user-2", Emoji: "❤️"})
+	assert.NoError(t, err)
+
+	_, err = uc.Add(ReactionInput{RoomID: "room-1", MessageID: "msg-1", UserID: "user-2", Emoji: "🎉"})
+	assert.ErrorIs(t, err, entity.ErrTooManyReactions)
+
+	// Joining an existing reaction doesn't count against the cap
+	users, err := uc.Add(ReactionInput{RoomID: "room-1", MessageID: "msg-1", UserID: "user-3", Emoji: "👍"})
+	assert.NoError(t, err)
+	assert.Len(t, users, 2)
+}
+
+func TestReactionUseCase_DeletedMessage(t *testing.T) {
+	uc, chatRepo := setupReactions(nil, 0)
+
+	_, err := chatRepo.DeleteMessage("room-1", "msg-1", "user-1", false, time.Now())
+	assert.NoError(t, err)
+
+	_, err = uc.Add(ReactionInput{RoomID: "room-1", MessageID: "msg-1", UserID: "user-2", Emoji: "👍"})
+	assert.ErrorIs(t, err, entity.ErrReactionOnDeleted)
+}
+
+func TestReactionUseCase_Concurrent(t *testing.T) {
+	uc, chatRepo := setupReactions(nil, DefaultMaxReactionsPerMessage)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, err := uc.Add(ReactionInput{RoomID: "room-1", MessageID: "msg-1", UserID: fmt.Sprintf("user-%d", i), Emoji: "👍"})
+			assert.NoError(t, err)
+		}(i)
+	}
+	wg.Wait()
+
+	stored, _ := chatRepo.GetMessage("room-1", "msg-1")
+	assert.Len(t, stored.Reactions["👍"], 50)
+}
@@ -0,0 +1,91 @@
+package chat
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+
+	"voice-chat/internal/domain/repository"
+)
+
+var ErrReadMessageNotFound = errors.New("message to mark as read was not found")
+
+// UnreadState describes how far a session has read in a room
+type UnreadState struct {
+	RoomID      string
+	LastReadID  string
+	UnreadCount int
+}
+
+// ReadStateUseCase tracks per-session read markers and unread counts
+type ReadStateUseCase struct {
+	markerRepo repository.ReadMarkerRepository
+	chatRepo   repository.ChatRepository
+}
+
+// NewReadStateUseCase creates a new ReadStateUseCase
+func NewReadStateUseCase(markerRepo repository.ReadMarkerRepository, chatRepo repository.ChatRepository) *ReadStateUseCase {
+	return &ReadStateUseCase{
+		markerRepo: markerRepo,
+		chatRepo:   chatRepo,
+	}
+}
+
+// ResumeSession returns the session to use for a connection: the resume token when it is
+// still known, otherwise a fresh session
+func (uc *ReadStateUseCase) ResumeSession(token string) string {
+	sessionID := token
+	if sessionID == "" || !uc.markerRepo.HasSession(sessionID) {
+		sessionID = uuid.New().String()
+	}
+	_ = uc.markerRepo.Touch(sessionID)
+	return sessionID
+}
+
+// Unread reports the session's read marker and the number of newer messages in a room
+func (uc *ReadStateUseCase) Unread(sessionID, roomID string) (*UnreadState, error) {
+	lastReadID, err := uc.markerRepo.GetLastRead(sessionID, roomID)
+	if err != nil {
+		return nil, err
+	}
+
+	count, err := uc.chatRepo.CountMessagesAfter(roomID, lastReadID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &UnreadState{
+		RoomID:      roomID,
+		LastReadID:  lastReadID,
+		UnreadCount: count,
+	}, nil
+}
+
+// MarkRead moves the session's read marker to a message. Markers never move backwards,
+// so a late mark_read for an older message leaves the marker where it is.
+func (uc *ReadStateUseCase) MarkRead(sessionID, roomID, messageID string) (*UnreadState, error) {
+	msg, err := uc.chatRepo.GetMessage(roomID, messageID)
+	if err != nil {
+		return nil, ErrReadMessageNotFound
+	}
+
+	lastReadID, err := uc.markerRepo.GetLastRead(sessionID, roomID)
+	if err != nil {
+		return nil, err
+	}
+
+	advance := true
+	if lastReadID != "" {
+		if current, err := uc.chatRepo.GetMessage(roomID, lastReadID); err == nil {
+			advance = !msg.Timestamp.Before(current.Timestamp)
+		}
+	}
+
+	if advance {
+		if err := uc.markerRepo.SetLastRead(sessionID, roomID, messageID); err != nil {
+			return nil, err
+		}
+	}
+
+	return uc.Unread(sessionID, roomID)
+}
@@ -0,0 +1,86 @@
+package chat
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"voice-chat/internal/domain/entity"
+	"voice-chat/internal/infrastructure/persistence"
+)
+
+func newReadStateFixture(count int) (*ReadStateUseCase, *persistence.InMemoryChatRepository) {
+	chatRepo := persistence.NewInMemoryChatRepository()
+	base := time.Now()
+	for i := 0; i < count; i++ {
+		msg := entity.NewChatMessage(string(rune('a'+i)), "room-1", "user-1", "Alice", "hi")
+		msg.Timestamp = base.Add(time.Duration(i) * time.Second)
+		chatRepo.AddMessage(msg)
+	}
+	return NewReadStateUseCase(persistence.NewInMemoryReadMarkerRepository(), chatRepo), chatRepo
+}
+
+func TestReadStateUseCase_UnreadWithoutMarker(t *testing.T) {
+	uc, _ := newReadStateFixture(3)
+	session := uc.ResumeSession("")
+
+	state, err := uc.Unread(session, "room-1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "", state.LastReadID)
+	assert.Equal(t, 3, state.UnreadCount)
+}
+
+func TestReadStateUseCase_MarkRead(t *testing.T) {
+	uc, chatRepo := newReadStateFixture(4)
+	session := uc.ResumeSession("")
+
+	state, err := uc.MarkRead(session, "room-1", "b")
+	assert.NoError(t, err)
+	assert.Equal(t, "b", state.LastReadID)
+	assert.Equal(t, 2, state.UnreadCount)
+
+	chatRepo.AddMessage(entity.NewChatMessage("z", "room-1", "user-2", "Bob", "new"))
+	state, _ = uc.Unread(session, "room-1")
+	assert.Equal(t, 3, state.UnreadCount)
+}
+
+func TestReadStateUseCase_MarkReadNeverMovesBackwards(t *testing.T) {
+	uc, _ := newReadStateFixture(4)
+	session := uc.ResumeSession("")
+
+	uc.MarkRead(session, "room-1", "c")
+	state, err := uc.MarkRead(session, "room-1", "a")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "c", state.LastReadID)
+	assert.Equal(t, 1, state.UnreadCount)
+}
+
+func TestReadStateUseCase_MarkReadUnknownMessage(t *testing.T) {
+	uc, _ := newReadStateFixture(1)
+	session := uc.ResumeSession("")
+
+	_, err := uc.MarkRead(session, "room-1", "missing")
+
+	assert.ErrorIs(t, err, ErrReadMessageNotFound)
+}
+
+func TestReadStateUseCase_ResumeSession(t *testing.T) {
+	uc, _ := newReadStateFixture(3)
+	session := uc.ResumeSession("")
+	uc.MarkRead(session, "room-1", "a")
+
+	// Reconnecting with the token keeps the markers
+	resumed := uc.ResumeSession(session)
+	assert.Equal(t, session, resumed)
+	state, _ := uc.Unread(resumed, "room-1")
+	assert.Equal(t, "a", state.LastReadID)
+
+	// Unknown tokens start a fresh session
+	fresh := uc.ResumeSession("forged-token")
+	assert.NotEqual(t, "forged-token", fresh)
+	state, _ = uc.Unread(fresh, "room-1")
+	assert.Equal(t, 3, state.UnreadCount)
+}
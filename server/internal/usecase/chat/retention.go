@@ -0,0 +1,229 @@
+package chat
+
+import (
+	"errors"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+
+	"voice-chat/internal/domain/entity"
+	"voice-chat/internal/domain/repository"
+)
+
+var (
+	ErrNotAllowedToSetRetention = errors.New("only admins can change a room's chat retention")
+	ErrArchiveNotFound          = errors.New("chat archive not found")
+	ErrArchivesDisabled         = errors.New("chat archives are not configured")
+)
+
+// SetRetentionInput represents an admin changing a room's chat retention
+type SetRetentionInput struct {
+	RoomID  string
+	IsAdmin bool
+	Policy  entity.ChatRetention
+}
+
+// RetentionUseCase applies per-room chat retention policies: it hands each room's limits to the
+// chat store, prunes messages past their room's age limit and archives a room's chat when the
+// room goes away. Messages a room no longer keeps are dropped from the search index too.
+type RetentionUseCase struct {
+	chatRepo    repository.ChatRepository
+	roomRepo    repository.RoomRepository
+	searchRepo  repository.ChatSearchRepository
+	archiveRepo repository.ChatArchiveRepository
+}
+
+// NewRetentionUseCase creates a new RetentionUseCase; without an archive store, archiving rooms
+// are deleted like ephemeral ones
+func NewRetentionUseCase(
+	chatRepo repository.ChatRepository,
+	roomRepo repository.RoomRepository,
+	searchRepo repository.ChatSearchRepository,
+	archiveRepo repository.ChatArchiveRepository,
+) *RetentionUseCase {
+	return &RetentionUseCase{
+		chatRepo:    chatRepo,
+		roomRepo:    roomRepo,
+		searchRepo:  searchRepo,
+		archiveRepo: archiveRepo,
+	}
+}
+
+// Apply hands a room's message cap and TTL to the chat store
+func (uc *RetentionUseCase) Apply(room *entity.Room) error {
+	maxMessages, ttl := room.GetRetention().Limits()
+	return uc.chatRepo.SetRoomLimits(room.ID, maxMessages, ttl)
+}
+
+// ApplyAll applies every room's policy, after startup or a rooms file reload
+func (uc *RetentionUseCase) ApplyAll() error {
+	rooms, err := uc.roomRepo.GetAll()
+	if err != nil {
+		return err
+	}
+	for _, room := range rooms {
+		if err := uc.Apply(room); err != nil {
+			log.Printf("Error applying chat retention for room %s: %v", room.Name, err)
+		}
+	}
+	return nil
+}
+
+// Set changes a room's policy and applies it right away
+func (uc *RetentionUseCase) Set(input SetRetentionInput) (*entity.Room, error) {
+	if !input.IsAdmin {
+		return nil, ErrNotAllowedToSetRetention
+	}
+
+	policy := input.Policy
+	if err := policy.Normalize(); err != nil {
+		return nil, err
+	}
+
+	room, err := uc.roomRepo.GetByID(input.RoomID)
+	if err != nil || room == nil {
+		return nil, ErrRoomNotFound
+	}
+
+	room.SetRetention(policy)
+	if err := uc.roomRepo.Update(room); err != nil {
+		return nil, err
+	}
+	if err := uc.Apply(room); err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	if maxAge := policy.MaxAge(); maxAge > 0 {
+		if _, err := uc.chatRepo.PruneMessagesBefore(room.ID, now.Add(-maxAge)); err != nil {
+			return nil, err
+		}
+	}
+	if err := uc.pruneSearch(room, now); err != nil {
+		return nil, err
+	}
+	return room, nil
+}
+
+// Prune deletes messages older than their room's age limit and reports how many were removed
+func (uc *RetentionUseCase) Prune(now time.Time) (int, error) {
+	rooms, err := uc.roomRepo.GetAll()
+	if err != nil {
+		return 0, err
+	}
+
+	total := 0
+	for _, room := range rooms {
+		if maxAge := room.GetRetention().MaxAge(); maxAge > 0 {
+			count, err := uc.chatRepo.PruneMessagesBefore(room.ID, now.Add(-maxAge))
+			if err != nil {
+				log.Printf("Error pruning chat for room %s: %v", room.Name, err)
+				continue
+			}
+			total += count
+		}
+		if err := uc.pruneSearch(room, now); err != nil {
+			log.Printf("Error pruning search index for room %s: %v", room.Name, err)
+		}
+	}
+	return total, nil
+}
+
+// pruneSearch drops messages past the room's age limit or message cap from the search index
+func (uc *RetentionUseCase) pruneSearch(room *entity.Room, now time.Time) error {
+	if uc.searchRepo == nil {
+		return nil
+	}
+
+	policy := room.GetRetention()
+	var cutoff time.Time
+	switch {
+	case policy.MaxAge() > 0:
+		cutoff = now.Add(-policy.MaxAge())
+	case policy.Mode == entity.RetentionMessages:
+		// Everything older than the oldest message the store still keeps has been trimmed
+		kept, err := uc.chatRepo.GetMessages(room.ID, policy.Messages)
+		if err != nil {
+			return err
+		}
+		if len(kept) < policy.Messages {
+			return nil
+		}
+		cutoff = kept[0].Timestamp
+	default:
+		return nil
+	}
+
+	_, err := uc.searchRepo.PruneRoom(room.ID, cutoff)
+	return err
+}
+
+// ReleaseRoom archives a closing room's chat when its policy asks for it, then deletes the
+// chat. If archiving fails the chat is kept so nothing is lost, and the error is returned.
+func (uc *RetentionUseCase) ReleaseRoom(room *entity.Room, now time.Time) (*entity.ChatArchive, error) {
+	var archive *entity.ChatArchive
+	if room.GetRetention().Archives() && uc.archiveRepo != nil {
+		messages, err := collectMessages(uc.chatRepo, room.ID, time.Time{}, time.Time{})
+		if err != nil {
+			return nil, err
+		}
+		if len(messages) > 0 {
+			archive = &entity.ChatArchive{
+				ID:             uuid.New().String(),
+				RoomID:         room.ID,
+				RoomName:       room.Name,
+				MessageCount:   len(messages),
+				FirstMessageAt: messages[0].Timestamp,
+				LastMessageAt:  messages[len(messages)-1].Timestamp,
+				ArchivedAt:     now,
+			}
+			if err := uc.archiveRepo.Save(archive, messages); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if err := uc.chatRepo.DeleteRoomMessages(room.ID); err != nil {
+		return archive, err
+	}
+	if uc.searchRepo != nil {
+		if err := uc.searchRepo.RemoveRoom(room.ID); err != nil {
+			return archive, err
+		}
+	}
+	return archive, nil
+}
+
+// ListArchives lists archived transcripts for admins (newest first)
+func (uc *RetentionUseCase) ListArchives(isAdmin bool) ([]*entity.ChatArchive, error) {
+	if !isAdmin {
+		return nil, ErrNotAllowedToExport
+	}
+	if uc.archiveRepo == nil {
+		return []*entity.ChatArchive{}, nil
+	}
+	return uc.archiveRepo.List()
+}
+
+// GetArchive loads an archived transcript for admins, ready to be written in the given format
+func (uc *RetentionUseCase) GetArchive(id string, format ExportFormat, isAdmin bool) (*ChatExport, error) {
+	if !isAdmin {
+		return nil, ErrNotAllowedToExport
+	}
+	if uc.archiveRepo == nil {
+		return nil, ErrArchivesDisabled
+	}
+
+	archive, messages, err := uc.archiveRepo.Get(id)
+	if err != nil {
+		return nil, ErrArchiveNotFound
+	}
+
+	return &ChatExport{
+		RoomID:     archive.RoomID,
+		RoomName:   archive.RoomName,
+		Format:     format,
+		ExportedAt: archive.ArchivedAt,
+		Messages:   messages,
+	}, nil
+}
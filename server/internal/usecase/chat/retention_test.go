@@ -0,0 +1,184 @@
+package chat
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"voice-chat/internal/domain/entity"
+	"voice-chat/internal/domain/repository"
+	"voice-chat/internal/infrastructure/persistence"
+)
+
+func setupRetention(t *testing.T) (*RetentionUseCase, *persistence.InMemoryChatRepository, *entity.Room) {
+	chatRepo := persistence.NewInMemoryChatRepository()
+	roomRepo := persistence.NewInMemoryRoomRepository()
+	archiveRepo, err := persistence.NewFileChatArchiveRepository(t.TempDir())
+	assert.NoError(t, err)
+
+	room := entity.NewRoom("room-1", "Finals", entity.RoomTypePublic, "owner")
+	roomRepo.Create(room)
+	return NewRetentionUseCase(chatRepo, roomRepo, nil, archiveRepo), chatRepo, room
+}
+
+func addRetentionMessages(chatRepo *persistence.InMemoryChatRepository, start time.Time, count int) {
+	for i := 0; i < count; i++ {
+		msg := entity.NewChatMessage(fmt.Sprintf("msg-%d", i), "room-1", "user-1", "Alice", fmt.Sprintf("message %d", i))
+		msg.Timestamp = start.Add(time.Duration(i) * time.Hour)
+		chatRepo.AddMessage(msg)
+	}
+}
+
+func TestRetentionUseCase_Set(t *testing.T) {
+	uc, chatRepo, _ := setupRetention(t)
+	addRetentionMessages(chatRepo, time.Now().Add(-10*time.Hour), 10)
+
+	set := func(policy entity.ChatRetention, isAdmin bool) error {
+		_, err := uc.Set(SetRetentionInput{RoomID: "room-1", IsAdmin: isAdmin, Policy: policy})
+		return err
+	}
+
+	assert.ErrorIs(t, set(entity.ChatRetention{Mode: entity.RetentionArchive}, false), ErrNotAllowedToSetRetention)
+	assert.ErrorIs(t, set(entity.ChatRetention{Mode: "forever"}, true), entity.ErrInvalidRetention)
+	assert.ErrorIs(t, set(entity.ChatRetention{Mode: entity.RetentionMessages}, true), entity.ErrInvalidRetention)
+	assert.ErrorIs(t, set(entity.ChatRetention{Mode: entity.RetentionDays, Days: entity.MaxRetentionDays + 1}, true), entity.ErrInvalidRetention)
+	_, err := uc.Set(SetRetentionInput{RoomID: "missing", IsAdmin: true, Policy: entity.ChatRetention{}})
+	assert.ErrorIs(t, err, ErrRoomNotFound)
+
+	// Lowering the cap trims the room right away and keeps applying to new messages
+	assert.NoError(t, set(entity.ChatRetention{Mode: entity.RetentionMessages, Messages: 4}, true))
+	messages, _ := chatRepo.GetMessages("room-1", 0)
+	assert.Len(t, messages, 4)
+	assert.Equal(t, "msg-6", messages[0].ID)
+
+	chatRepo.AddMessage(entity.NewChatMessage("msg-new", "room-1", "user-1", "Alice", "new"))
+	messages, _ = chatRepo.GetMessages("room-1", 0)
+	assert.Len(t, messages, 4)
+	assert.Equal(t, "msg-new", messages[3].ID)
+}
+
+func TestRetentionUseCase_Prune(t *testing.T) {
+	uc, chatRepo, room := setupRetention(t)
+	now := time.Now()
+	addRetentionMessages(chatRepo, now.Add(-72*time.Hour), 48)
+
+	// Ephemeral rooms are not pruned by age
+	count, err := uc.Prune(now)
+	assert.NoError(t, err)
+	assert.Zero(t, count)
+
+	room.SetRetention(entity.ChatRetention{Mode: entity.RetentionDays, Days: 2})
+	count, err = uc.Prune(now)
+	assert.NoError(t, err)
+	assert.Equal(t, 24, count)
+
+	messages, _ := chatRepo.GetMessages("room-1", 0)
+	assert.Len(t, messages, 24)
+	assert.Equal(t, "msg-24", messages[0].ID)
+}
+
+func TestRetentionUseCase_ReleaseRoom(t *testing.T) {
+	uc, chatRepo, room := setupRetention(t)
+	start := time.Date(2026, 5, 1, 18, 0, 0, 0, time.UTC)
+
+	// Ephemeral rooms are deleted without an archive
+	addRetentionMessages(chatRepo, start, 3)
+	archive, err := uc.ReleaseRoom(room, start.Add(time.Hour))
+	assert.NoError(t, err)
+	assert.Nil(t, archive)
+	messages, _ := chatRepo.GetMessages("room-1", 0)
+	assert.Empty(t, messages)
+
+	room.SetRetention(entity.ChatRetention{Mode: entity.RetentionArchive})
+	addRetentionMessages(chatRepo, start, 5)
+	archive, err = uc.ReleaseRoom(room, start.Add(6*time.Hour))
+	assert.NoError(t, err)
+	assert.Equal(t, 5, archive.MessageCount)
+	assert.Equal(t, "Finals", archive.RoomName)
+	assert.Equal(t, start, archive.FirstMessageAt)
+	assert.Positive(t, archive.Size)
+	messages, _ = chatRepo.GetMessages("room-1", 0)
+	assert.Empty(t, messages)
+
+	// An empty room leaves no archive behind
+	archive, err = uc.ReleaseRoom(room, start.Add(7*time.Hour))
+	assert.NoError(t, err)
+	assert.Nil(t, archive)
+
+	_, err = uc.ListArchives(false)
+	assert.ErrorIs(t, err, ErrNotAllowedToExport)
+	archives, err := uc.ListArchives(true)
+	assert.NoError(t, err)
+	assert.Len(t, archives, 1)
+
+	_, err = uc.GetArchive("missing", ExportFormatText, true)
+	assert.ErrorIs(t, err, ErrArchiveNotFound)
+	_, err = uc.GetArchive(archives[0].ID, ExportFormatText, false)
+	assert.ErrorIs(t, err, ErrNotAllowedToExport)
+
+	export, err := uc.GetArchive(archives[0].ID, ExportFormatText, true)
+	assert.NoError(t, err)
+	assert.Len(t, export.Messages, 5)
+	var buf bytes.Buffer
+	assert.NoError(t, export.Write(&buf))
+	assert.Contains(t, buf.String(), "Chat export: Finals (room-1)")
+	assert.Contains(t, buf.String(), "[2026-05-01 22:00:00 UTC] Alice: message 4")
+}
+
+func TestRetentionUseCase_SearchIndex(t *testing.T) {
+	chatRepo := persistence.NewInMemoryChatRepository()
+	roomRepo := persistence.NewInMemoryRoomRepository()
+	searchRepo := persistence.NewInMemoryChatSearchRepository()
+	room := entity.NewRoom("room-1", "Finals", entity.RoomTypePublic, "owner")
+	roomRepo.Create(room)
+	uc := NewRetentionUseCase(chatRepo, roomRepo, searchRepo, nil)
+
+	now := time.Now()
+	addRetentionMessages(chatRepo, now.Add(-10*time.Hour), 10)
+	messages, _ := chatRepo.GetMessages("room-1", 0)
+	for _, msg := range messages {
+		searchRepo.Index(msg, room.Name)
+	}
+	search := func() int {
+		results, err := searchRepo.Search(repository.ChatSearchQuery{Text: "message", RoomID: "room-1"})
+		assert.NoError(t, err)
+		return len(results)
+	}
+
+	// Messages trimmed by the cap stop being searchable
+	_, err := uc.Set(SetRetentionInput{RoomID: "room-1", IsAdmin: true, Policy: entity.ChatRetention{Mode: entity.RetentionMessages, Messages: 6}})
+	assert.NoError(t, err)
+	assert.Equal(t, 6, search())
+
+	// So do messages pruned by age
+	room.SetRetention(entity.ChatRetention{Mode: entity.RetentionDays, Days: 1})
+	_, err = uc.Prune(now.Add(20 * time.Hour))
+	assert.NoError(t, err)
+	assert.Equal(t, 4, search())
+
+	// And everything left once the room is released
+	_, err = uc.ReleaseRoom(room, now)
+	assert.NoError(t, err)
+	assert.Zero(t, search())
+}
+
+func TestFileChatArchiveRepository_Reload(t *testing.T) {
+	dir := t.TempDir()
+	repo, err := persistence.NewFileChatArchiveRepository(dir)
+	assert.NoError(t, err)
+
+	msg := entity.NewChatMessage("msg-1", "room-1", "user-1", "Alice", "gg")
+	msg.AddReaction("🎉", "user-2")
+	assert.NoError(t, repo.Save(&entity.ChatArchive{ID: "archive-1", RoomID: "room-1", MessageCount: 1}, []*entity.ChatMessage{msg}))
+
+	reloaded, err := persistence.NewFileChatArchiveRepository(dir)
+	assert.NoError(t, err)
+	archive, messages, err := reloaded.Get("archive-1")
+	assert.NoError(t, err)
+	assert.Equal(t, "room-1", archive.RoomID)
+	assert.Len(t, messages, 1)
+	assert.Equal(t, []string{"user-2"}, messages[0].Reactions["🎉"])
+}
@@ -0,0 +1,67 @@
+package chat
+
+import (
+	"sync"
+	"time"
+)
+
+// MaxRoomMute is the longest a moderator can mute someone's chat for
+const MaxRoomMute = time.Hour
+
+// RoomMuteUseCase keeps track of users a moderator has muted in a room's chat
+type RoomMuteUseCase struct {
+	mutes map[string]time.Time // roomID:userID -> muted until
+	mu    sync.Mutex
+}
+
+// NewRoomMuteUseCase creates a new RoomMuteUseCase
+func NewRoomMuteUseCase() *RoomMuteUseCase {
+	return &RoomMuteUseCase{
+		mutes: make(map[string]time.Time),
+	}
+}
+
+// Mute stops a user chatting in a room until the given time
+func (uc *RoomMuteUseCase) Mute(roomID, userID string, until time.Time) {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	uc.mutes[roomID+":"+userID] = until
+}
+
+// Unmute lifts a mute, reporting whether there was one
+func (uc *RoomMuteUseCase) Unmute(roomID, userID string, now time.Time) bool {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	key := roomID + ":" + userID
+	until, exists := uc.mutes[key]
+	delete(uc.mutes, key)
+	return exists && now.Before(until)
+}
+
+// Remaining returns how long the user stays muted in the room (0 if not muted)
+func (uc *RoomMuteUseCase) Remaining(roomID, userID string, now time.Time) time.Duration {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	if until, exists := uc.mutes[roomID+":"+userID]; exists && now.Before(until) {
+		return until.Sub(now)
+	}
+	return 0
+}
+
+// Cleanup drops expired mutes and returns how many were removed
+func (uc *RoomMuteUseCase) Cleanup(now time.Time) int {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	removed := 0
+	for key, until := range uc.mutes {
+		if !now.Before(until) {
+			delete(uc.mutes, key)
+			removed++
+		}
+	}
+	return removed
+}
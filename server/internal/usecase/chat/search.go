@@ -0,0 +1,59 @@
+package chat
+
+import (
+	"errors"
+
+	"voice-chat/internal/domain/repository"
+)
+
+var (
+	ErrEmptySearch    = errors.New("enter at least one word to search for")
+	ErrUnscopedSearch = errors.New("search needs words, a room or a sender")
+)
+
+// SearchUseCase handles full-text search over chat history
+type SearchUseCase struct {
+	searchRepo repository.ChatSearchRepository
+}
+
+// NewSearchUseCase creates a new SearchUseCase
+func NewSearchUseCase(searchRepo repository.ChatSearchRepository) *SearchUseCase {
+	return &SearchUseCase{
+		searchRepo: searchRepo,
+	}
+}
+
+// SearchRoom finds messages in a single room containing every word of text
+func (uc *SearchUseCase) SearchRoom(roomID, text string, limit int) ([]*repository.ChatSearchResult, error) {
+	if len(repository.SearchTerms(text)) == 0 {
+		return nil, ErrEmptySearch
+	}
+
+	return uc.searchRepo.Search(repository.ChatSearchQuery{
+		Text:   text,
+		RoomID: roomID,
+		Limit:  clampSearchLimit(limit),
+	})
+}
+
+// SearchAll finds messages across every room for admins. Without words it lists messages
+// matching the room and sender filters, so at least one of them is required.
+func (uc *SearchUseCase) SearchAll(query repository.ChatSearchQuery) ([]*repository.ChatSearchResult, error) {
+	scoped := query.RoomID != "" || query.RoomName != "" || query.SenderID != "" || query.SenderName != ""
+	if len(repository.SearchTerms(query.Text)) == 0 && !scoped {
+		return nil, ErrUnscopedSearch
+	}
+
+	query.Limit = clampSearchLimit(query.Limit)
+	return uc.searchRepo.Search(query)
+}
+
+func clampSearchLimit(limit int) int {
+	if limit <= 0 {
+		return repository.DefaultChatSearchLimit
+	}
+	if limit > repository.MaxChatSearchLimit {
+		return repository.MaxChatSearchLimit
+	}
+	return limit
+}
@@ -0,0 +1,118 @@
+package chat
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"voice-chat/internal/domain/entity"
+	"voice-chat/internal/domain/repository"
+	"voice-chat/internal/infrastructure/persistence"
+)
+
+func newSearchFixture(t *testing.T) (*SearchUseCase, *SendMessageUseCase, *persistence.InMemoryChatRepository, repository.ChatSearchRepository) {
+	t.Helper()
+
+	roomRepo := persistence.NewInMemoryRoomRepository()
+	roomRepo.Create(entity.NewRoom("room-1", "Lobby", entity.RoomTypePublic, "owner-1"))
+	roomRepo.Create(entity.NewRoom("room-2", "Gaming", entity.RoomTypePublic, "owner-2"))
+
+	chatRepo := persistence.NewInMemoryChatRepository()
+	searchRepo := persistence.NewInMemoryChatSearchRepository()
+	return NewSearchUseCase(searchRepo), NewSendMessageUseCase(chatRepo, roomRepo, searchRepo, nil), chatRepo, searchRepo
+}
+
+func sendTo(t *testing.T, uc *SendMessageUseCase, roomID, userID, userName, content string) *entity.ChatMessage {
+	t.Helper()
+
+	out, err := uc.Execute(SendMessageInput{RoomID: roomID, UserID: userID, UserName: userName, Content: content})
+	assert.NoError(t, err)
+	return out.Message
+}
+
+func TestSearchUseCase_SearchRoom(t *testing.T) {
+	uc, send, _, _ := newSearchFixture(t)
+	sendTo(t, send, "room-1", "user-1", "Alice", "Anyone up for pizza tonight?")
+	sendTo(t, send, "room-1", "user-2", "Bob", "Pizza sounds great!")
+	sendTo(t, send, "room-2", "user-3", "Carol", "pizza in the other room")
+
+	results, err := uc.SearchRoom("room-1", "PIZZA", 0)
+
+	assert.NoError(t, err)
+	assert.Len(t, results, 2)
+	assert.Equal(t, "Bob", results[0].Message.SenderName) // Newest first
+	assert.Equal(t, "Lobby", results[0].RoomName)
+
+	results, _ = uc.SearchRoom("room-1", "pizza tonight", 0)
+	assert.Len(t, results, 1)
+	assert.Equal(t, "Alice", results[0].Message.SenderName)
+}
+
+func TestSearchUseCase_SearchRoomEmpty(t *testing.T) {
+	uc, _, _, _ := newSearchFixture(t)
+
+	_, err := uc.SearchRoom("room-1", " ! ", 0)
+
+	assert.ErrorIs(t, err, ErrEmptySearch)
+}
+
+func TestSearchUseCase_SearchAllFilters(t *testing.T) {
+	uc, send, _, _ := newSearchFixture(t)
+	before := time.Now()
+	sendTo(t, send, "room-1", "user-1", "Alice", "the match starts soon")
+	sendTo(t, send, "room-2", "user-2", "Bob", "who won the match?")
+	sendTo(t, send, "room-2", "user-1", "Alice", "I did")
+
+	results, err := uc.SearchAll(repository.ChatSearchQuery{Text: "match"})
+	assert.NoError(t, err)
+	assert.Len(t, results, 2)
+
+	results, _ = uc.SearchAll(repository.ChatSearchQuery{Text: "match", RoomName: "gaming"})
+	assert.Len(t, results, 1)
+	assert.Equal(t, "Bob", results[0].Message.SenderName)
+
+	// Sender filter alone lists everything they said
+	results, _ = uc.SearchAll(repository.ChatSearchQuery{SenderName: "alice"})
+	assert.Len(t, results, 2)
+
+	results, _ = uc.SearchAll(repository.ChatSearchQuery{SenderName: "alice", Until: before})
+	assert.Empty(t, results)
+
+	_, err = uc.SearchAll(repository.ChatSearchQuery{Since: before})
+	assert.ErrorIs(t, err, ErrUnscopedSearch)
+}
+
+func TestSearchUseCase_EditAndDeleteUpdateIndex(t *testing.T) {
+	uc, send, chatRepo, searchRepo := newSearchFixture(t)
+	msg := sendTo(t, send, "room-1", "user-1", "Alice", "meet at the cafe")
+
+	edit := NewEditMessageUseCase(chatRepo, searchRepo, nil, 15*time.Minute)
+	_, err := edit.Execute(EditMessageInput{RoomID: "room-1", MessageID: msg.ID, UserID: "user-1", Content: "meet at the park"})
+	assert.NoError(t, err)
+
+	results, _ := uc.SearchRoom("room-1", "cafe", 0)
+	assert.Empty(t, results)
+	results, _ = uc.SearchRoom("room-1", "park", 0)
+	assert.Len(t, results, 1)
+	assert.Equal(t, "Lobby", results[0].RoomName) // Kept across the re-index
+
+	del := NewDeleteMessageUseCase(chatRepo, persistence.NewInMemoryRoomRepository(), searchRepo, nil, nil)
+	_, err = del.Execute(DeleteMessageInput{RoomID: "room-1", MessageID: msg.ID, UserID: "user-1"})
+	assert.NoError(t, err)
+
+	results, _ = uc.SearchRoom("room-1", "park", 0)
+	assert.Empty(t, results)
+}
+
+func TestSearchUseCase_Prune(t *testing.T) {
+	uc, send, _, searchRepo := newSearchFixture(t)
+	sendTo(t, send, "room-1", "user-1", "Alice", "old news")
+
+	count, err := searchRepo.Prune(time.Now().Add(time.Second))
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+	results, _ := uc.SearchRoom("room-1", "news", 0)
+	assert.Empty(t, results)
+}
@@ -0,0 +1,225 @@
+package chat
+
+import (
+	"errors"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"voice-chat/internal/domain/entity"
+	"voice-chat/internal/domain/repository"
+	"voice-chat/internal/usecase/moderation"
+)
+
+// HereMentionCooldown is how often a moderator may ping a room with @here
+const HereMentionCooldown = time.Minute
+
+var (
+	ErrParentNotFound         = errors.New("message being replied to was not found")
+	ErrHereMentionRateLimited = errors.New("@here was used too recently, try again later")
+)
+
+// SendMessageInput represents the input for sending a chat message
+type SendMessageInput struct {
+	RoomID   string
+	UserID   string
+	UserName string
+	Content  string
+	ReplyTo  string // Optional parent message ID
+	IsAdmin  bool
+	IP       string
+
+	Attachments []*entity.Attachment // Claimed uploads; the content may be empty when there are any
+}
+
+// SendMessageOutput represents the output after sending a chat message
+type SendMessageOutput struct {
+	Message *entity.ChatMessage
+	Parent  *entity.ChatMessage // Thread root with its updated reply count, nil for top-level messages
+	Notify  []string            // User IDs to send a mention notification to (never the sender)
+	Review  *moderation.Result  // Moderation outcome, nil when moderation is disabled
+}
+
+// SendMessageUseCase handles posting chat messages to a room
+type SendMessageUseCase struct {
+	chatRepo     repository.ChatRepository
+	roomRepo     repository.RoomRepository
+	searchRepo   repository.ChatSearchRepository
+	moderationUC *moderation.ModerationUseCase
+	lastHere     map[string]time.Time // roomID:userID -> last @here
+	mu           sync.Mutex
+}
+
+// NewSendMessageUseCase creates a new SendMessageUseCase
+func NewSendMessageUseCase(
+	chatRepo repository.ChatRepository,
+	roomRepo repository.RoomRepository,
+	searchRepo repository.ChatSearchRepository,
+	moderationUC *moderation.ModerationUseCase,
+) *SendMessageUseCase {
+	return &SendMessageUseCase{
+		chatRepo:     chatRepo,
+		roomRepo:     roomRepo,
+		searchRepo:   searchRepo,
+		moderationUC: moderationUC,
+		lastHere:     make(map[string]time.Time),
+	}
+}
+
+// Execute stores a chat message, attaching it to a thread when it is a reply
+func (uc *SendMessageUseCase) Execute(input SendMessageInput) (*SendMessageOutput, error) {
+	if strings.TrimSpace(input.Content) == "" && len(input.Attachments) == 0 {
+		return nil, ErrEmptyMessage
+	}
+
+	messageID := uuid.New().String()
+
+	// Moderation may rewrite the content, so it runs before anything reads it
+	var review *moderation.Result
+	if uc.moderationUC != nil && input.Content != "" {
+		var err error
+		review, err = uc.moderationUC.Review(moderation.ReviewInput{
+			RoomID:    input.RoomID,
+			MessageID: messageID,
+			UserID:    input.UserID,
+			UserName:  input.UserName,
+			IP:        input.IP,
+			Content:   input.Content,
+		})
+		if err != nil {
+			return nil, err
+		}
+		input.Content = review.Content
+	}
+
+	msg := entity.NewChatMessage(
+		messageID,
+		input.RoomID,
+		input.UserID,
+		input.UserName,
+		input.Content,
+	)
+	msg.Attachments = input.Attachments
+
+	var parent *entity.ChatMessage
+	if input.ReplyTo != "" {
+		var err error
+		parent, err = uc.chatRepo.GetMessage(input.RoomID, input.ReplyTo)
+		if err != nil || parent.IsDeleted() || parent.Type == entity.ChatMessageTypeSystem {
+			return nil, ErrParentNotFound
+		}
+
+		// Threads are one level deep: replying to a reply joins the root's thread
+		if parent.IsReply() {
+			quoted := parent
+			parent, err = uc.chatRepo.GetMessage(input.RoomID, parent.ReplyTo)
+			if err != nil {
+				return nil, ErrParentNotFound
+			}
+			msg.Quote = entity.NewChatQuote(quoted)
+		} else {
+			msg.Quote = entity.NewChatQuote(parent)
+		}
+		msg.ReplyTo = parent.ID
+	}
+
+	notify, err := uc.resolveMentions(msg, input)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.chatRepo.AddMessage(msg); err != nil {
+		return nil, err
+	}
+	uc.index(msg)
+
+	output := &SendMessageOutput{Message: msg, Notify: notify, Review: review}
+	if parent != nil {
+		// Re-read so the reply count reflects the repository's index
+		if updated, err := uc.chatRepo.GetMessage(input.RoomID, parent.ID); err == nil {
+			output.Parent = updated
+		}
+	}
+
+	return output, nil
+}
+
+// index adds a new message to the search index; search is best effort and never fails a send
+func (uc *SendMessageUseCase) index(msg *entity.ChatMessage) {
+	if uc.searchRepo == nil {
+		return
+	}
+
+	roomName := ""
+	if room, err := uc.roomRepo.GetByID(msg.RoomID); err == nil && room != nil {
+		roomName = room.Name
+	}
+	if err := uc.searchRepo.Index(msg, roomName); err != nil {
+		log.Printf("Error indexing chat message %s: %v", msg.ID, err)
+	}
+}
+
+// resolveMentions stores the mentioned user IDs on the message and returns who to notify
+func (uc *SendMessageUseCase) resolveMentions(msg *entity.ChatMessage, input SendMessageInput) ([]string, error) {
+	room, err := uc.roomRepo.GetByID(input.RoomID)
+	if err != nil || room == nil {
+		return nil, nil
+	}
+
+	// Stealth participants can't be mentioned
+	participants := make([]*entity.User, 0)
+	for _, p := range room.GetAllParticipants() {
+		if !p.IsStealth || p.ID == input.UserID {
+			participants = append(participants, p)
+		}
+	}
+
+	mentioned, here := ParseMentions(input.Content, participants)
+	here = here && IsModerator(uc.roomRepo, input.RoomID, input.UserID, input.IsAdmin)
+	if here && !uc.allowHere(input.RoomID, input.UserID) {
+		return nil, ErrHereMentionRateLimited
+	}
+
+	msg.Mentions = mentioned
+	msg.AtHere = here
+
+	notify := make([]string, 0, len(mentioned))
+	if here {
+		mentioned = make([]string, 0, len(participants))
+		for _, p := range participants {
+			mentioned = append(mentioned, p.ID)
+		}
+	}
+	for _, userID := range mentioned {
+		if userID != input.UserID {
+			notify = append(notify, userID)
+		}
+	}
+
+	return notify, nil
+}
+
+// allowHere records an @here and reports whether it is outside the cooldown
+func (uc *SendMessageUseCase) allowHere(roomID, userID string) bool {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	key := roomID + ":" + userID
+	now := time.Now()
+	if last, ok := uc.lastHere[key]; ok && now.Sub(last) < HereMentionCooldown {
+		return false
+	}
+
+	// Drop stale entries so the map doesn't grow forever
+	for k, last := range uc.lastHere {
+		if now.Sub(last) >= HereMentionCooldown {
+			delete(uc.lastHere, k)
+		}
+	}
+
+	uc.lastHere[key] = now
+	return true
+}
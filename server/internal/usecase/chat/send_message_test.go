@@ -0,0 +1,123 @@
+package chat
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"voice-chat/internal/domain/entity"
+	"voice-chat/internal/infrastructure/persistence"
+	"voice-chat/internal/usecase/moderation"
+)
+
+func TestSendMessageUseCase_TopLevel(t *testing.T) {
+	chatRepo := persistence.NewInMemoryChatRepository()
+	uc := NewSendMessageUseCase(chatRepo, persistence.NewInMemoryRoomRepository(), nil, nil)
+
+	result, err := uc.Execute(SendMessageInput{RoomID: "room-1", UserID: "user-1", UserName: "Alice", Content: "hi"})
+
+	assert.NoError(t, err)
+	assert.Nil(t, result.Parent)
+	assert.False(t, result.Message.IsReply())
+
+	_, err = uc.Execute(SendMessageInput{RoomID: "room-1", UserID: "user-1", UserName: "Alice", Content: " "})
+	assert.ErrorIs(t, err, ErrEmptyMessage)
+}
+
+func TestSendMessageUseCase_AttachmentOnly(t *testing.T) {
+	chatRepo := persistence.NewInMemoryChatRepository()
+	uc := NewSendMessageUseCase(chatRepo, persistence.NewInMemoryRoomRepository(), nil, nil)
+	att := &entity.Attachment{ID: "att-1", RoomID: "room-1", UploaderID: "user-1", ContentType: "image/png"}
+
+	result, err := uc.Execute(SendMessageInput{RoomID: "room-1", UserID: "user-1", UserName: "Alice", Attachments: []*entity.Attachment{att}})
+
+	assert.NoError(t, err)
+	stored, _ := chatRepo.GetMessage("room-1", result.Message.ID)
+	assert.Equal(t, []*entity.Attachment{att}, stored.Attachments)
+
+	// Deleting the message drops its attachments
+	del := NewDeleteMessageUseCase(chatRepo, persistence.NewInMemoryRoomRepository(), nil, nil, nil)
+	deleted, err := del.Execute(DeleteMessageInput{RoomID: "room-1", MessageID: result.Message.ID, UserID: "user-1"})
+	assert.NoError(t, err)
+	assert.Empty(t, deleted.Attachments)
+}
+
+func TestSendMessageUseCase_Moderation(t *testing.T) {
+	chatRepo := persistence.NewInMemoryChatRepository()
+	roomRepo := persistence.NewInMemoryRoomRepository()
+	moderationUC := moderation.NewModerationUseCase(&entity.ModerationConfig{
+		Default: entity.ModerationPolicy{
+			MaxLength: &entity.MaxLengthRule{Action: entity.ModerationActionBlock, Limit: 20},
+			Profanity: &entity.ProfanityRule{Action: entity.ModerationActionMask, Words: []string{"darn"}},
+		},
+	}, roomRepo, nil)
+	uc := NewSendMessageUseCase(chatRepo, roomRepo, nil, moderationUC)
+
+	result, err := uc.Execute(SendMessageInput{RoomID: "room-1", UserID: "user-1", Content: "d4rn it"})
+	assert.NoError(t, err)
+	assert.Equal(t, "**** it", result.Message.Content)
+
+	_, err = uc.Execute(SendMessageInput{RoomID: "room-1", UserID: "user-1", Content: strings.Repeat("a", 21)})
+	assert.ErrorIs(t, err, moderation.ErrMessageBlocked)
+
+	messages, _ := chatRepo.GetMessages("room-1", 10)
+	assert.Len(t, messages, 1)
+}
+
+func TestSendMessageUseCase_Reply(t *testing.T) {
+	chatRepo := persistence.NewInMemoryChatRepository()
+	chatRepo.AddMessage(entity.NewChatMessage("root", "room-1", "user-1", "Alice", strings.Repeat("a", 150)))
+	uc := NewSendMessageUseCase(chatRepo, persistence.NewInMemoryRoomRepository(), nil, nil)
+
+	first, err := uc.Execute(SendMessageInput{RoomID: "room-1", UserID: "user-2", UserName: "Bob", Content: "agreed", ReplyTo: "root"})
+	assert.NoError(t, err)
+	assert.Equal(t, "root", first.Message.ReplyTo)
+	assert.Equal(t, "Alice", first.Message.Quote.SenderName)
+	assert.Equal(t, entity.QuoteSnippetLength, len([]rune(first.Message.Quote.Snippet)))
+	assert.Equal(t, 1, first.Parent.ReplyCount)
+
+	// Replying to a reply stays in the root's thread but quotes the reply
+	second, err := uc.Execute(SendMessageInput{RoomID: "room-1", UserID: "user-1", UserName: "Alice", Content: "thanks", ReplyTo: first.Message.ID})
+	assert.NoError(t, err)
+	assert.Equal(t, "root", second.Message.ReplyTo)
+	assert.Equal(t, first.Message.ID, second.Message.Quote.MessageID)
+	assert.Equal(t, 2, second.Parent.ReplyCount)
+
+	replies, err := chatRepo.GetReplies("room-1", "root", 0)
+	assert.NoError(t, err)
+	assert.Len(t, replies, 2)
+	assert.Equal(t, first.Message.ID, replies[0].ID)
+}
+
+func TestSendMessageUseCase_ReplyToMissingParent(t *testing.T) {
+	chatRepo := persistence.NewInMemoryChatRepository()
+	deleted := entity.NewChatMessage("gone", "room-1", "user-1", "Alice", "oops")
+	deleted.Delete("user-1", time.Now())
+	chatRepo.AddMessage(deleted)
+	uc := NewSendMessageUseCase(chatRepo, persistence.NewInMemoryRoomRepository(), nil, nil)
+
+	_, err := uc.Execute(SendMessageInput{RoomID: "room-1", UserID: "user-2", UserName: "Bob", Content: "?", ReplyTo: "missing"})
+	assert.ErrorIs(t, err, ErrParentNotFound)
+
+	_, err = uc.Execute(SendMessageInput{RoomID: "room-1", UserID: "user-2", UserName: "Bob", Content: "?", ReplyTo: "gone"})
+	assert.ErrorIs(t, err, ErrParentNotFound)
+}
+
+func TestDeleteMessageUseCase_ClearsQuotes(t *testing.T) {
+	chatRepo := persistence.NewInMemoryChatRepository()
+	roomRepo := persistence.NewInMemoryRoomRepository()
+	chatRepo.AddMessage(entity.NewChatMessage("root", "room-1", "user-1", "Alice", "secret"))
+	reply, _ := NewSendMessageUseCase(chatRepo, persistence.NewInMemoryRoomRepository(), nil, nil).Execute(SendMessageInput{
+		RoomID: "room-1", UserID: "user-2", UserName: "Bob", Content: "lol", ReplyTo: "root",
+	})
+	uc := NewDeleteMessageUseCase(chatRepo, roomRepo, nil, nil, nil)
+
+	_, err := uc.Execute(DeleteMessageInput{RoomID: "room-1", MessageID: "root", UserID: "user-1"})
+
+	assert.NoError(t, err)
+	stored, _ := chatRepo.GetMessage("room-1", reply.Message.ID)
+	assert.True(t, stored.Quote.Deleted)
+	assert.Empty(t, stored.Quote.Snippet)
+}
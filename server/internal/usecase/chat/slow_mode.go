@@ -0,0 +1,116 @@
+package chat
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"voice-chat/internal/domain/entity"
+	"voice-chat/internal/domain/repository"
+)
+
+// MaxSlowMode is the longest cooldown a room can set
+const MaxSlowMode = time.Hour
+
+var (
+	ErrInvalidSlowMode         = errors.New("slow mode must be between 0 seconds and 1 hour")
+	ErrNotAllowedToSetSlowMode = errors.New("only the room owner or an admin can set slow mode")
+	ErrRoomNotFound            = errors.New("room not found")
+)
+
+// SetSlowModeInput represents the input for changing a room's slow mode
+type SetSlowModeInput struct {
+	RoomID   string
+	UserID   string
+	IsAdmin  bool
+	Cooldown time.Duration // 0 turns slow mode off
+}
+
+// SlowModeUseCase enforces a per-room minimum time between a member's chat messages.
+// Room owners and admins set it and are never slowed down themselves.
+type SlowModeUseCase struct {
+	roomRepo repository.RoomRepository
+	lastSent map[string]time.Time // roomID:userID -> last message
+	mu       sync.Mutex
+}
+
+// NewSlowModeUseCase creates a new SlowModeUseCase
+func NewSlowModeUseCase(roomRepo repository.RoomRepository) *SlowModeUseCase {
+	return &SlowModeUseCase{
+		roomRepo: roomRepo,
+		lastSent: make(map[string]time.Time),
+	}
+}
+
+// Set changes a room's slow mode
+func (uc *SlowModeUseCase) Set(input SetSlowModeInput) (*entity.Room, error) {
+	if input.Cooldown < 0 || input.Cooldown > MaxSlowMode {
+		return nil, ErrInvalidSlowMode
+	}
+
+	room, err := uc.roomRepo.GetByID(input.RoomID)
+	if err != nil || room == nil {
+		return nil, ErrRoomNotFound
+	}
+	if !IsModerator(uc.roomRepo, input.RoomID, input.UserID, input.IsAdmin) {
+		return nil, ErrNotAllowedToSetSlowMode
+	}
+
+	room.SetSlowMode(input.Cooldown.Truncate(time.Second))
+	if err := uc.roomRepo.Update(room); err != nil {
+		return nil, err
+	}
+	return room, nil
+}
+
+// Remaining returns how long the user must wait before chatting in the room again
+func (uc *SlowModeUseCase) Remaining(roomID, userID string, isAdmin bool, now time.Time) time.Duration {
+	room, err := uc.roomRepo.GetByID(roomID)
+	if err != nil || room == nil {
+		return 0
+	}
+
+	cooldown := room.GetSlowMode()
+	if cooldown <= 0 || isAdmin || room.CreatedBy == userID {
+		return 0
+	}
+
+	uc.mu.Lock()
+	last, ok := uc.lastSent[roomID+":"+userID]
+	uc.mu.Unlock()
+	if !ok {
+		return 0
+	}
+
+	if wait := cooldown - now.Sub(last); wait > 0 {
+		return wait
+	}
+	return 0
+}
+
+// Record starts the user's cooldown after a message was sent
+func (uc *SlowModeUseCase) Record(roomID, userID string, now time.Time) {
+	room, err := uc.roomRepo.GetByID(roomID)
+	if err != nil || room == nil || room.GetSlowMode() <= 0 {
+		return
+	}
+
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+	uc.lastSent[roomID+":"+userID] = now
+}
+
+// Cleanup drops cooldowns that can no longer apply and returns how many were removed
+func (uc *SlowModeUseCase) Cleanup(now time.Time) int {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	removed := 0
+	for key, last := range uc.lastSent {
+		if now.Sub(last) >= MaxSlowMode {
+			delete(uc.lastSent, key)
+			removed++
+		}
+	}
+	return removed
+}
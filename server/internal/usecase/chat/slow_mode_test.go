@@ -0,0 +1,60 @@
+package chat
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"voice-chat/internal/domain/entity"
+	"voice-chat/internal/infrastructure/persistence"
+)
+
+func TestSlowModeUseCase_Set(t *testing.T) {
+	roomRepo := persistence.NewInMemoryRoomRepository()
+	roomRepo.Create(entity.NewRoom("room-1", "Room", entity.RoomTypePublic, "owner"))
+	uc := NewSlowModeUseCase(roomRepo)
+
+	_, err := uc.Set(SetSlowModeInput{RoomID: "room-1", UserID: "someone", Cooldown: 10 * time.Second})
+	assert.ErrorIs(t, err, ErrNotAllowedToSetSlowMode)
+
+	_, err = uc.Set(SetSlowModeInput{RoomID: "room-1", UserID: "owner", Cooldown: 2 * time.Hour})
+	assert.ErrorIs(t, err, ErrInvalidSlowMode)
+
+	_, err = uc.Set(SetSlowModeInput{RoomID: "missing", UserID: "owner", IsAdmin: true, Cooldown: time.Second})
+	assert.ErrorIs(t, err, ErrRoomNotFound)
+
+	room, err := uc.Set(SetSlowModeInput{RoomID: "room-1", UserID: "owner", Cooldown: 10 * time.Second})
+	assert.NoError(t, err)
+	assert.Equal(t, 10*time.Second, room.GetSlowMode())
+
+	room, err = uc.Set(SetSlowModeInput{RoomID: "room-1", UserID: "admin", IsAdmin: true})
+	assert.NoError(t, err)
+	assert.Zero(t, room.GetSlowMode())
+}
+
+func TestSlowModeUseCase_Remaining(t *testing.T) {
+	roomRepo := persistence.NewInMemoryRoomRepository()
+	roomRepo.Create(entity.NewRoom("room-1", "Room", entity.RoomTypePublic, "owner"))
+	uc := NewSlowModeUseCase(roomRepo)
+	now := time.Now()
+
+	// Nothing is tracked while slow mode is off
+	uc.Record("room-1", "user-1", now)
+	assert.Zero(t, uc.Remaining("room-1", "user-1", false, now))
+
+	uc.Set(SetSlowModeInput{RoomID: "room-1", UserID: "owner", Cooldown: 10 * time.Second})
+	uc.Record("room-1", "user-1", now)
+	assert.Equal(t, 10*time.Second, uc.Remaining("room-1", "user-1", false, now))
+	assert.Equal(t, 4*time.Second, uc.Remaining("room-1", "user-1", false, now.Add(6*time.Second)))
+	assert.Zero(t, uc.Remaining("room-1", "user-1", false, now.Add(10*time.Second)))
+	assert.Zero(t, uc.Remaining("room-1", "user-2", false, now))
+
+	// Moderators are never slowed down
+	uc.Record("room-1", "owner", now)
+	assert.Zero(t, uc.Remaining("room-1", "owner", false, now))
+	uc.Record("room-1", "admin", now)
+	assert.Zero(t, uc.Remaining("room-1", "admin", true, now))
+
+	assert.Equal(t, 3, uc.Cleanup(now.Add(MaxSlowMode)))
+}
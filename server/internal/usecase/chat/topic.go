@@ -0,0 +1,55 @@
+package chat
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"voice-chat/internal/domain/entity"
+	"voice-chat/internal/domain/repository"
+)
+
+var (
+	ErrTopicTooLong         = fmt.Errorf("topic is limited to %d characters", entity.MaxTopicLength)
+	ErrNotAllowedToSetTopic = errors.New("only the room owner or an admin can set the topic")
+)
+
+// SetTopicInput represents the input for changing a room's topic
+type SetTopicInput struct {
+	RoomID  string
+	UserID  string
+	IsAdmin bool
+	Topic   string // Empty clears the topic
+}
+
+// TopicUseCase lets room owners and admins set the topic shown at the top of their room
+type TopicUseCase struct {
+	roomRepo repository.RoomRepository
+}
+
+// NewTopicUseCase creates a new TopicUseCase
+func NewTopicUseCase(roomRepo repository.RoomRepository) *TopicUseCase {
+	return &TopicUseCase{roomRepo: roomRepo}
+}
+
+// Set changes a room's topic
+func (uc *TopicUseCase) Set(input SetTopicInput) (*entity.Room, error) {
+	topic := strings.TrimSpace(input.Topic)
+	if len([]rune(topic)) > entity.MaxTopicLength {
+		return nil, ErrTopicTooLong
+	}
+
+	room, err := uc.roomRepo.GetByID(input.RoomID)
+	if err != nil || room == nil {
+		return nil, ErrRoomNotFound
+	}
+	if !IsModerator(uc.roomRepo, input.RoomID, input.UserID, input.IsAdmin) {
+		return nil, ErrNotAllowedToSetTopic
+	}
+
+	room.SetTopic(topic)
+	if err := uc.roomRepo.Update(room); err != nil {
+		return nil, err
+	}
+	return room, nil
+}
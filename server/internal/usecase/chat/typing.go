@@ -0,0 +1,112 @@
+package chat
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	TypingThrottle = 3 * time.Second // Minimum gap between typing broadcasts for one user
+	TypingTimeout  = 6 * time.Second // Typing state expires unless refreshed within this time
+)
+
+// TypingChange is a typing state change that should be broadcast to a room
+type TypingChange struct {
+	RoomID   string
+	UserID   string
+	UserName string
+	Typing   bool
+}
+
+// typingState is one user's typing state in a room
+type typingState struct {
+	userName      string
+	lastBroadcast time.Time
+	expiresAt     time.Time
+}
+
+// TypingUseCase throttles typing indicators and expires them when clients stop refreshing
+type TypingUseCase struct {
+	typing map[string]map[string]*typingState // roomID -> userID -> state
+	mu     sync.Mutex
+}
+
+// NewTypingUseCase creates a new TypingUseCase
+func NewTypingUseCase() *TypingUseCase {
+	return &TypingUseCase{
+		typing: make(map[string]map[string]*typingState),
+	}
+}
+
+// Start records that a user is typing and reports whether the room should be told.
+// Repeated calls within TypingThrottle only extend the expiry.
+func (uc *TypingUseCase) Start(roomID, userID, userName string, now time.Time) bool {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	users, exists := uc.typing[roomID]
+	if !exists {
+		users = make(map[string]*typingState)
+		uc.typing[roomID] = users
+	}
+
+	state, exists := users[userID]
+	if !exists {
+		state = &typingState{}
+		users[userID] = state
+	}
+	state.userName = userName
+	state.expiresAt = now.Add(TypingTimeout)
+
+	if exists && now.Sub(state.lastBroadcast) < TypingThrottle {
+		return false
+	}
+	state.lastBroadcast = now
+	return true
+}
+
+// Stop clears a user's typing state and reports whether they were typing
+func (uc *TypingUseCase) Stop(roomID, userID string) bool {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	users, exists := uc.typing[roomID]
+	if !exists {
+		return false
+	}
+	if _, exists := users[userID]; !exists {
+		return false
+	}
+
+	delete(users, userID)
+	if len(users) == 0 {
+		delete(uc.typing, roomID)
+	}
+	return true
+}
+
+// Expire clears typing states that were not refreshed in time and returns them
+func (uc *TypingUseCase) Expire(now time.Time) []TypingChange {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	var expired []TypingChange
+	for roomID, users := range uc.typing {
+		for userID, state := range users {
+			if now.Before(state.expiresAt) {
+				continue
+			}
+			expired = append(expired, TypingChange{
+				RoomID:   roomID,
+				UserID:   userID,
+				UserName: state.userName,
+				Typing:   false,
+			})
+			delete(users, userID)
+		}
+		if len(users) == 0 {
+			delete(uc.typing, roomID)
+		}
+	}
+	return expired
+}
@@ -0,0 +1,55 @@
+package chat
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTypingUseCase_Throttle(t *testing.T) {
+	uc := NewTypingUseCase()
+	now := time.Now()
+
+	assert.True(t, uc.Start("room-1", "user-1", "Alice", now))
+	assert.False(t, uc.Start("room-1", "user-1", "Alice", now.Add(time.Second)))
+	assert.True(t, uc.Start("room-1", "user-1", "Alice", now.Add(TypingThrottle)))
+
+	// Other users are throttled independently
+	assert.True(t, uc.Start("room-1", "user-2", "Bob", now))
+}
+
+func TestTypingUseCase_Stop(t *testing.T) {
+	uc := NewTypingUseCase()
+	now := time.Now()
+
+	assert.False(t, uc.Stop("room-1", "user-1"))
+
+	uc.Start("room-1", "user-1", "Alice", now)
+	assert.True(t, uc.Stop("room-1", "user-1"))
+	assert.False(t, uc.Stop("room-1", "user-1"))
+
+	// Typing again right after stopping is broadcast
+	assert.True(t, uc.Start("room-1", "user-1", "Alice", now.Add(time.Second)))
+}
+
+func TestTypingUseCase_Expire(t *testing.T) {
+	uc := NewTypingUseCase()
+	now := time.Now()
+
+	uc.Start("room-1", "user-1", "Alice", now)
+	uc.Start("room-1", "user-2", "Bob", now)
+
+	// Alice keeps typing, throttled refreshes still extend her expiry
+	uc.Start("room-1", "user-1", "Alice", now.Add(TypingTimeout-time.Second))
+
+	assert.Empty(t, uc.Expire(now.Add(time.Second)))
+
+	expired := uc.Expire(now.Add(TypingTimeout))
+	assert.Equal(t, []TypingChange{{RoomID: "room-1", UserID: "user-2", UserName: "Bob", Typing: false}}, expired)
+
+	expired = uc.Expire(now.Add(2 * TypingTimeout))
+	assert.Len(t, expired, 1)
+	assert.Equal(t, "user-1", expired[0].UserID)
+	assert.False(t, uc.Stop("room-1", "user-1"))
+}
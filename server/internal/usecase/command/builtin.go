@@ -0,0 +1,297 @@
+package command
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"voice-chat/internal/domain/entity"
+	"voice-chat/internal/usecase/chat"
+)
+
+// Limits for the built-in commands
+const (
+	MaxDice          = 20
+	MaxDieSides      = 1000
+	MaxTeams         = 10
+	DefaultMuteTime  = 5 * time.Minute
+	MaxAFKMessageLen = 100
+)
+
+// dicePattern matches NdM, or dM for a single die
+var dicePattern = regexp.MustCompile(`^(\d*)d(\d+)$`)
+
+func (uc *CommandUseCase) registerBuiltins() {
+	uc.Register(&Command{
+		Name:        "help",
+		Usage:       "[command]",
+		Description: "List commands or show how to use one",
+		Run:         uc.help,
+	})
+	uc.Register(&Command{
+		Name:        "roll",
+		Usage:       "[NdM]",
+		Description: "Roll dice, 1d6 by default",
+		Run:         uc.roll,
+	})
+	uc.Register(&Command{
+		Name:        "flip",
+		Description: "Flip a coin",
+		Run:         uc.flip,
+	})
+	uc.Register(&Command{
+		Name:        "teams",
+		Usage:       "[count]",
+		Description: "Randomly split the room into teams, 2 by default",
+		Run:         uc.teams,
+	})
+	uc.Register(&Command{
+		Name:        "afk",
+		Usage:       "[message]",
+		Description: "Mark yourself as away, or back if you already are",
+		Run:         uc.afk,
+	})
+	uc.Register(&Command{
+		Name:        "topic",
+		Usage:       "[text]",
+		Description: "Set the room topic, or clear it when no text is given",
+		Moderator:   true,
+		Run:         uc.topic,
+	})
+	uc.Register(&Command{
+		Name:        "mute",
+		Usage:       "@name [minutes]",
+		Description: "Stop someone chatting in this room for a while, 5 minutes by default",
+		Moderator:   true,
+		Run:         uc.mute,
+	})
+	uc.Register(&Command{
+		Name:        "unmute",
+		Usage:       "@name",
+		Description: "Let a muted user chat again",
+		Moderator:   true,
+		Run:         uc.unmute,
+	})
+}
+
+func (uc *CommandUseCase) help(call *Call) (*Result, error) {
+	available := uc.Available(call.Room.ID, call.UserID, call.IsAdmin)
+
+	if name := strings.ToLower(strings.TrimPrefix(call.Args, "/")); name != "" {
+		for _, cmd := range available {
+			if cmd.Name == name {
+				return &Result{Private: fmt.Sprintf("%s: %s", usage(cmd), cmd.Description)}, nil
+			}
+		}
+		return nil, ErrUnknownCommand
+	}
+
+	lines := make([]string, len(available))
+	for i, cmd := range available {
+		lines[i] = fmt.Sprintf("%s: %s", usage(cmd), cmd.Description)
+	}
+	return &Result{Private: strings.Join(lines, "\n")}, nil
+}
+
+func (uc *CommandUseCase) roll(call *Call) (*Result, error) {
+	count, sides := 1, 6
+	if call.Args != "" {
+		m := dicePattern.FindStringSubmatch(strings.ToLower(call.Args))
+		if m == nil {
+			return nil, ErrInvalidArguments
+		}
+		if m[1] != "" {
+			count, _ = strconv.Atoi(m[1])
+		}
+		sides, _ = strconv.Atoi(m[2])
+	}
+	if count < 1 || count > MaxDice || sides < 2 || sides > MaxDieSides {
+		return nil, fmt.Errorf("%w: up to %d dice with 2 to %d sides", ErrInvalidArguments, MaxDice, MaxDieSides)
+	}
+
+	rolls := make([]string, count)
+	total := 0
+	for i := range rolls {
+		r := uc.intn(sides) + 1
+		total += r
+		rolls[i] = strconv.Itoa(r)
+	}
+
+	dice := fmt.Sprintf("%dd%d", count, sides)
+	outcome := strconv.Itoa(total)
+	if count > 1 {
+		outcome = fmt.Sprintf("%s = %d", strings.Join(rolls, " + "), total)
+	}
+	return publicResult("rolled "+dice+": "+outcome, entity.SystemCodeCommandRoll, map[string]string{
+		"dice":   dice,
+		"result": outcome,
+	}), nil
+}
+
+func (uc *CommandUseCase) flip(call *Call) (*Result, error) {
+	if uc.intn(2) == 1 {
+		return publicResult("flipped a coin: tails", entity.SystemCodeCommandFlipTails, nil), nil
+	}
+	return publicResult("flipped a coin: heads", entity.SystemCodeCommandFlipHeads, nil), nil
+}
+
+func (uc *CommandUseCase) teams(call *Call) (*Result, error) {
+	count := 2
+	if call.Args != "" {
+		n, err := strconv.Atoi(call.Args)
+		if err != nil {
+			return nil, ErrInvalidArguments
+		}
+		count = n
+	}
+
+	participants := visibleParticipants(call.Room)
+	if count < 2 || count > MaxTeams {
+		return nil, fmt.Errorf("%w: between 2 and %d teams", ErrInvalidArguments, MaxTeams)
+	}
+	if count > len(participants) {
+		return nil, fmt.Errorf("%w: not enough people for %d teams", ErrInvalidArguments, count)
+	}
+
+	uc.shuffle(len(participants), func(i, j int) {
+		participants[i], participants[j] = participants[j], participants[i]
+	})
+
+	teams := make([][]string, count)
+	for i, p := range participants {
+		teams[i%count] = append(teams[i%count], p.Name)
+	}
+
+	parts := make([]string, count)
+	for i, team := range teams {
+		parts[i] = fmt.Sprintf("Team %d: %s", i+1, strings.Join(team, ", "))
+	}
+	lineup := strings.Join(parts, "; ")
+	return publicResult(fmt.Sprintf("split the room into %d teams. %s", count, lineup), entity.SystemCodeCommandTeams, map[string]string{
+		"count": strconv.Itoa(count),
+		"teams": lineup,
+	}), nil
+}
+
+func (uc *CommandUseCase) afk(call *Call) (*Result, error) {
+	user, err := uc.userRepo.GetByID(call.UserID)
+	if err != nil || user == nil {
+		return nil, ErrUserNotFound
+	}
+
+	if user.IsAFK && call.Args == "" {
+		user.ClearAFK()
+		if err := uc.userRepo.Update(user); err != nil {
+			return nil, err
+		}
+		return publicResult("is back", entity.SystemCodeCommandBack, nil), nil
+	}
+
+	message := call.Args
+	if len([]rune(message)) > MaxAFKMessageLen {
+		message = string([]rune(message)[:MaxAFKMessageLen])
+	}
+	user.SetAFK(message)
+	if err := uc.userRepo.Update(user); err != nil {
+		return nil, err
+	}
+
+	if message == "" {
+		return publicResult("is away", entity.SystemCodeCommandAway, nil), nil
+	}
+	return publicResult("is away: "+message, entity.SystemCodeCommandAwayMessage, map[string]string{"message": message}), nil
+}
+
+func (uc *CommandUseCase) topic(call *Call) (*Result, error) {
+	if len([]rune(call.Args)) > entity.MaxTopicLength {
+		return nil, fmt.Errorf("%w: topics are limited to %d characters", ErrInvalidArguments, entity.MaxTopicLength)
+	}
+
+	call.Room.SetTopic(call.Args)
+	if err := uc.roomRepo.Update(call.Room); err != nil {
+		return nil, err
+	}
+
+	result := publicResult("set the topic: "+call.Args, entity.SystemCodeCommandTopicSet, map[string]string{"topic": call.Args})
+	if call.Args == "" {
+		result = publicResult("cleared the topic", entity.SystemCodeCommandTopicCleared, nil)
+	}
+	result.RoomUpdated = true
+	return result, nil
+}
+
+func (uc *CommandUseCase) mute(call *Call) (*Result, error) {
+	target, rest, err := uc.target(call)
+	if err != nil {
+		return nil, err
+	}
+
+	duration := DefaultMuteTime
+	if rest != "" {
+		minutes, err := strconv.Atoi(rest)
+		if err != nil || minutes < 1 || time.Duration(minutes)*time.Minute > chat.MaxRoomMute {
+			return nil, fmt.Errorf("%w: between 1 and %d minutes", ErrInvalidArguments, int(chat.MaxRoomMute/time.Minute))
+		}
+		duration = time.Duration(minutes) * time.Minute
+	}
+
+	uc.muteUC.Mute(call.Room.ID, target.ID, call.Now.Add(duration))
+	minutes := strconv.Itoa(int(duration / time.Minute))
+	return publicResult("muted "+target.Name+" for "+minutes+" minutes", entity.SystemCodeCommandMute, map[string]string{
+		"name":    target.Name,
+		"minutes": minutes,
+	}), nil
+}
+
+func (uc *CommandUseCase) unmute(call *Call) (*Result, error) {
+	target, _, err := uc.target(call)
+	if err != nil {
+		return nil, err
+	}
+
+	if !uc.muteUC.Unmute(call.Room.ID, target.ID, call.Now) {
+		return &Result{Private: target.Name + " is not muted"}, nil
+	}
+	return publicResult("unmuted "+target.Name, entity.SystemCodeCommandUnmute, map[string]string{"name": target.Name}), nil
+}
+
+// publicResult posts text to the room, with the code and params clients localise it from
+func publicResult(text, code string, params map[string]string) *Result {
+	return &Result{Public: text, Code: code, Params: params}
+}
+
+// target resolves the @name at the start of the arguments to a participant other than
+// the caller or another moderator, and returns the remaining arguments
+func (uc *CommandUseCase) target(call *Call) (*entity.User, string, error) {
+	participants := visibleParticipants(call.Room)
+	mentioned, _ := chat.ParseMentions(call.Args, participants)
+	if !strings.HasPrefix(call.Args, "@") || len(mentioned) == 0 {
+		return nil, "", ErrInvalidArguments
+	}
+
+	var target *entity.User
+	for _, p := range participants {
+		if p.ID == mentioned[0] {
+			target = p
+		}
+	}
+	if target.ID == call.UserID || chat.IsModerator(uc.roomRepo, call.Room.ID, target.ID, target.IsAdmin) {
+		return nil, "", fmt.Errorf("%w: moderators can't be muted", ErrInvalidArguments)
+	}
+
+	rest := strings.TrimSpace(call.Args[1+len(target.Name):])
+	return target, rest, nil
+}
+
+// visibleParticipants returns the room's participants, leaving out stealth admins
+func visibleParticipants(room *entity.Room) []*entity.User {
+	participants := make([]*entity.User, 0)
+	for _, p := range room.GetAllParticipants() {
+		if !p.IsStealth {
+			participants = append(participants, p)
+		}
+	}
+	return participants
+}
@@ -0,0 +1,213 @@
+package command
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/google/uuid"
+
+	"voice-chat/internal/domain/entity"
+	"voice-chat/internal/domain/repository"
+	"voice-chat/internal/usecase/chat"
+)
+
+var (
+	ErrUnknownCommand    = errors.New("unknown command, type /help for a list")
+	ErrCommandNotAllowed = errors.New("only the room owner or an admin can use this command")
+	ErrInvalidArguments  = errors.New("invalid arguments")
+	ErrUserNotFound      = errors.New("user not found")
+)
+
+// Command is a slash command typed into room chat
+type Command struct {
+	Name        string
+	Usage       string // Arguments, e.g. "[NdM]"
+	Description string
+	Moderator   bool // Only room owners and admins may run it
+	Run         func(call *Call) (*Result, error)
+}
+
+// Call is one invocation of a command
+type Call struct {
+	Room     *entity.Room
+	UserID   string
+	UserName string
+	IsAdmin  bool
+	Args     string // Everything after the command name, trimmed
+	Now      time.Time
+}
+
+// Result is what a command produced
+type Result struct {
+	Public  string // Posted to the room as a system message from the caller, in English
+	Private string // Shown only to the caller
+
+	// Code and Params let clients localise Public (see the entity.SystemCode constants);
+	// results without a code are posted as entity.SystemCodeCommandResult
+	Code   string
+	Params map[string]string
+
+	RoomUpdated bool // The room's topic or pins changed
+}
+
+// ExecuteInput represents a chat message that starts with a slash
+type ExecuteInput struct {
+	RoomID   string
+	UserID   string
+	UserName string
+	IsAdmin  bool
+	Content  string
+}
+
+// ExecuteOutput represents the outcome of running a command
+type ExecuteOutput struct {
+	Command string
+	Message *entity.ChatMessage // Stored system message, nil when the result is private
+	Reply   string              // Private reply for the caller
+
+	RoomUpdated *entity.Room // Set when the command changed the room's topic or pins
+}
+
+// CommandUseCase parses slash commands and runs them from a registry
+type CommandUseCase struct {
+	roomRepo repository.RoomRepository
+	userRepo repository.UserRepository
+	chatRepo repository.ChatRepository
+	muteUC   *chat.RoomMuteUseCase
+	commands map[string]*Command
+	rng      *rand.Rand
+	mu       sync.Mutex // protects rng
+}
+
+// NewCommandUseCase creates a CommandUseCase with the built-in commands registered
+func NewCommandUseCase(
+	roomRepo repository.RoomRepository,
+	userRepo repository.UserRepository,
+	chatRepo repository.ChatRepository,
+	muteUC *chat.RoomMuteUseCase,
+) *CommandUseCase {
+	uc := &CommandUseCase{
+		roomRepo: roomRepo,
+		userRepo: userRepo,
+		chatRepo: chatRepo,
+		muteUC:   muteUC,
+		commands: make(map[string]*Command),
+		rng:      rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+	uc.registerBuiltins()
+	return uc
+}
+
+// Register adds a command, replacing any command with the same name
+func (uc *CommandUseCase) Register(cmd *Command) {
+	uc.commands[strings.ToLower(cmd.Name)] = cmd
+}
+
+// IsCommand checks if chat content is a slash command ("/roll", but not "/" or "// comment")
+func IsCommand(content string) bool {
+	content = strings.TrimSpace(content)
+	if len(content) < 2 || content[0] != '/' {
+		return false
+	}
+	return unicode.IsLetter(rune(content[1]))
+}
+
+// Execute runs the command in content, storing its public result as a system message
+func (uc *CommandUseCase) Execute(input ExecuteInput) (*ExecuteOutput, error) {
+	name, args := parse(input.Content)
+	cmd, exists := uc.commands[name]
+	if !exists {
+		return nil, ErrUnknownCommand
+	}
+
+	room, err := uc.roomRepo.GetByID(input.RoomID)
+	if err != nil || room == nil {
+		return nil, chat.ErrRoomNotFound
+	}
+	if cmd.Moderator && !chat.IsModerator(uc.roomRepo, input.RoomID, input.UserID, input.IsAdmin) {
+		return nil, ErrCommandNotAllowed
+	}
+
+	result, err := cmd.Run(&Call{
+		Room:     room,
+		UserID:   input.UserID,
+		UserName: input.UserName,
+		IsAdmin:  input.IsAdmin,
+		Args:     args,
+		Now:      time.Now(),
+	})
+	if errors.Is(err, ErrInvalidArguments) {
+		return nil, fmt.Errorf("%w, usage: %s", err, usage(cmd))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	output := &ExecuteOutput{Command: cmd.Name, Reply: result.Private}
+	if result.RoomUpdated {
+		output.RoomUpdated = room
+	}
+	if result.Public != "" {
+		code, params := result.Code, result.Params
+		if code == "" {
+			code, params = entity.SystemCodeCommandResult, map[string]string{"text": result.Public}
+		}
+		msg := entity.NewSystemEvent(uuid.New().String(), room.ID, input.UserID, input.UserName, code, params, result.Public)
+		if err := uc.chatRepo.AddMessage(msg); err != nil {
+			return nil, err
+		}
+		output.Message = msg
+	}
+	return output, nil
+}
+
+// Available lists the commands a user may run in a room, sorted by name
+func (uc *CommandUseCase) Available(roomID, userID string, isAdmin bool) []*Command {
+	moderator := chat.IsModerator(uc.roomRepo, roomID, userID, isAdmin)
+
+	commands := make([]*Command, 0, len(uc.commands))
+	for _, cmd := range uc.commands {
+		if !cmd.Moderator || moderator {
+			commands = append(commands, cmd)
+		}
+	}
+	sort.Slice(commands, func(i, j int) bool {
+		return commands[i].Name < commands[j].Name
+	})
+	return commands
+}
+
+// parse splits "/name args" into the lowercase name and trimmed arguments
+func parse(content string) (string, string) {
+	content = strings.TrimPrefix(strings.TrimSpace(content), "/")
+	name, args, _ := strings.Cut(content, " ")
+	return strings.ToLower(name), strings.TrimSpace(args)
+}
+
+// usage formats a command's syntax
+func usage(cmd *Command) string {
+	if cmd.Usage == "" {
+		return "/" + cmd.Name
+	}
+	return "/" + cmd.Name + " " + cmd.Usage
+}
+
+// intn returns a random number in [0, n)
+func (uc *CommandUseCase) intn(n int) int {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+	return uc.rng.Intn(n)
+}
+
+// shuffle randomly reorders n elements
+func (uc *CommandUseCase) shuffle(n int, swap func(i, j int)) {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+	uc.rng.Shuffle(n, swap)
+}
@@ -0,0 +1,202 @@
+package command
+
+import (
+	"errors"
+	"math/rand"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"voice-chat/internal/domain/entity"
+	"voice-chat/internal/infrastructure/persistence"
+	"voice-chat/internal/usecase/chat"
+	"voice-chat/pkg/i18n"
+)
+
+func setupCommands() (*CommandUseCase, *persistence.InMemoryChatRepository, *chat.RoomMuteUseCase) {
+	roomRepo := persistence.NewInMemoryRoomRepository()
+	userRepo := persistence.NewInMemoryUserRepository()
+	chatRepo := persistence.NewInMemoryChatRepository()
+	muteUC := chat.NewRoomMuteUseCase()
+
+	room := entity.NewRoom("room-1", "Lobby", entity.RoomTypePublic, "owner")
+	for _, u := range []*entity.User{
+		entity.NewUser("owner", "Olivia", "", entity.VoiceModePTT),
+		entity.NewUser("user-1", "Alice", "", entity.VoiceModePTT),
+		entity.NewUser("user-2", "Bob Smith", "", entity.VoiceModePTT),
+		entity.NewUser("user-3", "Carol", "", entity.VoiceModePTT),
+	} {
+		room.AddParticipant(u)
+		userRepo.Create(u)
+	}
+	roomRepo.Create(room)
+
+	uc := NewCommandUseCase(roomRepo, userRepo, chatRepo, muteUC)
+	uc.rng = rand.New(rand.NewSource(1))
+	return uc, chatRepo, muteUC
+}
+
+func run(uc *CommandUseCase, userID, content string) (*ExecuteOutput, error) {
+	return uc.Execute(ExecuteInput{RoomID: "room-1", UserID: userID, UserName: userID, Content: content})
+}
+
+func TestIsCommand(t *testing.T) {
+	assert.True(t, IsCommand("/roll 2d6"))
+	assert.True(t, IsCommand("  /HELP"))
+	assert.False(t, IsCommand("/"))
+	assert.False(t, IsCommand("// not a command"))
+	assert.False(t, IsCommand("/ shrug"))
+	assert.False(t, IsCommand("hello /roll"))
+}
+
+func TestCommandUseCase_Roll(t *testing.T) {
+	uc, chatRepo, _ := setupCommands()
+
+	out, err := run(uc, "user-1", "/roll 3d6")
+	assert.NoError(t, err)
+	assert.Equal(t, "roll", out.Command)
+	assert.Regexp(t, `^rolled 3d6: [1-6] \+ [1-6] \+ [1-6] = \d+$`, out.Message.Content)
+	assert.Equal(t, entity.ChatMessageTypeSystem, out.Message.Type)
+
+	stored, _ := chatRepo.GetMessages("room-1", 10)
+	assert.Len(t, stored, 1)
+
+	out, err = run(uc, "user-1", "/ROLL")
+	assert.NoError(t, err)
+	assert.Regexp(t, `^rolled 1d6: [1-6]$`, out.Message.Content)
+
+	for _, bad := range []string{"/roll abc", "/roll 0d6", "/roll 2d1", "/roll 50d6"} {
+		_, err = run(uc, "user-1", bad)
+		assert.ErrorIs(t, err, ErrInvalidArguments, bad)
+		assert.Contains(t, err.Error(), "usage: /roll [NdM]")
+	}
+}
+
+func TestCommandUseCase_Teams(t *testing.T) {
+	uc, _, _ := setupCommands()
+
+	out, err := run(uc, "user-1", "/teams")
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(out.Message.Content, "split the room into 2 teams."))
+	for _, name := range []string{"Olivia", "Alice", "Bob Smith", "Carol"} {
+		assert.Contains(t, out.Message.Content, name)
+	}
+
+	_, err = run(uc, "user-1", "/teams 5")
+	assert.ErrorIs(t, err, ErrInvalidArguments)
+}
+
+func TestCommandUseCase_Help(t *testing.T) {
+	uc, _, _ := setupCommands()
+
+	out, err := run(uc, "user-1", "/help")
+	assert.NoError(t, err)
+	assert.Nil(t, out.Message)
+	assert.Contains(t, out.Reply, "/roll [NdM]")
+	assert.NotContains(t, out.Reply, "/mute")
+
+	out, _ = run(uc, "owner", "/help")
+	assert.Contains(t, out.Reply, "/mute @name [minutes]")
+
+	out, _ = run(uc, "user-1", "/help /flip")
+	assert.Equal(t, "/flip: Flip a coin", out.Reply)
+
+	_, err = run(uc, "user-1", "/nope")
+	assert.ErrorIs(t, err, ErrUnknownCommand)
+}
+
+func TestCommandUseCase_ModeratorCommands(t *testing.T) {
+	uc, _, muteUC := setupCommands()
+
+	_, err := run(uc, "user-1", "/topic finals tonight")
+	assert.ErrorIs(t, err, ErrCommandNotAllowed)
+
+	out, err := run(uc, "owner", "/topic finals tonight")
+	assert.NoError(t, err)
+	assert.Equal(t, "set the topic: finals tonight", out.Message.Content)
+	assert.NotNil(t, out.RoomUpdated)
+	room, _ := uc.roomRepo.GetByID("room-1")
+	assert.Equal(t, "finals tonight", room.GetTopic())
+
+	out, err = run(uc, "owner", "/mute @bob smith 10")
+	assert.NoError(t, err)
+	assert.Equal(t, "muted Bob Smith for 10 minutes", out.Message.Content)
+	assert.InDelta(t, float64(10*time.Minute), float64(muteUC.Remaining("room-1", "user-2", time.Now())), float64(time.Second))
+
+	_, err = run(uc, "owner", "/mute @Alice 600")
+	assert.ErrorIs(t, err, ErrInvalidArguments)
+	_, err = run(uc, "owner", "/mute @nobody")
+	assert.ErrorIs(t, err, ErrInvalidArguments)
+
+	// Admins are moderators everywhere and can't be muted
+	_, err = uc.Execute(ExecuteInput{RoomID: "room-1", UserID: "user-1", IsAdmin: true, Content: "/mute @Olivia"})
+	assert.True(t, errors.Is(err, ErrInvalidArguments))
+
+	out, _ = run(uc, "owner", "/unmute @Bob Smith")
+	assert.Equal(t, "unmuted Bob Smith", out.Message.Content)
+	assert.Zero(t, muteUC.Remaining("room-1", "user-2", time.Now()))
+
+	out, _ = run(uc, "owner", "/unmute @Bob Smith")
+	assert.Nil(t, out.Message)
+	assert.Equal(t, "Bob Smith is not muted", out.Reply)
+}
+
+func TestCommandUseCase_AFK(t *testing.T) {
+	uc, _, _ := setupCommands()
+
+	out, err := run(uc, "user-1", "/afk getting coffee")
+	assert.NoError(t, err)
+	assert.Equal(t, "is away: getting coffee", out.Message.Content)
+	user, _ := uc.userRepo.GetByID("user-1")
+	assert.True(t, user.IsAFK)
+	assert.Equal(t, "getting coffee", user.AFKMessage)
+
+	out, _ = run(uc, "user-1", "/afk")
+	assert.Equal(t, "is back", out.Message.Content)
+	assert.False(t, user.IsAFK)
+}
+
+func TestCommandUseCase_Register(t *testing.T) {
+	uc, _, _ := setupCommands()
+
+	uc.Register(&Command{
+		Name:        "shrug",
+		Description: "Shrug",
+		Run: func(call *Call) (*Result, error) {
+			return &Result{Public: "shrugs " + call.Args}, nil
+		},
+	})
+
+	out, err := run(uc, "user-1", "/shrug whatever")
+	assert.NoError(t, err)
+	assert.Equal(t, "shrugs whatever", out.Message.Content)
+	assert.Equal(t, entity.SystemCodeCommandResult, out.Message.Code)
+	assert.Equal(t, map[string]string{"text": "shrugs whatever"}, out.Message.Params)
+}
+
+func TestCommandUseCase_ResultsMatchCatalog(t *testing.T) {
+	uc, _, _ := setupCommands()
+	catalog, err := i18n.Load("en", i18n.Builtin())
+	assert.NoError(t, err)
+
+	for _, content := range []string{
+		"/roll", "/roll 2d6", "/flip", "/flip", "/teams", "/afk", "/afk", "/afk brb",
+		"/topic finals tonight", "/topic", "/mute @Bob Smith 10", "/unmute @Bob Smith",
+	} {
+		out, err := uc.Execute(ExecuteInput{RoomID: "room-1", UserID: "owner", UserName: "Olivia", Content: content})
+		assert.NoError(t, err, content)
+
+		// Stored content is the English rendering, and every locale can render the code
+		msg := out.Message
+		assert.NotEmpty(t, msg.Code, content)
+		english, ok := catalog.Render("en", "system."+msg.Code, msg.Params)
+		assert.True(t, ok, content)
+		assert.Equal(t, msg.Content, english, content)
+		for _, locale := range catalog.Locales() {
+			_, ok := catalog.Lookup(locale, "system."+msg.Code, msg.Params)
+			assert.True(t, ok, "%s in %s", msg.Code, locale)
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package dm
+
+import (
+	"errors"
+
+	"voice-chat/internal/domain/repository"
+)
+
+var ErrBlockSelf = errors.New("you cannot block yourself")
+
+// BlockUseCase manages the users someone no longer wants direct messages from
+type BlockUseCase struct {
+	blockRepo repository.BlockRepository
+}
+
+// NewBlockUseCase creates a new BlockUseCase
+func NewBlockUseCase(blockRepo repository.BlockRepository) *BlockUseCase {
+	return &BlockUseCase{
+		blockRepo: blockRepo,
+	}
+}
+
+// Block stops blockedID from messaging userID and returns the updated block list
+func (uc *BlockUseCase) Block(userID, blockedID string) ([]string, error) {
+	if userID == blockedID {
+		return nil, ErrBlockSelf
+	}
+	if err := uc.blockRepo.Block(userID, blockedID); err != nil {
+		return nil, err
+	}
+	return uc.blockRepo.GetBlocked(userID)
+}
+
+// Unblock lets blockedID message userID again and returns the updated block list
+func (uc *BlockUseCase) Unblock(userID, blockedID string) ([]string, error) {
+	if err := uc.blockRepo.Unblock(userID, blockedID); err != nil {
+		return nil, err
+	}
+	return uc.blockRepo.GetBlocked(userID)
+}
+
+// Blocked returns the users userID has blocked
+func (uc *BlockUseCase) Blocked(userID string) ([]string, error) {
+	return uc.blockRepo.GetBlocked(userID)
+}
@@ -0,0 +1,36 @@
+package dm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"voice-chat/internal/infrastructure/persistence"
+)
+
+func TestBlockUseCase_BlockAndUnblock(t *testing.T) {
+	uc := NewBlockUseCase(persistence.NewInMemoryBlockRepository())
+
+	blocked, err := uc.Block("alice", "mallory")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"mallory"}, blocked)
+
+	blocked, _ = uc.Block("alice", "eve")
+	assert.Equal(t, []string{"eve", "mallory"}, blocked)
+
+	blocked, err = uc.Unblock("alice", "mallory")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"eve"}, blocked)
+
+	// Block lists are per user
+	other, _ := uc.Blocked("bob")
+	assert.Empty(t, other)
+}
+
+func TestBlockUseCase_BlockSelf(t *testing.T) {
+	uc := NewBlockUseCase(persistence.NewInMemoryBlockRepository())
+
+	_, err := uc.Block("alice", "alice")
+
+	assert.ErrorIs(t, err, ErrBlockSelf)
+}
@@ -0,0 +1,117 @@
+package dm
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"voice-chat/internal/domain/entity"
+	"voice-chat/internal/domain/repository"
+)
+
+const (
+	MaxReportReasonLength = 500 // Longest report reason kept, in characters
+	DefaultReportsLimit   = 100 // Reports returned to admins when no limit is given
+)
+
+var (
+	ErrMessageNotFound = errors.New("direct message not found")
+	ErrNotReportable   = errors.New("you can only report messages sent to you")
+	ErrReasonRequired  = errors.New("a reason is required to report a message")
+)
+
+// ReportInput represents the input for reporting a direct message
+type ReportInput struct {
+	ReporterID   string
+	ReporterName string
+	MessageID    string
+	Reason       string
+	IP           string
+}
+
+// ReportUseCase records reported direct messages for admins to review
+type ReportUseCase struct {
+	dmRepo       repository.DirectMessageRepository
+	reportRepo   repository.DMReportRepository
+	activityRepo repository.ActivityRepository
+}
+
+// NewReportUseCase creates a new ReportUseCase
+func NewReportUseCase(
+	dmRepo repository.DirectMessageRepository,
+	reportRepo repository.DMReportRepository,
+	activityRepo repository.ActivityRepository,
+) *ReportUseCase {
+	return &ReportUseCase{
+		dmRepo:       dmRepo,
+		reportRepo:   reportRepo,
+		activityRepo: activityRepo,
+	}
+}
+
+// Report files a report for a message the reporter received, keeping a copy of the
+// conversation leading up to it
+func (uc *ReportUseCase) Report(input ReportInput) (*entity.DMReport, error) {
+	reason := strings.TrimSpace(input.Reason)
+	if reason == "" {
+		return nil, ErrReasonRequired
+	}
+	if r := []rune(reason); len(r) > MaxReportReasonLength {
+		reason = string(r[:MaxReportReasonLength])
+	}
+
+	msg, err := uc.dmRepo.GetMessage(input.MessageID)
+	if err != nil || !msg.IsParticipant(input.ReporterID) {
+		return nil, ErrMessageNotFound
+	}
+	if msg.RecipientID != input.ReporterID {
+		return nil, ErrNotReportable
+	}
+
+	context, err := uc.dmRepo.GetConversation(msg.SenderID, msg.RecipientID, msg.ID, entity.MaxDMReportContext)
+	if err != nil {
+		return nil, err
+	}
+
+	// Copy the messages so later retention or changes cannot alter the audit trail
+	snapshot := *msg
+	contextCopy := make([]*entity.DirectMessage, len(context))
+	for i, m := range context {
+		c := *m
+		contextCopy[i] = &c
+	}
+
+	report := entity.NewDMReport(uuid.New().String(), input.ReporterID, input.ReporterName, reason, &snapshot, contextCopy)
+	if err := uc.reportRepo.Create(report); err != nil {
+		return nil, err
+	}
+
+	if uc.activityRepo != nil {
+		activity := entity.NewActivityLog(
+			uuid.New().String(),
+			entity.ActivityTypeDMReport,
+			input.ReporterID,
+			input.ReporterName,
+			"",
+			"",
+			input.IP,
+		)
+		activity.AddDetail("report_id", report.ID)
+		activity.AddDetail("message_id", msg.ID)
+		activity.AddDetail("sender_id", msg.SenderID)
+		activity.AddDetail("sender_name", msg.SenderName)
+		activity.AddDetail("reason", reason)
+		_ = uc.activityRepo.Log(activity)
+	}
+
+	return report, nil
+}
+
+// Reports retrieves the most recent reports (newest first)
+func (uc *ReportUseCase) Reports(limit int) ([]*entity.DMReport, error) {
+	if limit <= 0 {
+		limit = DefaultReportsLimit
+	}
+	return uc.reportRepo.GetRecent(limit)
+}
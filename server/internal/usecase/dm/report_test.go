@@ -0,0 +1,58 @@
+package dm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"voice-chat/internal/domain/entity"
+	"voice-chat/internal/infrastructure/persistence"
+)
+
+func TestReportUseCase_Report(t *testing.T) {
+	dmRepo := persistence.NewInMemoryDirectMessageRepository()
+	activityRepo := persistence.NewInMemoryActivityRepository()
+	dmUC := NewDirectMessageUseCase(dmRepo, persistence.NewInMemoryBlockRepository(), nil)
+	uc := NewReportUseCase(dmRepo, persistence.NewInMemoryDMReportRepository(), activityRepo)
+
+	send(dmUC, "mallory", "alice", "hey")
+	send(dmUC, "alice", "mallory", "hello")
+	abuse, _, _ := dmUC.Send(SendMessageInput{SenderID: "mallory", SenderName: "Mallory", RecipientID: "alice", Content: "something nasty"})
+
+	report, err := uc.Report(ReportInput{ReporterID: "alice", ReporterName: "Alice", MessageID: abuse.ID, Reason: " harassment "})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "harassment", report.Reason)
+	assert.Equal(t, "something nasty", report.Message.Content)
+	assert.Len(t, report.Context, 2)
+	assert.Equal(t, "hey", report.Context[0].Content)
+
+	// The report keeps its own copy of the message
+	abuse.Content = "changed"
+	assert.Equal(t, "something nasty", report.Message.Content)
+
+	reports, _ := uc.Reports(0)
+	assert.Len(t, reports, 1)
+	assert.Equal(t, report.ID, reports[0].ID)
+
+	logged, _ := activityRepo.GetByType(entity.ActivityTypeDMReport)
+	assert.Len(t, logged, 1)
+	assert.Equal(t, "mallory", logged[0].Details["sender_id"])
+}
+
+func TestReportUseCase_OnlyRecipientCanReport(t *testing.T) {
+	dmRepo := persistence.NewInMemoryDirectMessageRepository()
+	dmUC := NewDirectMessageUseCase(dmRepo, persistence.NewInMemoryBlockRepository(), nil)
+	uc := NewReportUseCase(dmRepo, persistence.NewInMemoryDMReportRepository(), nil)
+
+	msg, _, _ := dmUC.Send(SendMessageInput{SenderID: "mallory", RecipientID: "alice", Content: "hey"})
+
+	_, err := uc.Report(ReportInput{ReporterID: "mallory", MessageID: msg.ID, Reason: "spam"})
+	assert.ErrorIs(t, err, ErrNotReportable)
+
+	_, err = uc.Report(ReportInput{ReporterID: "eve", MessageID: msg.ID, Reason: "spam"})
+	assert.ErrorIs(t, err, ErrMessageNotFound)
+
+	_, err = uc.Report(ReportInput{ReporterID: "alice", MessageID: msg.ID, Reason: ""})
+	assert.ErrorIs(t, err, ErrReasonRequired)
+}
@@ -0,0 +1,126 @@
+package dm
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"voice-chat/internal/domain/entity"
+	"voice-chat/internal/domain/repository"
+	"voice-chat/internal/usecase/moderation"
+)
+
+// MaxDirectMessageLength is the longest direct message accepted, in characters
+const MaxDirectMessageLength = 2000
+
+var (
+	ErrEmptyMessage     = errors.New("message content is empty")
+	ErrMessageTooLong   = errors.New("message is too long")
+	ErrMessageSelf      = errors.New("you cannot send a direct message to yourself")
+	ErrRecipientBlocked = errors.New("this user is not accepting your messages")
+	ErrSenderBlocked    = errors.New("unblock this user to message them")
+)
+
+// Identity returns the ID a session is known by in direct messages. It survives
+// reconnects like the session does, without giving away the session token.
+func Identity(sessionID string) string {
+	sum := sha256.Sum256([]byte("dm:" + sessionID))
+	return hex.EncodeToString(sum[:16])
+}
+
+// SendMessageInput represents the input for sending a direct message (IDs are DM identities)
+type SendMessageInput struct {
+	SenderID      string
+	SenderName    string
+	RecipientID   string
+	RecipientName string
+	Content       string
+	IP            string
+}
+
+// DirectMessageUseCase handles sending and reading one-to-one messages
+type DirectMessageUseCase struct {
+	dmRepo       repository.DirectMessageRepository
+	blockRepo    repository.BlockRepository
+	moderationUC *moderation.ModerationUseCase
+}
+
+// NewDirectMessageUseCase creates a new DirectMessageUseCase
+func NewDirectMessageUseCase(
+	dmRepo repository.DirectMessageRepository,
+	blockRepo repository.BlockRepository,
+	moderationUC *moderation.ModerationUseCase,
+) *DirectMessageUseCase {
+	return &DirectMessageUseCase{
+		dmRepo:       dmRepo,
+		blockRepo:    blockRepo,
+		moderationUC: moderationUC,
+	}
+}
+
+// Send moderates and stores a direct message unless either user has blocked the other.
+// The review is nil when moderation is disabled.
+func (uc *DirectMessageUseCase) Send(input SendMessageInput) (*entity.DirectMessage, *moderation.Result, error) {
+	content := strings.TrimSpace(input.Content)
+	if content == "" {
+		return nil, nil, ErrEmptyMessage
+	}
+	if len([]rune(content)) > MaxDirectMessageLength {
+		return nil, nil, ErrMessageTooLong
+	}
+	if input.SenderID == input.RecipientID {
+		return nil, nil, ErrMessageSelf
+	}
+
+	if uc.blockRepo.IsBlocked(input.RecipientID, input.SenderID) {
+		return nil, nil, ErrRecipientBlocked
+	}
+	if uc.blockRepo.IsBlocked(input.SenderID, input.RecipientID) {
+		return nil, nil, ErrSenderBlocked
+	}
+
+	messageID := uuid.New().String()
+
+	// Moderation may rewrite the content, so it runs before the message is built
+	var review *moderation.Result
+	if uc.moderationUC != nil {
+		var err error
+		review, err = uc.moderationUC.Review(moderation.ReviewInput{
+			RoomName:  entity.DirectMessagesName,
+			MessageID: messageID,
+			UserID:    input.SenderID,
+			UserName:  input.SenderName,
+			IP:        input.IP,
+			Content:   content,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		content = review.Content
+	}
+
+	msg := entity.NewDirectMessage(
+		messageID,
+		input.SenderID,
+		input.SenderName,
+		input.RecipientID,
+		input.RecipientName,
+		content,
+	)
+	if err := uc.dmRepo.AddMessage(msg); err != nil {
+		return nil, nil, err
+	}
+
+	return msg, review, nil
+}
+
+// History retrieves a page of the conversation between two users (chronological order)
+func (uc *DirectMessageUseCase) History(userID, otherID, beforeID string, limit int) ([]*entity.DirectMessage, error) {
+	if limit <= 0 || limit > repository.MaxChatHistoryPageSize {
+		limit = repository.DefaultDMHistoryLimit
+	}
+	return uc.dmRepo.GetConversation(userID, otherID, beforeID, limit)
+}
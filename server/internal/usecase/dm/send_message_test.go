@@ -0,0 +1,93 @@
+package dm
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"voice-chat/internal/domain/entity"
+	"voice-chat/internal/infrastructure/persistence"
+	"voice-chat/internal/usecase/moderation"
+)
+
+func newDirectMessageFixture() (*DirectMessageUseCase, *persistence.InMemoryBlockRepository) {
+	blockRepo := persistence.NewInMemoryBlockRepository()
+	return NewDirectMessageUseCase(persistence.NewInMemoryDirectMessageRepository(), blockRepo, nil), blockRepo
+}
+
+func send(uc *DirectMessageUseCase, from, to, content string) error {
+	_, _, err := uc.Send(SendMessageInput{
+		SenderID:      from,
+		SenderName:    from,
+		RecipientID:   to,
+		RecipientName: to,
+		Content:       content,
+	})
+	return err
+}
+
+func TestDirectMessageUseCase_SendAndHistory(t *testing.T) {
+	uc, _ := newDirectMessageFixture()
+
+	assert.NoError(t, send(uc, "alice", "bob", "hi bob"))
+	assert.NoError(t, send(uc, "bob", "alice", "hi alice"))
+	assert.NoError(t, send(uc, "alice", "carol", "hi carol"))
+
+	// Both sides see the same conversation, without other conversations mixed in
+	history, err := uc.History("bob", "alice", "", 0)
+	assert.NoError(t, err)
+	assert.Len(t, history, 2)
+	assert.Equal(t, "hi bob", history[0].Content)
+	assert.Equal(t, "hi alice", history[1].Content)
+
+	older, err := uc.History("alice", "bob", history[1].ID, 0)
+	assert.NoError(t, err)
+	assert.Len(t, older, 1)
+	assert.Equal(t, history[0].ID, older[0].ID)
+}
+
+func TestDirectMessageUseCase_Validation(t *testing.T) {
+	uc, _ := newDirectMessageFixture()
+
+	assert.ErrorIs(t, send(uc, "alice", "bob", "   "), ErrEmptyMessage)
+	assert.ErrorIs(t, send(uc, "alice", "alice", "hi me"), ErrMessageSelf)
+	assert.ErrorIs(t, send(uc, "alice", "bob", strings.Repeat("a", MaxDirectMessageLength+1)), ErrMessageTooLong)
+}
+
+func TestDirectMessageUseCase_Blocked(t *testing.T) {
+	uc, blockRepo := newDirectMessageFixture()
+	blockRepo.Block("bob", "alice")
+
+	assert.ErrorIs(t, send(uc, "alice", "bob", "hi"), ErrRecipientBlocked)
+	assert.ErrorIs(t, send(uc, "bob", "alice", "hi"), ErrSenderBlocked)
+
+	history, _ := uc.History("alice", "bob", "", 0)
+	assert.Empty(t, history)
+
+	blockRepo.Unblock("bob", "alice")
+	assert.NoError(t, send(uc, "alice", "bob", "hi"))
+}
+
+func TestDirectMessageUseCase_Moderation(t *testing.T) {
+	moderationUC := moderation.NewModerationUseCase(&entity.ModerationConfig{
+		Rooms: map[string]entity.ModerationPolicy{
+			"direct messages": {MaxLength: &entity.MaxLengthRule{Action: entity.ModerationActionBlock, Limit: 10}},
+		},
+	}, persistence.NewInMemoryRoomRepository(), nil)
+	uc := NewDirectMessageUseCase(persistence.NewInMemoryDirectMessageRepository(), persistence.NewInMemoryBlockRepository(), moderationUC)
+
+	assert.ErrorIs(t, send(uc, "alice", "bob", strings.Repeat("a", 11)), moderation.ErrMessageBlocked)
+	history, _ := uc.History("alice", "bob", "", 0)
+	assert.Empty(t, history)
+
+	_, review, err := uc.Send(SendMessageInput{SenderID: "alice", RecipientID: "bob", Content: "hi"})
+	assert.NoError(t, err)
+	assert.NotNil(t, review)
+}
+
+func TestIdentity(t *testing.T) {
+	assert.Equal(t, Identity("session-1"), Identity("session-1"))
+	assert.NotEqual(t, Identity("session-1"), Identity("session-2"))
+	assert.NotContains(t, Identity("session-1"), "session-1")
+}
@@ -0,0 +1,79 @@
+package moderation
+
+import (
+	"voice-chat/internal/domain/entity"
+)
+
+// Violation is a filter a message tripped
+type Violation struct {
+	Filter string
+	Action entity.ModerationAction
+	Reason string
+}
+
+// Result is the outcome of running a message through a chain
+type Result struct {
+	Content  string      // Content to store, after masking
+	Blocked  *Violation  // Set when the message must not be sent
+	Flagged  []Violation // Sent, but recorded for review
+	Warnings []Violation // Sent, and the sender is warned
+}
+
+// Chain runs filters in order
+type Chain []Filter
+
+// NewChain builds the filters a policy enables, cheapest and most decisive first
+func NewChain(policy entity.ModerationPolicy) Chain {
+	chain := make(Chain, 0, 6)
+	enabled := func(action entity.ModerationAction) bool {
+		return action != "" && action != entity.ModerationActionOff
+	}
+
+	if r := policy.MaxLength; r != nil && enabled(r.Action) {
+		chain = append(chain, &maxLengthFilter{rule: rule{r.Action}, limit: r.Limit})
+	}
+	if r := policy.Invites; r != nil && enabled(r.Action) && len(r.Patterns) > 0 {
+		chain = append(chain, newInviteFilter(r))
+	}
+	if r := policy.Links; r != nil && enabled(r.Action) && (len(r.Allow) > 0 || len(r.Deny) > 0) {
+		chain = append(chain, &linkFilter{rule: rule{r.Action}, allow: r.Allow, deny: r.Deny})
+	}
+	if r := policy.Profanity; r != nil && enabled(r.Action) && len(r.Words) > 0 {
+		chain = append(chain, newProfanityFilter(r))
+	}
+	if r := policy.RepeatedChars; r != nil && enabled(r.Action) {
+		chain = append(chain, &repeatedCharsFilter{rule: rule{r.Action}, maxRun: r.MaxRun})
+	}
+	if r := policy.Caps; r != nil && enabled(r.Action) {
+		chain = append(chain, &capsFilter{rule: rule{r.Action}, minLetters: r.MinLetters, maxRatio: r.MaxRatio})
+	}
+	return chain
+}
+
+// Run passes content through every filter. A block stops the chain; masks rewrite the content
+// seen by later filters; flags and warnings are collected.
+func (c Chain) Run(content string) *Result {
+	result := &Result{Content: content}
+
+	for _, filter := range c {
+		reason, masked := filter.Check(result.Content)
+		if reason == "" {
+			continue
+		}
+
+		violation := Violation{Filter: filter.Name(), Action: filter.Action(), Reason: reason}
+		switch filter.Action() {
+		case entity.ModerationActionBlock:
+			result.Blocked = &violation
+			return result
+		case entity.ModerationActionMask:
+			result.Content = masked
+		case entity.ModerationActionFlag:
+			result.Flagged = append(result.Flagged, violation)
+		case entity.ModerationActionWarn:
+			result.Warnings = append(result.Warnings, violation)
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,110 @@
+package moderation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"voice-chat/internal/domain/entity"
+)
+
+func TestChain_MaxLength(t *testing.T) {
+	chain := NewChain(entity.DefaultModerationConfig().Default)
+
+	result := chain.Run("hello")
+	assert.Nil(t, result.Blocked)
+	assert.Equal(t, "hello", result.Content)
+
+	long := make([]rune, entity.DefaultMaxMessageLength+1)
+	for i := range long {
+		long[i] = 'é'
+	}
+	result = chain.Run(string(long))
+	assert.NotNil(t, result.Blocked)
+	assert.Equal(t, "max_length", result.Blocked.Filter)
+}
+
+func TestChain_ProfanityLeetspeak(t *testing.T) {
+	chain := NewChain(entity.ModerationPolicy{
+		Profanity: &entity.ProfanityRule{Action: entity.ModerationActionMask, Words: []string{"darn", "ass", "hell"}},
+	})
+
+	for _, content := range []string{"darn it", "D4RN it", "d.a.r.n it", "daaarn it", "h3lllll it"} {
+		result := chain.Run(content)
+		assert.NotEqual(t, content, result.Content, content)
+		assert.NotContains(t, result.Content, "it it")
+	}
+	assert.Equal(t, "**** it", chain.Run("d@rn it").Content)
+
+	// Innocent words survive, including ones that share a prefix or only match with letters collapsed
+	for _, content := range []string{"as you wish", "pass the salt", "darling", "hello there", "shellfish", "hellooo", "assassin"} {
+		assert.Equal(t, content, chain.Run(content).Content, content)
+	}
+}
+
+func TestChain_Links(t *testing.T) {
+	chain := NewChain(entity.ModerationPolicy{
+		Links: &entity.LinkRule{
+			Action: entity.ModerationActionMask,
+			Allow:  []string{"github.com"},
+			Deny:   []string{"evil.github.com"},
+		},
+	})
+
+	assert.Equal(t, "see https://github.com/x/y", chain.Run("see https://github.com/x/y").Content)
+	assert.Equal(t, "see www.gist.github.com", chain.Run("see www.gist.github.com").Content)
+	assert.Equal(t, "see [link removed]", chain.Run("see http://example.com/page").Content)
+	assert.Equal(t, "see [link removed]", chain.Run("see https://evil.github.com").Content)
+	assert.Equal(t, "see [link removed]", chain.Run("see https://notgithub.com").Content)
+}
+
+func TestChain_Invites(t *testing.T) {
+	chain := NewChain(entity.ModerationPolicy{
+		Invites: &entity.InviteRule{Action: entity.ModerationActionBlock, Patterns: entity.DefaultInvitePatterns},
+	})
+
+	assert.NotNil(t, chain.Run("join discord.gg/abc123").Blocked)
+	assert.NotNil(t, chain.Run("https://t.me/somegroup").Blocked)
+	assert.Nil(t, chain.Run("we talk on discord.gg sometimes").Blocked)
+}
+
+func TestChain_RepeatedCharsAndCaps(t *testing.T) {
+	chain := NewChain(entity.ModerationPolicy{
+		RepeatedChars: &entity.RepeatedCharsRule{Action: entity.ModerationActionMask, MaxRun: 3},
+		Caps:          &entity.CapsRule{Action: entity.ModerationActionWarn, MinLetters: 5, MaxRatio: 0.5},
+	})
+
+	result := chain.Run("noooooo 1000000")
+	assert.Equal(t, "nooo 1000000", result.Content)
+	assert.Empty(t, result.Warnings)
+
+	result = chain.Run("STOP THAT NOW")
+	assert.Equal(t, "STOP THAT NOW", result.Content)
+	assert.Len(t, result.Warnings, 1)
+	assert.Equal(t, "caps", result.Warnings[0].Filter)
+
+	// Too short to count as shouting
+	assert.Empty(t, chain.Run("OK").Warnings)
+}
+
+func TestChain_BlockStopsChain(t *testing.T) {
+	chain := NewChain(entity.ModerationPolicy{
+		MaxLength: &entity.MaxLengthRule{Action: entity.ModerationActionFlag, Limit: 3},
+		Invites:   &entity.InviteRule{Action: entity.ModerationActionBlock, Patterns: []string{"t.me"}},
+		Caps:      &entity.CapsRule{Action: entity.ModerationActionWarn, MinLetters: 1, MaxRatio: 0.5},
+	})
+
+	result := chain.Run("T.ME/GROUP")
+	assert.NotNil(t, result.Blocked)
+	assert.Len(t, result.Flagged, 1)
+	assert.Empty(t, result.Warnings)
+}
+
+func TestChain_OffDisablesRule(t *testing.T) {
+	chain := NewChain(entity.ModerationPolicy{
+		MaxLength: &entity.MaxLengthRule{Action: entity.ModerationActionOff, Limit: 1},
+	})
+
+	assert.Empty(t, chain)
+	assert.Nil(t, chain.Run("long enough").Blocked)
+}
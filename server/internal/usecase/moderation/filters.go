@@ -0,0 +1,261 @@
+package moderation
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"voice-chat/internal/domain/entity"
+)
+
+// Filter checks chat content for one kind of problem
+type Filter interface {
+	// Name identifies the filter in violations and logs
+	Name() string
+
+	// Action is what the chain does when the filter trips
+	Action() entity.ModerationAction
+
+	// Check reports why content trips the filter (empty if it doesn't) and the content
+	// rewritten so it no longer would
+	Check(content string) (reason string, masked string)
+}
+
+// rule holds the configured action shared by every filter
+type rule struct {
+	action entity.ModerationAction
+}
+
+func (r rule) Action() entity.ModerationAction {
+	return r.action
+}
+
+// maxLengthFilter rejects overly long messages; masking truncates them
+type maxLengthFilter struct {
+	rule
+	limit int
+}
+
+func (f *maxLengthFilter) Name() string { return "max_length" }
+
+func (f *maxLengthFilter) Check(content string) (string, string) {
+	runes := []rune(content)
+	if len(runes) <= f.limit {
+		return "", content
+	}
+	return fmt.Sprintf("message is longer than %d characters", f.limit), string(runes[:f.limit])
+}
+
+// inviteFilter catches invite links to other chat services
+type inviteFilter struct {
+	rule
+	pattern *regexp.Regexp
+}
+
+func newInviteFilter(r *entity.InviteRule) *inviteFilter {
+	hosts := make([]string, len(r.Patterns))
+	for i, p := range r.Patterns {
+		hosts[i] = regexp.QuoteMeta(strings.ToLower(p))
+	}
+	// An invite needs a code after the host, so a bare mention of the service passes
+	pattern := regexp.MustCompile(`(?i)\b(?:https?://)?(?:www\.)?(?:` + strings.Join(hosts, "|") + `)/\S+`)
+	return &inviteFilter{rule: rule{r.Action}, pattern: pattern}
+}
+
+func (f *inviteFilter) Name() string { return "invites" }
+
+func (f *inviteFilter) Check(content string) (string, string) {
+	if !f.pattern.MatchString(content) {
+		return "", content
+	}
+	return "invite links are not allowed", f.pattern.ReplaceAllString(content, "[invite removed]")
+}
+
+// linkPattern finds links that start with a scheme or www.
+var linkPattern = regexp.MustCompile(`(?i)\b(?:https?://|www\.)[^\s<>"]+`)
+
+// linkFilter restricts links by domain
+type linkFilter struct {
+	rule
+	allow []string
+	deny  []string
+}
+
+func (f *linkFilter) Name() string { return "links" }
+
+func (f *linkFilter) Check(content string) (string, string) {
+	reason := ""
+	masked := linkPattern.ReplaceAllStringFunc(content, func(link string) string {
+		host := linkHost(link)
+		if matchesDomain(host, f.deny) || (len(f.allow) > 0 && !matchesDomain(host, f.allow)) {
+			reason = fmt.Sprintf("links to %s are not allowed", host)
+			return "[link removed]"
+		}
+		return link
+	})
+	return reason, masked
+}
+
+// linkHost extracts the lowercase host of a link, without a leading www.
+func linkHost(link string) string {
+	host := strings.ToLower(link)
+	if i := strings.Index(host, "://"); i >= 0 {
+		host = host[i+3:]
+	}
+	if i := strings.IndexAny(host, "/:?#"); i >= 0 {
+		host = host[:i]
+	}
+	return strings.TrimPrefix(host, "www.")
+}
+
+// matchesDomain checks if host is one of the domains or a subdomain of one
+func matchesDomain(host string, domains []string) bool {
+	for _, d := range domains {
+		d = strings.ToLower(strings.TrimPrefix(d, "www."))
+		if host == d || strings.HasSuffix(host, "."+d) {
+			return true
+		}
+	}
+	return false
+}
+
+// wordPattern finds words, keeping leetspeak symbols and separators inside them (f.u.c.k, sh!t)
+var wordPattern = regexp.MustCompile(`[\p{L}\p{N}@$!|+]+(?:[._*-][\p{L}\p{N}@$!|+]+)*`)
+
+// leetspeak maps look-alike symbols back to letters
+var leetspeak = map[rune]rune{
+	'0': 'o', '1': 'i', '3': 'e', '4': 'a', '5': 's', '7': 't', '8': 'b', '9': 'g',
+	'@': 'a', '$': 's', '!': 'i', '|': 'l', '+': 't',
+}
+
+// profanityFilter matches listed words, including leetspeak and stretched spellings
+type profanityFilter struct {
+	rule
+	words     map[string]bool // normalized words
+	collapsed map[string]bool // normalized words with repeated letters collapsed
+}
+
+func newProfanityFilter(r *entity.ProfanityRule) *profanityFilter {
+	f := &profanityFilter{
+		rule:      rule{r.Action},
+		words:     make(map[string]bool, len(r.Words)),
+		collapsed: make(map[string]bool, len(r.Words)),
+	}
+	for _, w := range r.Words {
+		if n := normalizeWord(w); n != "" {
+			f.words[n] = true
+			f.collapsed[collapseRepeats(n)] = true
+		}
+	}
+	return f
+}
+
+func (f *profanityFilter) Name() string { return "profanity" }
+
+func (f *profanityFilter) Check(content string) (string, string) {
+	reason := ""
+	masked := wordPattern.ReplaceAllStringFunc(content, func(token string) string {
+		if !f.matches(token) {
+			return token
+		}
+		reason = "message contains blocked language"
+		return strings.Repeat("*", len([]rune(token)))
+	})
+	return reason, masked
+}
+
+// matches checks if a whole token is a listed word, so "hell" doesn't match "hello". Stretched
+// spellings (fuuuck) are compared with repeats collapsed, but only when the token has repeats,
+// so "as" doesn't match "ass".
+func (f *profanityFilter) matches(token string) bool {
+	normalized := normalizeWord(token)
+	if f.words[normalized] {
+		return true
+	}
+	collapsed := collapseRepeats(normalized)
+	return collapsed != normalized && f.collapsed[collapsed]
+}
+
+// normalizeWord lowercases a word, undoes leetspeak and drops separators
+func normalizeWord(word string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(word) {
+		if mapped, ok := leetspeak[r]; ok {
+			r = mapped
+		}
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// collapseRepeats reduces every run of the same character to one
+func collapseRepeats(s string) string {
+	var b strings.Builder
+	var prev rune = -1
+	for _, r := range s {
+		if r != prev {
+			b.WriteRune(r)
+		}
+		prev = r
+	}
+	return b.String()
+}
+
+// repeatedCharsFilter limits runs of the same character (digits and spaces are exempt)
+type repeatedCharsFilter struct {
+	rule
+	maxRun int
+}
+
+func (f *repeatedCharsFilter) Name() string { return "repeated_chars" }
+
+func (f *repeatedCharsFilter) Check(content string) (string, string) {
+	var b strings.Builder
+	var prev rune = -1
+	run := 0
+	tripped := false
+	for _, r := range content {
+		if r == prev {
+			run++
+		} else {
+			prev, run = r, 1
+		}
+		if run > f.maxRun && !unicode.IsDigit(r) && !unicode.IsSpace(r) {
+			tripped = true
+			continue
+		}
+		b.WriteRune(r)
+	}
+	if !tripped {
+		return "", content
+	}
+	return "message repeats the same character too many times", b.String()
+}
+
+// capsFilter limits shouting
+type capsFilter struct {
+	rule
+	minLetters int
+	maxRatio   float64
+}
+
+func (f *capsFilter) Name() string { return "caps" }
+
+func (f *capsFilter) Check(content string) (string, string) {
+	letters, upper := 0, 0
+	for _, r := range content {
+		if unicode.IsLetter(r) {
+			letters++
+			if unicode.IsUpper(r) {
+				upper++
+			}
+		}
+	}
+	if letters < f.minLetters || float64(upper)/float64(letters) <= f.maxRatio {
+		return "", content
+	}
+	return "message uses too many capital letters", strings.ToLower(content)
+}
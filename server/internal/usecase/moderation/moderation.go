@@ -0,0 +1,125 @@
+package moderation
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/google/uuid"
+
+	"voice-chat/internal/domain/entity"
+	"voice-chat/internal/domain/repository"
+)
+
+var ErrMessageBlocked = errors.New("message blocked")
+
+// ReviewInput represents a chat message about to be stored
+type ReviewInput struct {
+	RoomID    string
+	MessageID string
+	UserID    string
+	UserName  string
+	IP        string
+	Content   string
+	RoomName  string // Set for channels that aren't rooms, such as the lobby chat
+}
+
+// ModerationUseCase runs chat messages through the filters configured for their room
+type ModerationUseCase struct {
+	roomRepo     repository.RoomRepository
+	activityRepo repository.ActivityRepository
+	config       *entity.ModerationConfig
+	chains       map[string]Chain // lowercase room name -> chain, built on first use
+	mu           sync.RWMutex
+}
+
+// NewModerationUseCase creates a new ModerationUseCase
+func NewModerationUseCase(
+	config *entity.ModerationConfig,
+	roomRepo repository.RoomRepository,
+	activityRepo repository.ActivityRepository,
+) *ModerationUseCase {
+	return &ModerationUseCase{
+		roomRepo:     roomRepo,
+		activityRepo: activityRepo,
+		config:       config,
+		chains:       make(map[string]Chain),
+	}
+}
+
+// SetConfig replaces the filter settings, e.g. after the moderation file is reloaded
+func (uc *ModerationUseCase) SetConfig(config *entity.ModerationConfig) {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	uc.config = config
+	uc.chains = make(map[string]Chain)
+}
+
+// chain returns the filter chain for a room
+func (uc *ModerationUseCase) chain(roomName string) Chain {
+	key := strings.ToLower(roomName)
+
+	uc.mu.RLock()
+	chain, exists := uc.chains[key]
+	uc.mu.RUnlock()
+	if exists {
+		return chain
+	}
+
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+	chain = NewChain(uc.config.ForRoom(roomName))
+	uc.chains[key] = chain
+	return chain
+}
+
+// Review runs a message through its room's filters. Blocked messages return an error wrapping
+// ErrMessageBlocked; flagged and warned messages are recorded in the activity log.
+func (uc *ModerationUseCase) Review(input ReviewInput) (*Result, error) {
+	roomName := input.RoomName
+	if roomName == "" {
+		if room, err := uc.roomRepo.GetByID(input.RoomID); err == nil && room != nil {
+			roomName = room.Name
+		}
+	}
+
+	result := uc.chain(roomName).Run(input.Content)
+	if result.Blocked != nil {
+		return result, fmt.Errorf("%w: %s", ErrMessageBlocked, result.Blocked.Reason)
+	}
+
+	uc.logViolations(entity.ActivityTypeChatFlag, result.Flagged, input, roomName)
+	uc.logViolations(entity.ActivityTypeChatWarn, result.Warnings, input, roomName)
+
+	return result, nil
+}
+
+func (uc *ModerationUseCase) logViolations(activityType entity.ActivityType, violations []Violation, input ReviewInput, roomName string) {
+	if uc.activityRepo == nil || len(violations) == 0 {
+		return
+	}
+
+	filters := make([]string, len(violations))
+	reasons := make([]string, len(violations))
+	for i, v := range violations {
+		filters[i] = v.Filter
+		reasons[i] = v.Reason
+	}
+
+	activity := entity.NewActivityLog(
+		uuid.New().String(),
+		activityType,
+		input.UserID,
+		input.UserName,
+		input.RoomID,
+		roomName,
+		input.IP,
+	)
+	activity.AddDetail("message_id", input.MessageID)
+	activity.AddDetail("content", input.Content)
+	activity.AddDetail("filters", filters)
+	activity.AddDetail("reasons", reasons)
+	_ = uc.activityRepo.Log(activity)
+}
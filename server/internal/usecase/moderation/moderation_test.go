@@ -0,0 +1,83 @@
+package moderation
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"voice-chat/internal/domain/entity"
+	"voice-chat/internal/infrastructure/persistence"
+)
+
+func setupModeration(config *entity.ModerationConfig) (*ModerationUseCase, *persistence.InMemoryActivityRepository) {
+	roomRepo := persistence.NewInMemoryRoomRepository()
+	_ = roomRepo.Create(entity.NewRoom("lobby", "Lobby", entity.RoomTypePublic, "owner"))
+	_ = roomRepo.Create(entity.NewRoom("games", "Games", entity.RoomTypePublic, "owner"))
+
+	activityRepo := persistence.NewInMemoryActivityRepository()
+	return NewModerationUseCase(config, roomRepo, activityRepo), activityRepo
+}
+
+func TestModerationUseCase_RoomOverrides(t *testing.T) {
+	config := &entity.ModerationConfig{
+		Default: entity.ModerationPolicy{
+			Links: &entity.LinkRule{Action: entity.ModerationActionBlock, Deny: []string{"example.com"}},
+		},
+		Rooms: map[string]entity.ModerationPolicy{
+			"games": {Links: &entity.LinkRule{Action: entity.ModerationActionFlag, Deny: []string{"example.com"}}},
+		},
+	}
+	assert.NoError(t, config.Normalize())
+	uc, activityRepo := setupModeration(config)
+
+	_, err := uc.Review(ReviewInput{RoomID: "lobby", UserID: "u1", Content: "https://example.com"})
+	assert.True(t, errors.Is(err, ErrMessageBlocked))
+
+	// Room names match case-insensitively
+	result, err := uc.Review(ReviewInput{RoomID: "games", MessageID: "m1", UserID: "u1", Content: "https://example.com"})
+	assert.NoError(t, err)
+	assert.Len(t, result.Flagged, 1)
+
+	logged, _ := activityRepo.GetByType(entity.ActivityTypeChatFlag)
+	assert.Len(t, logged, 1)
+	assert.Equal(t, "Games", logged[0].RoomName)
+	assert.Equal(t, "m1", logged[0].Details["message_id"])
+}
+
+func TestModerationUseCase_SetConfig(t *testing.T) {
+	uc, _ := setupModeration(entity.DefaultModerationConfig())
+
+	result, err := uc.Review(ReviewInput{RoomID: "lobby", Content: "HELLO EVERYONE"})
+	assert.NoError(t, err)
+	assert.Empty(t, result.Warnings)
+
+	// Reloading drops the cached chains
+	uc.SetConfig(&entity.ModerationConfig{
+		Default: entity.ModerationPolicy{
+			Caps: &entity.CapsRule{Action: entity.ModerationActionWarn, MinLetters: 5, MaxRatio: 0.5},
+		},
+	})
+
+	result, err = uc.Review(ReviewInput{RoomID: "lobby", Content: "HELLO EVERYONE"})
+	assert.NoError(t, err)
+	assert.Len(t, result.Warnings, 1)
+}
+
+func TestModerationConfig_Normalize(t *testing.T) {
+	config := &entity.ModerationConfig{
+		Default: entity.ModerationPolicy{
+			MaxLength: &entity.MaxLengthRule{},
+			Invites:   &entity.InviteRule{Action: entity.ModerationActionMask},
+		},
+	}
+	assert.NoError(t, config.Normalize())
+	assert.Equal(t, entity.ModerationActionBlock, config.Default.MaxLength.Action)
+	assert.Equal(t, entity.DefaultMaxMessageLength, config.Default.MaxLength.Limit)
+	assert.Equal(t, entity.DefaultInvitePatterns, config.Default.Invites.Patterns)
+
+	config.Rooms = map[string]entity.ModerationPolicy{
+		"Lobby": {Caps: &entity.CapsRule{Action: "shout"}},
+	}
+	assert.True(t, errors.Is(config.Normalize(), entity.ErrInvalidModerationPolicy))
+}
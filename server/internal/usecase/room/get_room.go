@@ -1,16 +1,20 @@
 package room
 
 import (
+	"time"
+
 	"voice-chat/internal/domain/entity"
 	"voice-chat/internal/domain/repository"
 )
 
 // ParticipantInfo represents participant info for preview
 type ParticipantInfo struct {
-	ID       string `json:"id"`
-	Name     string `json:"name"`
-	IsMuted  bool   `json:"is_muted"`
-	IsAdmin  bool   `json:"is_admin"`
+	ID         string `json:"id"`
+	Name       string `json:"name"`
+	IsMuted    bool   `json:"is_muted"`
+	IsAdmin    bool   `json:"is_admin"`
+	IsListener bool   `json:"is_listener"`
+	IsAFK      bool   `json:"is_afk"`
 }
 
 // GetRoomInput represents the input for getting room info
@@ -21,13 +25,19 @@ type GetRoomInput struct {
 
 // GetRoomOutput represents the room preview information
 type GetRoomOutput struct {
-	ID           string             `json:"id"`
-	Name         string             `json:"name"`
-	Type         entity.RoomType    `json:"type"`
-	Capacity     int                `json:"capacity"`
-	Participants []*ParticipantInfo `json:"participants"`
-	CanJoin      bool               `json:"can_join"`
-	IsClosed     bool               `json:"is_closed"`
+	ID           string                  `json:"id"`
+	Name         string                  `json:"name"`
+	Type         entity.RoomType         `json:"type"`
+	Capacity     int                     `json:"capacity"`
+	Participants []*ParticipantInfo      `json:"participants"`
+	CanJoin      bool                    `json:"can_join"`
+	IsClosed     bool                    `json:"is_closed"`
+	VoicePolicy  entity.VoicePolicy      `json:"voice_policy"`
+	Description  string                  `json:"description,omitempty"`
+	Category     string                  `json:"category,omitempty"`
+	SlowMode     time.Duration           `json:"slow_mode"`
+	Topic        string                  `json:"topic,omitempty"`
+	Pinned       []*entity.PinnedMessage `json:"pinned,omitempty"` // Left out for private rooms
 }
 
 // GetRoomUseCase handles getting room information
@@ -78,11 +88,23 @@ func (uc *GetRoomUseCase) Execute(input GetRoomInput) (*GetRoomOutput, error) {
 				Name:    p.Name,
 				IsMuted: p.IsMuted,
 				IsAdmin: p.IsAdmin,
+				IsAFK:   p.IsAFK,
 			})
 		}
 	}
 
-	return &GetRoomOutput{
+	// Add visible listeners (flagged so clients can show them apart)
+	for _, l := range room.Listeners {
+		if !l.IsStealth {
+			participants = append(participants, &ParticipantInfo{
+				ID:         l.ID,
+				Name:       l.Name,
+				IsListener: true,
+			})
+		}
+	}
+
+	output := &GetRoomOutput{
 		ID:           room.ID,
 		Name:         room.Name,
 		Type:         room.Type,
@@ -90,5 +112,16 @@ func (uc *GetRoomUseCase) Execute(input GetRoomInput) (*GetRoomOutput, error) {
 		Participants: participants,
 		CanJoin:      room.CanJoin(),
 		IsClosed:     room.IsClosed,
-	}, nil
+		VoicePolicy:  room.VoicePolicy,
+		Description:  room.Description,
+		Category:     room.Category,
+		SlowMode:     room.GetSlowMode(),
+		Topic:        room.GetTopic(),
+	}
+
+	// Pins quote chat, which only members of a private room may read
+	if room.IsPublic() {
+		output.Pinned = room.GetPinned()
+	}
+	return output, nil
 }
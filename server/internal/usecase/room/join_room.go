@@ -95,8 +95,8 @@ func (uc *JoinRoomUseCase) Execute(input JoinRoomInput) (*JoinRoomOutput, error)
 	}
 	uniqueName := uc.nameGen.GenerateUniqueName(input.UserName, existingNames)
 
-	// Create user
-	user := entity.NewUser(input.UserID, uniqueName, input.IP, input.VoiceMode)
+	// Create user (the room's voice policy may override the requested mode)
+	user := entity.NewUser(input.UserID, uniqueName, input.IP, room.VoicePolicy.Apply(input.VoiceMode))
 	user.IsStealth = input.Stealth
 
 	// Add user to room
@@ -126,7 +126,7 @@ func (uc *JoinRoomUseCase) Execute(input JoinRoomInput) (*JoinRoomOutput, error)
 			room.Name,
 			input.IP,
 		)
-		activity.AddDetail("voice_mode", string(input.VoiceMode))
+		activity.AddDetail("voice_mode", string(user.VoiceMode))
 		activity.AddDetail("stealth", input.Stealth)
 		_ = uc.activityRepo.Log(activity)
 
@@ -135,8 +135,9 @@ func (uc *JoinRoomUseCase) Execute(input JoinRoomInput) (*JoinRoomOutput, error)
 		uc.activityRepo.UpdatePeakUsers(totalUsers)
 	}
 
-	// Get visible participants for the user
+	// Get visible participants and listeners for the user
 	participants := room.GetVisibleParticipants(user)
+	participants = append(participants, room.GetVisibleListeners(user)...)
 
 	return &JoinRoomOutput{
 		Room:         room,
@@ -1,6 +1,8 @@
 package room
 
 import (
+	"sort"
+
 	"voice-chat/internal/domain/entity"
 	"voice-chat/internal/domain/repository"
 )
@@ -13,6 +15,10 @@ type RoomSummary struct {
 	ParticipantCount int             `json:"participant_count"`
 	Capacity         int             `json:"capacity"`
 	IsFull           bool            `json:"is_full"`
+	VoicePolicy      string          `json:"voice_policy"`
+	Description      string          `json:"description,omitempty"`
+	Category         string          `json:"category,omitempty"`
+	SortOrder        int             `json:"sort_order"`
 }
 
 // ListRoomsOutput represents the output of listing rooms
@@ -39,19 +45,14 @@ func (uc *ListRoomsUseCase) Execute() (*ListRoomsOutput, error) {
 		return nil, err
 	}
 
+	sortRooms(rooms)
+
 	summaries := make([]*RoomSummary, 0, len(rooms))
 	for _, room := range rooms {
 		if room.IsClosed {
 			continue
 		}
-		summaries = append(summaries, &RoomSummary{
-			ID:               room.ID,
-			Name:             room.Name,
-			Type:             room.Type,
-			ParticipantCount: room.VisibleParticipantCount(),
-			Capacity:         room.Capacity,
-			IsFull:           room.IsFull(),
-		})
+		summaries = append(summaries, toRoomSummary(room, room.VisibleParticipantCount()))
 	}
 
 	return &ListRoomsOutput{Rooms: summaries}, nil
@@ -64,17 +65,42 @@ func (uc *ListRoomsUseCase) ExecuteAll() (*ListRoomsOutput, error) {
 		return nil, err
 	}
 
+	sortRooms(rooms)
+
 	summaries := make([]*RoomSummary, 0, len(rooms))
 	for _, room := range rooms {
-		summaries = append(summaries, &RoomSummary{
-			ID:               room.ID,
-			Name:             room.Name,
-			Type:             room.Type,
-			ParticipantCount: room.ParticipantCount(),
-			Capacity:         room.Capacity,
-			IsFull:           room.IsFull(),
-		})
+		summaries = append(summaries, toRoomSummary(room, room.ParticipantCount()))
 	}
 
 	return &ListRoomsOutput{Rooms: summaries}, nil
 }
+
+// toRoomSummary builds a RoomSummary with the given participant count
+func toRoomSummary(room *entity.Room, participantCount int) *RoomSummary {
+	return &RoomSummary{
+		ID:               room.ID,
+		Name:             room.Name,
+		Type:             room.Type,
+		ParticipantCount: participantCount,
+		Capacity:         room.Capacity,
+		IsFull:           room.IsFull(),
+		VoicePolicy:      string(room.VoicePolicy),
+		Description:      room.Description,
+		Category:         room.Category,
+		SortOrder:        room.SortOrder,
+	}
+}
+
+// sortRooms orders persistent rooms by sort order first, then everything else by name
+func sortRooms(rooms []*entity.Room) {
+	sort.SliceStable(rooms, func(i, j int) bool {
+		a, b := rooms[i], rooms[j]
+		if a.Persistent != b.Persistent {
+			return a.Persistent
+		}
+		if a.Persistent && a.SortOrder != b.SortOrder {
+			return a.SortOrder < b.SortOrder
+		}
+		return a.Name < b.Name
+	})
+}
@@ -0,0 +1,225 @@
+package room
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/google/uuid"
+
+	"voice-chat/internal/domain/entity"
+	"voice-chat/internal/domain/repository"
+)
+
+var (
+	ErrListenNotAllowed   = errors.New("your role is not allowed to listen to other rooms")
+	ErrListenPrivateRoom  = errors.New("private rooms can only be listened to by admins")
+	ErrListenLimitReached = errors.New("maximum number of listened rooms reached")
+)
+
+// ListenRoomInput represents the input for listening to an additional room
+type ListenRoomInput struct {
+	RoomName string
+	UserID   string
+	UserName string
+	IP       string
+	Role     entity.UserRole
+	Stealth  bool // Admin only
+}
+
+// ListenRoomOutput represents the output after starting to listen to a room
+type ListenRoomOutput struct {
+	Room         *entity.Room
+	Listener     *entity.User
+	Participants []*entity.User
+}
+
+// UnlistenRoomInput represents the input for stopping listening to a room
+type UnlistenRoomInput struct {
+	RoomID string
+	UserID string
+}
+
+// ListenRoomUseCase handles listen-only access to rooms other than the user's primary room
+type ListenRoomUseCase struct {
+	roomRepo     repository.RoomRepository
+	banRepo      repository.BanRepository
+	activityRepo repository.ActivityRepository
+	nameGen      *NameGenerator
+	allowedRoles map[entity.UserRole]bool
+	maxRooms     int
+	mu           sync.RWMutex // protects allowedRoles
+}
+
+// NewListenRoomUseCase creates a new ListenRoomUseCase
+func NewListenRoomUseCase(
+	roomRepo repository.RoomRepository,
+	banRepo repository.BanRepository,
+	activityRepo repository.ActivityRepository,
+	allowedRoles []string,
+	maxRooms int,
+) *ListenRoomUseCase {
+	uc := &ListenRoomUseCase{
+		roomRepo:     roomRepo,
+		banRepo:      banRepo,
+		activityRepo: activityRepo,
+		nameGen:      NewNameGenerator(),
+		maxRooms:     maxRooms,
+	}
+	uc.SetAllowedRoles(allowedRoles)
+	return uc
+}
+
+// SetAllowedRoles replaces the roles permitted to listen to additional rooms
+func (uc *ListenRoomUseCase) SetAllowedRoles(roles []string) {
+	allowed := make(map[entity.UserRole]bool, len(roles))
+	for _, role := range roles {
+		allowed[entity.UserRole(role)] = true
+	}
+
+	uc.mu.Lock()
+	uc.allowedRoles = allowed
+	uc.mu.Unlock()
+}
+
+// AllowedRoles returns the roles permitted to listen to additional rooms
+func (uc *ListenRoomUseCase) AllowedRoles() []string {
+	uc.mu.RLock()
+	defer uc.mu.RUnlock()
+
+	roles := make([]string, 0, len(uc.allowedRoles))
+	for role := range uc.allowedRoles {
+		roles = append(roles, string(role))
+	}
+	return roles
+}
+
+// isRoleAllowed checks if a role may listen to additional rooms (admins always can)
+func (uc *ListenRoomUseCase) isRoleAllowed(role entity.UserRole) bool {
+	if role == entity.UserRoleAdmin {
+		return true
+	}
+
+	uc.mu.RLock()
+	defer uc.mu.RUnlock()
+	return uc.allowedRoles[role]
+}
+
+// Listen adds the user as a listener to an existing room
+func (uc *ListenRoomUseCase) Listen(input ListenRoomInput) (*ListenRoomOutput, error) {
+	if !uc.isRoleAllowed(input.Role) {
+		return nil, ErrListenNotAllowed
+	}
+
+	if uc.banRepo != nil && uc.banRepo.IsBanned(input.IP) {
+		return nil, ErrUserBanned
+	}
+
+	// Listening never creates rooms
+	room, err := uc.roomRepo.GetByName(input.RoomName)
+	if err != nil || room == nil {
+		return nil, ErrRoomNotFound
+	}
+
+	isAdmin := input.Role == entity.UserRoleAdmin
+	if !room.IsPublic() && !isAdmin {
+		return nil, ErrListenPrivateRoom
+	}
+
+	if uc.maxRooms > 0 && uc.countListening(input.UserID) >= uc.maxRooms {
+		return nil, ErrListenLimitReached
+	}
+
+	// Listener names must not clash with anyone already in the room
+	existing := room.GetAllParticipants()
+	existingNames := make([]string, 0, len(existing))
+	for _, u := range existing {
+		existingNames = append(existingNames, u.Name)
+	}
+	uniqueName := uc.nameGen.GenerateUniqueName(input.UserName, existingNames)
+
+	listener := entity.NewUser(input.UserID, uniqueName, input.IP, entity.VoiceModePTT)
+	listener.IsAdmin = isAdmin
+	listener.IsStealth = input.Stealth && isAdmin // Stealth rules: admins only
+
+	if err := room.AddListener(listener); err != nil {
+		return nil, err
+	}
+
+	if err := uc.roomRepo.Update(room); err != nil {
+		room.RemoveListener(listener.ID)
+		return nil, err
+	}
+
+	// Log activity
+	if uc.activityRepo != nil {
+		activity := entity.NewActivityLog(
+			uuid.New().String(),
+			entity.ActivityTypeRoomListen,
+			listener.ID,
+			listener.Name,
+			room.ID,
+			room.Name,
+			input.IP,
+		)
+		activity.AddDetail("stealth", listener.IsStealth)
+		_ = uc.activityRepo.Log(activity)
+	}
+
+	participants := room.GetVisibleParticipants(listener)
+	participants = append(participants, room.GetVisibleListeners(listener)...)
+
+	return &ListenRoomOutput{
+		Room:         room,
+		Listener:     listener,
+		Participants: participants,
+	}, nil
+}
+
+// Unlisten removes the user as a listener from a room
+func (uc *ListenRoomUseCase) Unlisten(input UnlistenRoomInput) (*entity.Room, *entity.User, error) {
+	room, err := uc.roomRepo.GetByID(input.RoomID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	listener, err := room.RemoveListener(input.UserID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := uc.roomRepo.Update(room); err != nil {
+		return nil, nil, err
+	}
+
+	// Log activity
+	if uc.activityRepo != nil {
+		activity := entity.NewActivityLog(
+			uuid.New().String(),
+			entity.ActivityTypeRoomUnlisten,
+			listener.ID,
+			listener.Name,
+			room.ID,
+			room.Name,
+			listener.IP,
+		)
+		_ = uc.activityRepo.Log(activity)
+	}
+
+	return room, listener, nil
+}
+
+// countListening returns the number of rooms the user is currently listening to
+func (uc *ListenRoomUseCase) countListening(userID string) int {
+	rooms, err := uc.roomRepo.GetAll()
+	if err != nil {
+		return 0
+	}
+
+	count := 0
+	for _, room := range rooms {
+		if room.IsListening(userID) {
+			count++
+		}
+	}
+	return count
+}
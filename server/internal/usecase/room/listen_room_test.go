@@ -0,0 +1,132 @@
+package room
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"voice-chat/internal/domain/entity"
+	"voice-chat/internal/infrastructure/persistence"
+)
+
+func TestListenRoomUseCase_Listen_Success(t *testing.T) {
+	roomRepo := persistence.NewInMemoryRoomRepository()
+	banRepo := persistence.NewInMemoryBanRepository()
+	activityRepo := persistence.NewInMemoryActivityRepository()
+	roomRepo.Create(entity.NewRoom("room-1", "Team A", entity.RoomTypePublic, "admin"))
+	uc := NewListenRoomUseCase(roomRepo, banRepo, activityRepo, []string{"user"}, 3)
+
+	result, err := uc.Listen(ListenRoomInput{
+		RoomName: "Team A",
+		UserID:   "user-1",
+		UserName: "Caster",
+		IP:       "192.168.1.1",
+		Role:     entity.UserRoleUser,
+	})
+
+	assert.NoError(t, err)
+	assert.True(t, result.Listener.IsListener)
+	assert.True(t, result.Room.IsListening("user-1"))
+	assert.Len(t, result.Participants, 1)
+	assert.True(t, result.Participants[0].IsListener)
+}
+
+func TestListenRoomUseCase_Listen_RoleNotAllowed(t *testing.T) {
+	roomRepo := persistence.NewInMemoryRoomRepository()
+	roomRepo.Create(entity.NewRoom("room-1", "Team A", entity.RoomTypePublic, "admin"))
+	uc := NewListenRoomUseCase(roomRepo, nil, nil, []string{"admin"}, 3)
+
+	_, err := uc.Listen(ListenRoomInput{
+		RoomName: "Team A",
+		UserID:   "user-1",
+		UserName: "Caster",
+		Role:     entity.UserRoleUser,
+	})
+
+	assert.ErrorIs(t, err, ErrListenNotAllowed)
+
+	uc.SetAllowedRoles([]string{"user"})
+	_, err = uc.Listen(ListenRoomInput{
+		RoomName: "Team A",
+		UserID:   "user-1",
+		UserName: "Caster",
+		Role:     entity.UserRoleUser,
+	})
+
+	assert.NoError(t, err)
+}
+
+func TestListenRoomUseCase_Listen_PrivateRoom(t *testing.T) {
+	roomRepo := persistence.NewInMemoryRoomRepository()
+	roomRepo.Create(entity.NewRoom("room-1", "Secret", entity.RoomTypePrivate, "admin"))
+	uc := NewListenRoomUseCase(roomRepo, nil, nil, []string{"user"}, 3)
+
+	_, err := uc.Listen(ListenRoomInput{
+		RoomName: "Secret",
+		UserID:   "user-1",
+		UserName: "Caster",
+		Role:     entity.UserRoleUser,
+	})
+	assert.ErrorIs(t, err, ErrListenPrivateRoom)
+
+	_, err = uc.Listen(ListenRoomInput{
+		RoomName: "Secret",
+		UserID:   "admin-1",
+		UserName: "Admin",
+		Role:     entity.UserRoleAdmin,
+	})
+	assert.NoError(t, err)
+}
+
+func TestListenRoomUseCase_Listen_DoesNotCreateRoom(t *testing.T) {
+	roomRepo := persistence.NewInMemoryRoomRepository()
+	uc := NewListenRoomUseCase(roomRepo, nil, nil, []string{"user"}, 3)
+
+	_, err := uc.Listen(ListenRoomInput{
+		RoomName: "Nowhere",
+		UserID:   "user-1",
+		UserName: "Caster",
+		Role:     entity.UserRoleUser,
+	})
+
+	assert.ErrorIs(t, err, ErrRoomNotFound)
+	assert.False(t, roomRepo.Exists("Nowhere"))
+}
+
+func TestListenRoomUseCase_Listen_LimitReached(t *testing.T) {
+	roomRepo := persistence.NewInMemoryRoomRepository()
+	roomRepo.Create(entity.NewRoom("room-1", "Team A", entity.RoomTypePublic, "admin"))
+	roomRepo.Create(entity.NewRoom("room-2", "Team B", entity.RoomTypePublic, "admin"))
+	uc := NewListenRoomUseCase(roomRepo, nil, nil, []string{"user"}, 1)
+
+	_, err := uc.Listen(ListenRoomInput{RoomName: "Team A", UserID: "user-1", UserName: "Caster", Role: entity.UserRoleUser})
+	assert.NoError(t, err)
+
+	_, err = uc.Listen(ListenRoomInput{RoomName: "Team B", UserID: "user-1", UserName: "Caster", Role: entity.UserRoleUser})
+	assert.ErrorIs(t, err, ErrListenLimitReached)
+}
+
+func TestListenRoomUseCase_Listen_StealthAdminOnly(t *testing.T) {
+	roomRepo := persistence.NewInMemoryRoomRepository()
+	roomRepo.Create(entity.NewRoom("room-1", "Team A", entity.RoomTypePublic, "admin"))
+	uc := NewListenRoomUseCase(roomRepo, nil, nil, []string{"user"}, 3)
+
+	userResult, _ := uc.Listen(ListenRoomInput{RoomName: "Team A", UserID: "user-1", UserName: "Caster", Role: entity.UserRoleUser, Stealth: true})
+	adminResult, _ := uc.Listen(ListenRoomInput{RoomName: "Team A", UserID: "admin-1", UserName: "Admin", Role: entity.UserRoleAdmin, Stealth: true})
+
+	assert.False(t, userResult.Listener.IsStealth)
+	assert.True(t, adminResult.Listener.IsStealth)
+}
+
+func TestListenRoomUseCase_Unlisten(t *testing.T) {
+	roomRepo := persistence.NewInMemoryRoomRepository()
+	roomRepo.Create(entity.NewRoom("room-1", "Team A", entity.RoomTypePublic, "admin"))
+	uc := NewListenRoomUseCase(roomRepo, nil, nil, []string{"user"}, 3)
+	uc.Listen(ListenRoomInput{RoomName: "Team A", UserID: "user-1", UserName: "Caster", Role: entity.UserRoleUser})
+
+	room, listener, err := uc.Unlisten(UnlistenRoomInput{RoomID: "room-1", UserID: "user-1"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Caster", listener.Name)
+	assert.False(t, room.IsListening("user-1"))
+}
@@ -0,0 +1,195 @@
+package room
+
+import (
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+
+	"voice-chat/internal/domain/entity"
+	"voice-chat/internal/domain/repository"
+	"voice-chat/internal/usecase/chat"
+)
+
+// DefaultPresets returns the presets used when no rooms file is configured
+func DefaultPresets() []*entity.RoomPreset {
+	return []*entity.RoomPreset{
+		{
+			Name:        "Lobby",
+			Type:        entity.RoomTypePublic,
+			Capacity:    100,
+			VoicePolicy: entity.VoicePolicyAny,
+		},
+	}
+}
+
+// presetRoomNamespace scopes the room IDs derived from preset names
+var presetRoomNamespace = uuid.MustParse("7aadfcd9-ccc8-4436-a4b9-125c2796990e")
+
+// presetRoomID derives a preset room's ID from its name, so the room keeps its ID, and with it
+// the chat history of persistent chat stores, across restarts
+func presetRoomID(name string) string {
+	return uuid.NewSHA1(presetRoomNamespace, []byte(name)).String()
+}
+
+// SyncPresetsOutput reports what a reconcile changed
+type SyncPresetsOutput struct {
+	Created []*entity.Room
+	Updated []*entity.Room
+	Closed  []*entity.Room // Removed from the file but still occupied
+	Deleted []*entity.Room // Removed from the file and empty
+}
+
+// HasChanges checks if the reconcile changed any room
+func (o *SyncPresetsOutput) HasChanges() bool {
+	return len(o.Created)+len(o.Updated)+len(o.Closed)+len(o.Deleted) > 0
+}
+
+// SyncPresetsUseCase reconciles rooms against the configured presets
+type SyncPresetsUseCase struct {
+	roomRepo     repository.RoomRepository
+	chatRepo     repository.ChatRepository
+	retentionUC  *chat.RetentionUseCase
+	blobStore    repository.BlobStore
+	activityRepo repository.ActivityRepository
+}
+
+// NewSyncPresetsUseCase creates a new SyncPresetsUseCase
+func NewSyncPresetsUseCase(
+	roomRepo repository.RoomRepository,
+	chatRepo repository.ChatRepository,
+	retentionUC *chat.RetentionUseCase,
+	blobStore repository.BlobStore,
+	activityRepo repository.ActivityRepository,
+) *SyncPresetsUseCase {
+	return &SyncPresetsUseCase{
+		roomRepo:     roomRepo,
+		chatRepo:     chatRepo,
+		retentionUC:  retentionUC,
+		blobStore:    blobStore,
+		activityRepo: activityRepo,
+	}
+}
+
+// Execute creates missing preset rooms, updates changed ones and closes rooms no longer in presets
+func (uc *SyncPresetsUseCase) Execute(presets []*entity.RoomPreset) (*SyncPresetsOutput, error) {
+	output := &SyncPresetsOutput{}
+	wanted := make(map[string]bool, len(presets))
+
+	for _, preset := range presets {
+		wanted[preset.Name] = true
+
+		existing, err := uc.roomRepo.GetByName(preset.Name)
+		if err != nil || existing == nil {
+			created := entity.NewRoom(presetRoomID(preset.Name), preset.Name, preset.Type, entity.PresetRoomCreator)
+			created.ApplyPreset(preset)
+			if err := uc.roomRepo.Create(created); err != nil {
+				return output, err
+			}
+			output.Created = append(output.Created, created)
+			uc.applyRetention(created)
+			uc.logActivity(entity.ActivityTypeRoomCreate, created, "created")
+			continue
+		}
+
+		if preset.Matches(existing) {
+			continue
+		}
+
+		// Also adopts a user-created room that now has a preset with the same name
+		existing.ApplyPreset(preset)
+		if err := uc.roomRepo.Update(existing); err != nil {
+			return output, err
+		}
+		output.Updated = append(output.Updated, existing)
+		uc.applyRetention(existing)
+		uc.logActivity(entity.ActivityTypeRoomUpdate, existing, "updated")
+	}
+
+	rooms, err := uc.roomRepo.GetAll()
+	if err != nil {
+		return output, err
+	}
+
+	for _, r := range rooms {
+		if !r.Persistent || wanted[r.Name] {
+			continue
+		}
+
+		// Occupied rooms stay open for current participants and are cleaned up once empty;
+		// listeners lose access straight away
+		r.RetirePreset()
+		r.RemoveAllListeners()
+		if !r.IsEmpty() {
+			if err := uc.roomRepo.Update(r); err != nil {
+				return output, err
+			}
+			output.Closed = append(output.Closed, r)
+			uc.logActivity(entity.ActivityTypeRoomClose, r, "closed")
+			continue
+		}
+
+		uc.releaseChat(r)
+		if uc.blobStore != nil {
+			if err := uc.blobStore.DeletePrefix(entity.RoomAttachmentPrefix(r.ID)); err != nil {
+				log.Printf("Error deleting attachments for room %s: %v", r.Name, err)
+			}
+		}
+		if err := uc.roomRepo.Delete(r.ID); err != nil {
+			return output, err
+		}
+		output.Deleted = append(output.Deleted, r)
+		uc.logActivity(entity.ActivityTypeRoomClose, r, "deleted")
+	}
+
+	return output, nil
+}
+
+// applyRetention hands a preset room's chat limits to the chat store
+func (uc *SyncPresetsUseCase) applyRetention(r *entity.Room) {
+	if uc.retentionUC == nil {
+		return
+	}
+	if err := uc.retentionUC.Apply(r); err != nil {
+		log.Printf("Error applying chat retention for room %s: %v", r.Name, err)
+	}
+}
+
+// releaseChat archives a deleted room's chat if its policy asks for it, then deletes the chat
+func (uc *SyncPresetsUseCase) releaseChat(r *entity.Room) {
+	if uc.retentionUC == nil {
+		if err := uc.chatRepo.DeleteRoomMessages(r.ID); err != nil {
+			log.Printf("Error deleting chat messages for room %s: %v", r.Name, err)
+		}
+		return
+	}
+
+	archive, err := uc.retentionUC.ReleaseRoom(r, time.Now())
+	if err != nil {
+		log.Printf("Error releasing chat for room %s: %v", r.Name, err)
+		return
+	}
+	if archive != nil {
+		log.Printf("Archived %d chat messages of room %s", archive.MessageCount, r.Name)
+	}
+}
+
+// logActivity records a preset change
+func (uc *SyncPresetsUseCase) logActivity(activityType entity.ActivityType, r *entity.Room, action string) {
+	if uc.activityRepo == nil {
+		return
+	}
+
+	activity := entity.NewActivityLog(
+		uuid.New().String(),
+		activityType,
+		entity.PresetRoomCreator,
+		"",
+		r.ID,
+		r.Name,
+		"",
+	)
+	activity.AddDetail("action", action)
+	activity.AddDetail("capacity", r.Capacity)
+	_ = uc.activityRepo.Log(activity)
+}
@@ -0,0 +1,141 @@
+package room
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"voice-chat/internal/domain/entity"
+	"voice-chat/internal/infrastructure/persistence"
+)
+
+func newTestPresets() []*entity.RoomPreset {
+	presets := []*entity.RoomPreset{
+		{Name: "Lobby", Capacity: 100, SortOrder: 1},
+		{Name: "Quiet Corner", Type: entity.RoomTypePublic, Capacity: 6, VoicePolicy: entity.VoicePolicyPTT, Category: "Study", SortOrder: 2},
+	}
+	for _, p := range presets {
+		p.Normalize()
+	}
+	return presets
+}
+
+func TestSyncPresetsUseCase_CreatesRooms(t *testing.T) {
+	roomRepo := persistence.NewInMemoryRoomRepository()
+	chatRepo := persistence.NewInMemoryChatRepository()
+	uc := NewSyncPresetsUseCase(roomRepo, chatRepo, nil, nil, nil)
+
+	result, err := uc.Execute(newTestPresets())
+
+	assert.NoError(t, err)
+	assert.Len(t, result.Created, 2)
+
+	quiet, err := roomRepo.GetByName("Quiet Corner")
+	assert.NoError(t, err)
+	assert.True(t, quiet.Persistent)
+	assert.Equal(t, 6, quiet.Capacity)
+	assert.Equal(t, entity.VoicePolicyPTT, quiet.VoicePolicy)
+	assert.Equal(t, entity.PresetRoomCreator, quiet.CreatedBy)
+
+	// Preset rooms get the same ID on every start
+	assert.Equal(t, presetRoomID("Quiet Corner"), quiet.ID)
+	assert.NotEqual(t, presetRoomID("Lobby"), quiet.ID)
+
+	// Running again with the same presets changes nothing
+	result, err = uc.Execute(newTestPresets())
+	assert.NoError(t, err)
+	assert.False(t, result.HasChanges())
+}
+
+func TestSyncPresetsUseCase_UpdatesRooms(t *testing.T) {
+	roomRepo := persistence.NewInMemoryRoomRepository()
+	uc := NewSyncPresetsUseCase(roomRepo, persistence.NewInMemoryChatRepository(), nil, nil, nil)
+	uc.Execute(newTestPresets())
+
+	presets := newTestPresets()
+	presets[1].Capacity = 8
+	presets[1].Description = "Keep it down"
+
+	result, err := uc.Execute(presets)
+
+	assert.NoError(t, err)
+	assert.Len(t, result.Updated, 1)
+	quiet, _ := roomRepo.GetByName("Quiet Corner")
+	assert.Equal(t, 8, quiet.Capacity)
+	assert.Equal(t, "Keep it down", quiet.Description)
+}
+
+func TestSyncPresetsUseCase_AdoptsExistingRoom(t *testing.T) {
+	roomRepo := persistence.NewInMemoryRoomRepository()
+	roomRepo.Create(entity.NewRoom("room-1", "Quiet Corner", entity.RoomTypePublic, "user-1"))
+	uc := NewSyncPresetsUseCase(roomRepo, persistence.NewInMemoryChatRepository(), nil, nil, nil)
+
+	result, err := uc.Execute(newTestPresets())
+
+	assert.NoError(t, err)
+	assert.Len(t, result.Updated, 1)
+	quiet, _ := roomRepo.GetByID("room-1")
+	assert.True(t, quiet.IsPersistent())
+}
+
+func TestSyncPresetsUseCase_RemovesRooms(t *testing.T) {
+	roomRepo := persistence.NewInMemoryRoomRepository()
+	chatRepo := persistence.NewInMemoryChatRepository()
+	uc := NewSyncPresetsUseCase(roomRepo, chatRepo, nil, nil, nil)
+	uc.Execute(newTestPresets())
+
+	// Occupy the lobby so it has to close gracefully
+	lobby, _ := roomRepo.GetByName("Lobby")
+	lobby.AddParticipant(entity.NewUser("user-1", "Alice", "192.168.1.1", entity.VoiceModePTT))
+	lobby.AddListener(entity.NewUser("user-3", "Bob", "192.168.1.2", entity.VoiceModePTT))
+	roomRepo.Create(entity.NewRoom("room-9", "Team A", entity.RoomTypePublic, "user-2"))
+
+	result, err := uc.Execute(nil)
+
+	assert.NoError(t, err)
+	assert.Len(t, result.Closed, 1)
+	assert.Len(t, result.Deleted, 1)
+	assert.Equal(t, "Lobby", result.Closed[0].Name)
+	assert.Equal(t, "Quiet Corner", result.Deleted[0].Name)
+
+	assert.True(t, lobby.IsClosed)
+	assert.False(t, lobby.IsPersistent())
+	assert.False(t, lobby.IsListening("user-3"))
+	assert.False(t, roomRepo.Exists("Quiet Corner"))
+
+	// Rooms that never came from the file are left alone
+	assert.True(t, roomRepo.Exists("Team A"))
+}
+
+func TestJoinRoomUseCase_AppliesVoicePolicy(t *testing.T) {
+	roomRepo := persistence.NewInMemoryRoomRepository()
+	userRepo := persistence.NewInMemoryUserRepository()
+	NewSyncPresetsUseCase(roomRepo, persistence.NewInMemoryChatRepository(), nil, nil, nil).Execute(newTestPresets())
+	uc := NewJoinRoomUseCase(roomRepo, userRepo, nil, nil)
+
+	result, err := uc.Execute(JoinRoomInput{
+		RoomName:  "Quiet Corner",
+		UserID:    "user-1",
+		UserName:  "Alice",
+		VoiceMode: entity.VoiceModeVAD,
+		IP:        "192.168.1.1",
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, entity.VoiceModePTT, result.User.VoiceMode)
+}
+
+func TestListRoomsUseCase_SortsPresetsFirst(t *testing.T) {
+	roomRepo := persistence.NewInMemoryRoomRepository()
+	roomRepo.Create(entity.NewRoom("room-1", "Alpha", entity.RoomTypePublic, "user-1"))
+	NewSyncPresetsUseCase(roomRepo, persistence.NewInMemoryChatRepository(), nil, nil, nil).Execute(newTestPresets())
+
+	result, err := NewListRoomsUseCase(roomRepo).Execute()
+
+	assert.NoError(t, err)
+	assert.Len(t, result.Rooms, 3)
+	assert.Equal(t, "Lobby", result.Rooms[0].Name)
+	assert.Equal(t, "Quiet Corner", result.Rooms[1].Name)
+	assert.Equal(t, "Study", result.Rooms[1].Category)
+	assert.Equal(t, "Alpha", result.Rooms[2].Name)
+}
@@ -27,6 +27,43 @@ type Config struct {
 	// Room settings
 	DefaultRoomCapacity int
 	RoomCleanupMinutes  int
+	RoomsFile           string // YAML or JSON file of persistent rooms (reloaded on SIGHUP)
+
+	// Listen-only settings (hearing additional rooms)
+	ListenRoomRoles []string // Roles allowed to listen to additional rooms (admins always can)
+	MaxListenRooms  int      // Maximum number of additional rooms a user can listen to
+
+	// Shutdown settings
+	ShutdownReconnectSeconds int // Hint sent to clients on shutdown for when to reconnect
+
+	// Chat settings
+	ChatEditWindowMinutes int    // How long authors can edit their messages (0 disables editing)
+	ReadMarkerHours       int    // How long an unused session keeps its read markers
+	DMRetentionHours      int    // How long direct messages are kept
+	SearchRetentionHours  int    // How long messages stay searchable at most (retention policies can drop them sooner)
+	ModerationFile        string // YAML or JSON file of chat filter settings (reloaded on SIGHUP)
+	FloodControlFile      string // YAML or JSON file of rate limits for incoming messages
+	UploadDir             string // Where chat attachments are stored (defaults to uploads under DataDir)
+	MaxUploadMB           int    // Largest chat attachment accepted
+	MaxPinnedMessages     int    // Pinned messages allowed per room
+	OwnerChatExport       bool   // Whether room owners may export their own room's chat (admins always can)
+	ChatArchiveDir        string // Where transcripts of archiving rooms are kept (defaults to archives under DataDir)
+	RetentionPruneMinutes int    // How often messages past their room's age limit are pruned
+
+	// Reaction settings
+	ReactionEmojis     []string // Reactions users may add (empty allows any emoji)
+	MaxReactionsPerMsg int      // Different reactions a message can have (0 = no cap)
+
+	// Lobby chat settings
+	LobbyChatEnabled         bool // Whether the café-wide lobby chat is available
+	LobbyChatCooldownSeconds int  // Minimum time between a user's lobby messages (admins are exempt)
+
+	// Locale settings
+	DefaultLocale string // Locale for clients whose languages have no messages
+	LocaleDir     string // Extra <locale>.yaml message files, added to the built-in ones
+
+	// Announcement settings
+	AnnouncementTimezone string // IANA time zone scheduled announcements run in (empty = server local time)
 
 	// Logging settings
 	LogLevel         string
@@ -38,6 +75,15 @@ type Config struct {
 	RedisPassword string
 	RedisDB       int
 
+	// Chat storage settings (without Redis)
+	ChatStore             string // "memory" or "file"; ignored when Redis is enabled
+	ChatLogDir            string // Where the file store keeps its logs (defaults to chat under DataDir)
+	ChatLogSyncMillis     int    // How often chat logs are fsynced (0 = on every write)
+	ChatLogCompactMinutes int    // How often chat logs are checked for compaction
+
+	// Data settings (for state that must survive restarts)
+	DataDir string // Directory for persisted server state (announcements, etc.)
+
 	// Webhook settings (for external notifications)
 	WebhookURL    string // External webhook URL to receive notifications
 	WebhookSecret string // HMAC secret for webhook signature verification
@@ -67,6 +113,43 @@ func Load() *Config {
 		// Room
 		DefaultRoomCapacity: getEnvInt("DEFAULT_ROOM_CAPACITY", 15),
 		RoomCleanupMinutes:  getEnvInt("ROOM_CLEANUP_MINUTES", 10),
+		RoomsFile:           getEnv("ROOMS_FILE", ""),
+
+		// Listen-only
+		ListenRoomRoles: getEnvSlice("LISTEN_ROOM_ROLES", []string{"admin"}),
+		MaxListenRooms:  getEnvInt("MAX_LISTEN_ROOMS", 3),
+
+		// Shutdown
+		ShutdownReconnectSeconds: getEnvInt("SHUTDOWN_RECONNECT_SECONDS", 15),
+
+		// Chat
+		ChatEditWindowMinutes: getEnvInt("CHAT_EDIT_WINDOW_MINUTES", 15),
+		ReadMarkerHours:       getEnvInt("READ_MARKER_HOURS", 24),
+		DMRetentionHours:      getEnvInt("DM_RETENTION_HOURS", 72),
+		SearchRetentionHours:  getEnvInt("CHAT_SEARCH_RETENTION_HOURS", 72),
+		ModerationFile:        getEnv("MODERATION_FILE", ""),
+		FloodControlFile:      getEnv("FLOOD_CONTROL_FILE", ""),
+		UploadDir:             getEnv("UPLOAD_DIR", ""),
+		MaxUploadMB:           getEnvInt("MAX_UPLOAD_MB", 8),
+		MaxPinnedMessages:     getEnvInt("MAX_PINNED_MESSAGES", 5),
+		OwnerChatExport:       getEnvBool("OWNER_CHAT_EXPORT", false),
+		ChatArchiveDir:        getEnv("CHAT_ARCHIVE_DIR", ""),
+		RetentionPruneMinutes: getEnvInt("RETENTION_PRUNE_MINUTES", 60),
+
+		// Reactions
+		ReactionEmojis:     getEnvSlice("REACTION_EMOJIS", nil),
+		MaxReactionsPerMsg: getEnvInt("MAX_REACTIONS_PER_MESSAGE", 20),
+
+		// Lobby chat
+		LobbyChatEnabled:         getEnvBool("LOBBY_CHAT_ENABLED", true),
+		LobbyChatCooldownSeconds: getEnvInt("LOBBY_CHAT_COOLDOWN_SECONDS", 3),
+
+		// Locales
+		DefaultLocale: getEnv("DEFAULT_LOCALE", "en"),
+		LocaleDir:     getEnv("LOCALE_DIR", ""),
+
+		// Announcements
+		AnnouncementTimezone: getEnv("ANNOUNCEMENT_TIMEZONE", ""),
 
 		// Logging
 		LogLevel:         getEnv("LOG_LEVEL", "info"),
@@ -78,6 +161,15 @@ func Load() *Config {
 		RedisPassword: getEnv("REDIS_PASSWORD", ""),
 		RedisDB:       getEnvInt("REDIS_DB", 0),
 
+		// Chat storage without Redis (in-memory by default)
+		ChatStore:             getEnv("CHAT_STORE", "memory"),
+		ChatLogDir:            getEnv("CHAT_LOG_DIR", ""),
+		ChatLogSyncMillis:     getEnvInt("CHAT_LOG_SYNC_MS", 500),
+		ChatLogCompactMinutes: getEnvInt("CHAT_LOG_COMPACT_MINUTES", 10),
+
+		// Data
+		DataDir: getEnv("DATA_DIR", "data"),
+
 		// Webhooks (for external notifications when users disconnect, rooms close, etc.)
 		WebhookURL:    getEnv("WEBHOOK_URL", ""),
 		WebhookSecret: getEnv("WEBHOOK_SECRET", ""),
@@ -0,0 +1,182 @@
+package cron
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrInvalidExpression = errors.New("invalid cron expression")
+
+// field describes the allowed range of one cron field
+type field struct {
+	name string
+	min  int
+	max  int
+}
+
+var fields = []field{
+	{"minute", 0, 59},
+	{"hour", 0, 23},
+	{"day of month", 1, 31},
+	{"month", 1, 12},
+	{"day of week", 0, 6},
+}
+
+// maxSearch bounds how far ahead Next looks for a matching minute
+const maxSearch = 5 * 366 * 24 * time.Hour
+
+// Schedule is a parsed five-field cron expression (minute hour day-of-month month day-of-week)
+type Schedule struct {
+	expr    string
+	minute  map[int]bool
+	hour    map[int]bool
+	dom     map[int]bool
+	month   map[int]bool
+	dow     map[int]bool
+	domStar bool
+	dowStar bool
+}
+
+// Parse parses a standard five-field cron expression.
+// Each field supports "*", single values, ranges ("1-5"), lists ("1,15") and steps ("*/10", "8-18/2").
+func Parse(expr string) (*Schedule, error) {
+	parts := strings.Fields(expr)
+	if len(parts) != len(fields) {
+		return nil, fmt.Errorf("%w: expected %d fields, got %d", ErrInvalidExpression, len(fields), len(parts))
+	}
+
+	sets := make([]map[int]bool, len(fields))
+	for i, part := range parts {
+		set, err := parseField(part, fields[i])
+		if err != nil {
+			return nil, err
+		}
+		sets[i] = set
+	}
+
+	// Sunday may be written as 7
+	if sets[4][7] {
+		sets[4][0] = true
+		delete(sets[4], 7)
+	}
+
+	return &Schedule{
+		expr:    strings.Join(parts, " "),
+		minute:  sets[0],
+		hour:    sets[1],
+		dom:     sets[2],
+		month:   sets[3],
+		dow:     sets[4],
+		domStar: parts[2] == "*",
+		dowStar: parts[4] == "*",
+	}, nil
+}
+
+// String returns the normalized expression
+func (s *Schedule) String() string {
+	return s.expr
+}
+
+// Next returns the first matching time strictly after the given time (minute precision).
+// It returns the zero time if nothing matches within five years.
+func (s *Schedule) Next(after time.Time) time.Time {
+	t := after.Truncate(time.Minute).Add(time.Minute)
+	limit := after.Add(maxSearch)
+
+	for !t.After(limit) {
+		if !s.month[int(t.Month())] {
+			// Jump to the first day of the next month
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if !s.matchesDay(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if !s.hour[t.Hour()] {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+			continue
+		}
+		if !s.minute[t.Minute()] {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+
+	return time.Time{}
+}
+
+// matchesDay applies the cron rule that day-of-month and day-of-week are OR-ed when both are restricted
+func (s *Schedule) matchesDay(t time.Time) bool {
+	domMatch := s.dom[t.Day()]
+	dowMatch := s.dow[int(t.Weekday())]
+
+	switch {
+	case s.domStar && s.dowStar:
+		return true
+	case s.domStar:
+		return dowMatch
+	case s.dowStar:
+		return domMatch
+	default:
+		return domMatch || dowMatch
+	}
+}
+
+// parseField expands one cron field into the set of values it matches
+func parseField(expr string, f field) (map[int]bool, error) {
+	upper := f.max
+	if f.name == "day of week" {
+		upper = 7 // Allow 7 as an alias for Sunday
+	}
+
+	set := make(map[int]bool)
+	for _, part := range strings.Split(expr, ",") {
+		step := 1
+		if idx := strings.Index(part, "/"); idx >= 0 {
+			n, err := strconv.Atoi(part[idx+1:])
+			if err != nil || n <= 0 {
+				return nil, fmt.Errorf("%w: bad step in %s field %q", ErrInvalidExpression, f.name, part)
+			}
+			step = n
+			part = part[:idx]
+		}
+
+		var lo, hi int
+		switch {
+		case part == "*":
+			lo, hi = f.min, f.max
+		case strings.Contains(part, "-"):
+			bounds := strings.SplitN(part, "-", 2)
+			a, errA := strconv.Atoi(bounds[0])
+			b, errB := strconv.Atoi(bounds[1])
+			if errA != nil || errB != nil {
+				return nil, fmt.Errorf("%w: bad range in %s field %q", ErrInvalidExpression, f.name, part)
+			}
+			lo, hi = a, b
+		default:
+			n, err := strconv.Atoi(part)
+			if err != nil {
+				return nil, fmt.Errorf("%w: bad value in %s field %q", ErrInvalidExpression, f.name, part)
+			}
+			lo, hi = n, n
+			if step > 1 {
+				hi = upper // "5/15" means from 5 onwards every 15
+			}
+		}
+
+		if lo < f.min || hi > upper || lo > hi {
+			return nil, fmt.Errorf("%w: %s field %q out of range %d-%d", ErrInvalidExpression, f.name, part, f.min, f.max)
+		}
+
+		for v := lo; v <= hi; v += step {
+			set[v] = true
+		}
+	}
+
+	return set, nil
+}
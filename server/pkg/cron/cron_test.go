@@ -0,0 +1,86 @@
+package cron
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse_Invalid(t *testing.T) {
+	invalid := []string{
+		"",
+		"* * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"*/0 * * * *",
+		"a * * * *",
+		"5-1 * * * *",
+	}
+
+	for _, expr := range invalid {
+		_, err := Parse(expr)
+		assert.ErrorIs(t, err, ErrInvalidExpression, expr)
+	}
+}
+
+func TestSchedule_Next_Daily(t *testing.T) {
+	s, err := Parse("0 18 * * *")
+	assert.NoError(t, err)
+
+	from := time.Date(2026, 3, 10, 12, 30, 0, 0, time.UTC)
+	assert.Equal(t, time.Date(2026, 3, 10, 18, 0, 0, 0, time.UTC), s.Next(from))
+
+	from = time.Date(2026, 3, 10, 18, 0, 0, 0, time.UTC)
+	assert.Equal(t, time.Date(2026, 3, 11, 18, 0, 0, 0, time.UTC), s.Next(from))
+}
+
+func TestSchedule_Next_Steps(t *testing.T) {
+	s, err := Parse("*/10 8-18/2 * * *")
+	assert.NoError(t, err)
+
+	from := time.Date(2026, 3, 10, 9, 55, 0, 0, time.UTC)
+	assert.Equal(t, time.Date(2026, 3, 10, 10, 0, 0, 0, time.UTC), s.Next(from))
+
+	from = time.Date(2026, 3, 10, 18, 50, 0, 0, time.UTC)
+	assert.Equal(t, time.Date(2026, 3, 11, 8, 0, 0, 0, time.UTC), s.Next(from))
+}
+
+func TestSchedule_Next_DayOfWeek(t *testing.T) {
+	// Fridays at 20:00 (2026-03-13 is a Friday)
+	s, err := Parse("0 20 * * 5")
+	assert.NoError(t, err)
+
+	from := time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)
+	assert.Equal(t, time.Date(2026, 3, 13, 20, 0, 0, 0, time.UTC), s.Next(from))
+}
+
+func TestSchedule_Next_SundayAsSeven(t *testing.T) {
+	s, err := Parse("0 12 * * 7")
+	assert.NoError(t, err)
+
+	from := time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)
+	assert.Equal(t, time.Date(2026, 3, 15, 12, 0, 0, 0, time.UTC), s.Next(from))
+}
+
+func TestSchedule_Next_DayOfMonthOrWeekday(t *testing.T) {
+	// 1st of the month OR Mondays
+	s, err := Parse("0 9 1 * 1")
+	assert.NoError(t, err)
+
+	from := time.Date(2026, 3, 10, 10, 0, 0, 0, time.UTC) // Tuesday
+	assert.Equal(t, time.Date(2026, 3, 16, 9, 0, 0, 0, time.UTC), s.Next(from))
+
+	from = time.Date(2026, 3, 30, 10, 0, 0, 0, time.UTC) // Monday after 09:00
+	assert.Equal(t, time.Date(2026, 4, 1, 9, 0, 0, 0, time.UTC), s.Next(from))
+}
+
+func TestSchedule_Next_Impossible(t *testing.T) {
+	s, err := Parse("0 0 31 2 *")
+	assert.NoError(t, err)
+
+	assert.True(t, s.Next(time.Now()).IsZero())
+}
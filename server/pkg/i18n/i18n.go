@@ -0,0 +1,203 @@
+package i18n
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed locales/*.yaml
+var builtin embed.FS
+
+// Builtin returns the catalogs shipped with the server, one <locale>.yaml file per locale
+func Builtin() fs.FS {
+	sub, _ := fs.Sub(builtin, "locales")
+	return sub
+}
+
+// Catalog holds the server's messages per locale. Each locale file groups its messages in
+// sections ("system", "errors"); a message is looked up as "<section>.<key>" and may refer
+// to parameters as {name}.
+type Catalog struct {
+	fallback string
+	messages map[string]map[string]string // locale -> key -> message
+}
+
+// Load reads every <locale>.yaml file in the given directories; later files add to and
+// override earlier ones. The fallback locale must be present.
+func Load(fallback string, dirs ...fs.FS) (*Catalog, error) {
+	c := &Catalog{
+		fallback: normalize(fallback),
+		messages: make(map[string]map[string]string),
+	}
+
+	for _, dir := range dirs {
+		files, err := fs.Glob(dir, "*.yaml")
+		if err != nil {
+			return nil, err
+		}
+		for _, file := range files {
+			if err := c.loadFile(dir, file); err != nil {
+				return nil, fmt.Errorf("locale file %s: %w", file, err)
+			}
+		}
+	}
+
+	if _, ok := c.messages[c.fallback]; !ok {
+		return nil, fmt.Errorf("no messages for fallback locale %q", fallback)
+	}
+	return c, nil
+}
+
+func (c *Catalog) loadFile(dir fs.FS, file string) error {
+	data, err := fs.ReadFile(dir, file)
+	if err != nil {
+		return err
+	}
+
+	var sections map[string]map[string]string
+	if err := yaml.Unmarshal(data, &sections); err != nil {
+		return err
+	}
+
+	locale := normalize(strings.TrimSuffix(path.Base(file), ".yaml"))
+	messages, ok := c.messages[locale]
+	if !ok {
+		messages = make(map[string]string)
+		c.messages[locale] = messages
+	}
+	for section, entries := range sections {
+		for key, message := range entries {
+			messages[section+"."+key] = message
+		}
+	}
+	return nil
+}
+
+// Fallback returns the locale used when a client's languages aren't available
+func (c *Catalog) Fallback() string {
+	return c.fallback
+}
+
+// Locales returns the available locales, sorted
+func (c *Catalog) Locales() []string {
+	locales := make([]string, 0, len(c.messages))
+	for locale := range c.messages {
+		locales = append(locales, locale)
+	}
+	sort.Strings(locales)
+	return locales
+}
+
+// Match returns the available locale for a language tag such as "de-AT", trying the tag
+// itself before its base language
+func (c *Catalog) Match(tag string) (string, bool) {
+	tag = normalize(tag)
+	if tag == "" {
+		return "", false
+	}
+	if _, ok := c.messages[tag]; ok {
+		return tag, true
+	}
+	if base, _, found := strings.Cut(tag, "-"); found {
+		if _, ok := c.messages[base]; ok {
+			return base, true
+		}
+	}
+	return "", false
+}
+
+// Negotiate picks the locale for a client: an explicitly requested locale wins, then the
+// languages of an Accept-Language header by preference, then the fallback
+func (c *Catalog) Negotiate(requested, acceptLanguage string) string {
+	if locale, ok := c.Match(requested); ok {
+		return locale
+	}
+	for _, tag := range parseAcceptLanguage(acceptLanguage) {
+		if locale, ok := c.Match(tag); ok {
+			return locale
+		}
+	}
+	return c.fallback
+}
+
+// Lookup returns a message in exactly the given locale, with its parameters filled in
+func (c *Catalog) Lookup(locale, key string, params map[string]string) (string, bool) {
+	message, ok := c.messages[locale][key]
+	if !ok {
+		return "", false
+	}
+	return format(message, params), true
+}
+
+// Render returns a message in the given locale, falling back to the fallback locale
+func (c *Catalog) Render(locale, key string, params map[string]string) (string, bool) {
+	if message, ok := c.Lookup(locale, key, params); ok {
+		return message, true
+	}
+	return c.Lookup(c.fallback, key, params)
+}
+
+// format replaces {name} placeholders; unknown placeholders are left as they are
+func format(message string, params map[string]string) string {
+	if len(params) == 0 {
+		return message
+	}
+	pairs := make([]string, 0, len(params)*2)
+	for name, value := range params {
+		pairs = append(pairs, "{"+name+"}", value)
+	}
+	return strings.NewReplacer(pairs...).Replace(message)
+}
+
+// normalize lowercases a language tag and uses dashes, so "pt_BR" matches a pt-br.yaml file
+func normalize(tag string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(tag), "_", "-"))
+}
+
+// parseAcceptLanguage returns the tags of an Accept-Language header, most preferred first
+func parseAcceptLanguage(header string) []string {
+	type weighted struct {
+		tag string
+		q   float64
+	}
+
+	var tags []weighted
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(part, ";")
+		tag = strings.TrimSpace(tag)
+		if tag == "" || tag == "*" {
+			continue
+		}
+
+		q := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+		if q <= 0 {
+			continue
+		}
+		tags = append(tags, weighted{tag: tag, q: q})
+	}
+
+	// Equal weights keep the order they were listed in
+	sort.SliceStable(tags, func(i, j int) bool {
+		return tags[i].q > tags[j].q
+	})
+
+	result := make([]string, len(tags))
+	for i, t := range tags {
+		result[i] = t.tag
+	}
+	return result
+}
@@ -0,0 +1,82 @@
+package i18n
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testCatalog(t *testing.T) *Catalog {
+	c, err := Load("en", fstest.MapFS{
+		"en.yaml":    {Data: []byte("system:\n  user_joined: \"{name} joined the room\"\n  bye: bye\n")},
+		"de.yaml":    {Data: []byte("system:\n  user_joined: \"{name} hat den Raum betreten\"\nerrors:\n  NOT_IN_ROOM: Tritt zuerst einem Raum bei\n")},
+		"pt_BR.yaml": {Data: []byte("system:\n  user_joined: \"{name} entrou na sala\"\n")},
+	})
+	assert.NoError(t, err)
+	return c
+}
+
+func TestCatalog_Render(t *testing.T) {
+	c := testCatalog(t)
+	params := map[string]string{"name": "Alice"}
+
+	message, ok := c.Render("de", "system.user_joined", params)
+	assert.True(t, ok)
+	assert.Equal(t, "Alice hat den Raum betreten", message)
+
+	// Missing messages fall back to the fallback locale
+	message, ok = c.Render("de", "system.bye", nil)
+	assert.True(t, ok)
+	assert.Equal(t, "bye", message)
+
+	_, ok = c.Render("de", "system.unknown", nil)
+	assert.False(t, ok)
+
+	// Lookup doesn't fall back
+	_, ok = c.Lookup("en", "errors.NOT_IN_ROOM", nil)
+	assert.False(t, ok)
+	message, ok = c.Lookup("de", "errors.NOT_IN_ROOM", nil)
+	assert.True(t, ok)
+	assert.Equal(t, "Tritt zuerst einem Raum bei", message)
+
+	// Unknown placeholders are left alone
+	message, _ = c.Render("en", "system.user_joined", map[string]string{"other": "x"})
+	assert.Equal(t, "{name} joined the room", message)
+}
+
+func TestCatalog_Negotiate(t *testing.T) {
+	c := testCatalog(t)
+	assert.Equal(t, []string{"de", "en", "pt-br"}, c.Locales())
+
+	assert.Equal(t, "de", c.Negotiate("", "fr-CH, de-AT;q=0.8, en;q=0.5"))
+	assert.Equal(t, "en", c.Negotiate("", "de;q=0.2, en"))
+	assert.Equal(t, "pt-br", c.Negotiate("", "pt-BR"))
+	assert.Equal(t, "en", c.Negotiate("", "fr, *;q=0.1"))
+	assert.Equal(t, "en", c.Negotiate("", "de;q=0, garbage;q=x"))
+
+	// An explicit locale wins over the header
+	assert.Equal(t, "de", c.Negotiate("DE", "en"))
+	assert.Equal(t, "en", c.Negotiate("xx", "en"))
+
+	_, ok := c.Match("fr")
+	assert.False(t, ok)
+}
+
+func TestLoad(t *testing.T) {
+	// Later directories override earlier ones
+	c, err := Load("en", Builtin(), fstest.MapFS{
+		"en.yaml": {Data: []byte("system:\n  user_left: \"{name} is gone\"\n")},
+	})
+	assert.NoError(t, err)
+	message, _ := c.Render("en", "system.user_left", map[string]string{"name": "Bob"})
+	assert.Equal(t, "Bob is gone", message)
+	message, _ = c.Render("de", "system.user_joined", map[string]string{"name": "Bob"})
+	assert.Equal(t, "Bob hat den Raum betreten", message)
+
+	_, err = Load("fr", Builtin())
+	assert.Error(t, err)
+
+	_, err = Load("en", fstest.MapFS{"en.yaml": {Data: []byte("system: [broken")}})
+	assert.Error(t, err)
+}
@@ -0,0 +1,89 @@
+package ratelimit
+
+import (
+	"sync"
+	"time"
+)
+
+// Limit describes a token bucket: Burst tokens at most, refilled at Rate tokens per second
+type Limit struct {
+	Burst int
+	Rate  float64
+}
+
+// Enabled reports whether the limit restricts anything
+func (l Limit) Enabled() bool {
+	return l.Burst > 0 && l.Rate > 0
+}
+
+// bucket is one key's token bucket
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// refill adds the tokens earned since the last update
+func (b *bucket) refill(limit Limit, now time.Time) {
+	if elapsed := now.Sub(b.last).Seconds(); elapsed > 0 {
+		b.tokens += elapsed * limit.Rate
+		if b.tokens > float64(limit.Burst) {
+			b.tokens = float64(limit.Burst)
+		}
+	}
+	b.last = now
+}
+
+// Limiter keeps a token bucket per key
+type Limiter struct {
+	limit   Limit
+	buckets map[string]*bucket
+	mu      sync.Mutex
+}
+
+// NewLimiter creates a limiter applying the same limit to every key
+func NewLimiter(limit Limit) *Limiter {
+	return &Limiter{
+		limit:   limit,
+		buckets: make(map[string]*bucket),
+	}
+}
+
+// Allow takes a token for key, returning false and how long until one is available when there is none
+func (l *Limiter) Allow(key string, now time.Time) (bool, time.Duration) {
+	if !l.limit.Enabled() {
+		return true, 0
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	b, exists := l.buckets[key]
+	if !exists {
+		b = &bucket{tokens: float64(l.limit.Burst), last: now}
+		l.buckets[key] = b
+	}
+	b.refill(l.limit, now)
+
+	if b.tokens < 1 {
+		wait := time.Duration((1 - b.tokens) / l.limit.Rate * float64(time.Second))
+		return false, wait
+	}
+	b.tokens--
+	return true, 0
+}
+
+// Cleanup drops buckets that have refilled completely and returns how many were removed
+func (l *Limiter) Cleanup(now time.Time) int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	removed := 0
+	for key, b := range l.buckets {
+		b.refill(l.limit, now)
+		if b.tokens >= float64(l.limit.Burst) {
+			delete(l.buckets, key)
+			removed++
+		}
+	}
+	return removed
+}
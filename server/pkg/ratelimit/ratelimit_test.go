@@ -0,0 +1,54 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLimiter_Allow(t *testing.T) {
+	l := NewLimiter(Limit{Burst: 3, Rate: 1})
+	now := time.Now()
+
+	for i := 0; i < 3; i++ {
+		ok, _ := l.Allow("a", now)
+		assert.True(t, ok)
+	}
+
+	ok, wait := l.Allow("a", now)
+	assert.False(t, ok)
+	assert.Equal(t, time.Second, wait)
+
+	// Other keys have their own bucket
+	ok, _ = l.Allow("b", now)
+	assert.True(t, ok)
+
+	// Tokens refill over time
+	ok, wait = l.Allow("a", now.Add(500*time.Millisecond))
+	assert.False(t, ok)
+	assert.Equal(t, 500*time.Millisecond, wait)
+
+	ok, _ = l.Allow("a", now.Add(time.Second))
+	assert.True(t, ok)
+}
+
+func TestLimiter_Disabled(t *testing.T) {
+	l := NewLimiter(Limit{})
+	for i := 0; i < 100; i++ {
+		ok, _ := l.Allow("a", time.Now())
+		assert.True(t, ok)
+	}
+}
+
+func TestLimiter_Cleanup(t *testing.T) {
+	l := NewLimiter(Limit{Burst: 2, Rate: 1})
+	now := time.Now()
+
+	l.Allow("a", now)
+	l.Allow("b", now)
+	l.Allow("b", now)
+
+	assert.Equal(t, 1, l.Cleanup(now.Add(time.Second)))
+	assert.Equal(t, 1, l.Cleanup(now.Add(2*time.Second)))
+}